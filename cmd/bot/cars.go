@@ -0,0 +1,156 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/config"
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"github.com/athebyme/forza-top-gear-bot/internal/parser"
+	"github.com/athebyme/forza-top-gear-bot/internal/repository"
+)
+
+// carsUsage - справка по подкоманде cars
+const carsUsage = `Использование:
+  forza-bot [-config путь] cars export [-format csv|json] <файл>  выгрузить каталог машин
+  forza-bot [-config путь] cars import [-format csv|json] [-prune] <файл>  загрузить каталог машин
+  forza-bot [-config путь] cars wiki  обновить каталог машин с вики`
+
+// runCarsCommand выполняет подкоманду работы с каталогом машин
+func runCarsCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("не указано действие\n%s", carsUsage)
+	}
+
+	repo := repository.NewCarRepository(db)
+
+	switch args[0] {
+	case "export":
+		return exportCars(repo, args[1:])
+	case "import":
+		return importCars(repo, args[1:])
+	case "wiki":
+		cars, err := parser.LoadCars(cfg.Cars.Source)
+		if err != nil {
+			return err
+		}
+
+		report, err := repo.Import(cars, true, cfg.Cars.Source, 0)
+		if err != nil {
+			return err
+		}
+
+		printCarImportReport(report)
+		return nil
+	default:
+		return fmt.Errorf("неизвестное действие %q\n%s", args[0], carsUsage)
+	}
+}
+
+// catalogFileArgs разбирает общие аргументы импорта и экспорта: формат и путь к файлу
+func catalogFileArgs(flags *flag.FlagSet, args []string) (path, format string, err error) {
+	formatFlag := flags.String("format", "", "Формат файла: csv или json (по умолчанию - по расширению)")
+	if err := flags.Parse(args); err != nil {
+		return "", "", err
+	}
+
+	if flags.NArg() != 1 {
+		return "", "", fmt.Errorf("укажите путь к файлу\n%s", carsUsage)
+	}
+
+	path = flags.Arg(0)
+	format = *formatFlag
+	if format == "" {
+		format, err = parser.FormatFromName(path)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return path, format, nil
+}
+
+// exportCars выгружает каталог машин в файл
+func exportCars(repo *repository.CarRepository, args []string) error {
+	path, format, err := catalogFileArgs(flag.NewFlagSet("export", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	cars, err := repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла: %v", err)
+	}
+	defer file.Close()
+
+	if err := parser.WriteCatalog(file, format, cars); err != nil {
+		return err
+	}
+
+	log.Printf("Выгружено машин: %d в %s", len(cars), path)
+	return nil
+}
+
+// importCars загружает каталог машин из файла. Если в файле есть ошибки, ничего не сохраняется.
+func importCars(repo *repository.CarRepository, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	prune := flags.Bool("prune", false, "Вывести из каталога машины, которых нет в файле")
+
+	path, format, err := catalogFileArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия файла: %v", err)
+	}
+	defer file.Close()
+
+	cars, rowErrors, err := parser.ReadCatalog(file, format)
+	if err != nil {
+		return err
+	}
+
+	if len(rowErrors) > 0 {
+		for _, rowErr := range rowErrors {
+			log.Printf("Ошибка в файле: %v", rowErr)
+		}
+		return fmt.Errorf("в файле ошибок: %d, каталог не изменен", len(rowErrors))
+	}
+
+	if len(cars) == 0 {
+		return fmt.Errorf("в файле нет ни одной машины")
+	}
+
+	report, err := repo.Import(cars, *prune, "файл "+filepath.Base(path), 0)
+	if err != nil {
+		return err
+	}
+
+	printCarImportReport(report)
+	return nil
+}
+
+// printCarImportReport выводит итоги импорта в лог
+func printCarImportReport(report *models.CarImportReport) {
+	log.Printf("Версия каталога #%d: машин в источнике: %d, добавлено: %d, изменено: %d, выведено: %d, без изменений: %d",
+		report.VersionID, report.Total, report.Added, report.Updated, report.Removed, report.Unchanged)
+
+	if report.Skipped > 0 {
+		log.Printf("Пропущено повторов: %d", report.Skipped)
+	}
+
+	for _, change := range report.Changes {
+		log.Println(change)
+	}
+}
@@ -71,6 +71,18 @@ func main() {
 	}
 	log.Println("Миграции успешно применены")
 
+	// Подкоманда работы с каталогом машин выполняется без запуска бота
+	if args := flag.Args(); len(args) > 0 {
+		if args[0] != "cars" {
+			log.Fatalf("Неизвестная подкоманда %q\n%s", args[0], carsUsage)
+		}
+
+		if err := runCarsCommand(cfg, database.GetDB(), args[1:]); err != nil {
+			log.Fatalf("Ошибка работы с каталогом машин: %v", err)
+		}
+		return
+	}
+
 	// Инициализируем бота
 	log.Println("Инициализация Telegram бота...")
 	bot, err := telegram.New(cfg, database.GetDB())
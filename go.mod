@@ -5,5 +5,6 @@ go 1.23.1
 require (
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
 	github.com/lib/pq v1.10.9
+	golang.org/x/net v0.30.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -9,6 +9,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultCarsSource - страница вики с таблицей машин Forza Horizon 4
+const DefaultCarsSource = "https://forza.fandom.com/wiki/Forza_Horizon_4/Cars"
+
 // Config представляет структуру конфигурации приложения
 type Config struct {
 	Bot struct {
@@ -29,6 +32,11 @@ type Config struct {
 		Users []int64 `yaml:"users"`
 	} `yaml:"admin"`
 
+	Cars struct {
+		// URL страницы вики с таблицей машин или путь к сохраненному HTML-файлу
+		Source string `yaml:"source"`
+	} `yaml:"cars"`
+
 	// Добавлено для работы с Docker
 	IsDockerized bool `yaml:"is_dockerized"`
 }
@@ -95,6 +103,11 @@ func Load(path string) (*Config, error) {
 		config.Database.SSLMode = "disable"
 	}
 
+	// По умолчанию каталог машин загружается со страницы вики
+	if config.Cars.Source == "" {
+		config.Cars.Source = DefaultCarsSource
+	}
+
 	return config, nil
 }
 
@@ -144,4 +144,256 @@ var migrations = []string{
 	`CREATE INDEX IF NOT EXISTS idx_cars_class_letter ON cars(class_letter)`,
 	`CREATE INDEX IF NOT EXISTS idx_race_car_assignments_race_id ON race_car_assignments(race_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_race_car_assignments_driver_id ON race_car_assignments(driver_id)`,
+
+	// Таблица очков сезона (если строки нет, действуют правила по умолчанию 3/2/1)
+	`CREATE TABLE IF NOT EXISTS season_scoring (
+		season_id INTEGER PRIMARY KEY REFERENCES seasons(id) ON DELETE CASCADE,
+		place_points JSONB NOT NULL,
+		participation_points INTEGER DEFAULT 0,
+		reroll_penalty INTEGER DEFAULT 1,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+
+	// Добавление times к race_results (время в миллисекундах по дисциплинам на время)
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT FROM information_schema.columns 
+			WHERE table_schema = 'public'
+			AND table_name = 'race_results'
+			AND column_name = 'times'
+		) THEN
+			ALTER TABLE race_results
+			ADD COLUMN times JSONB NOT NULL DEFAULT '{}';
+		END IF;
+	END $$;`,
+
+	// Добавление статуса подтверждения к race_results (существующие результаты считаются подтвержденными)
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT FROM information_schema.columns 
+			WHERE table_schema = 'public'
+			AND table_name = 'race_results'
+			AND column_name = 'confirmation_status'
+		) THEN
+			ALTER TABLE race_results
+			ADD COLUMN confirmation_status VARCHAR(20) NOT NULL DEFAULT 'confirmed'
+			CHECK (confirmation_status IN ('pending', 'confirmed'));
+			ALTER TABLE race_results ADD COLUMN confirmed_by BIGINT;
+			ALTER TABLE race_results ADD COLUMN confirmed_at TIMESTAMP;
+		END IF;
+	END $$;`,
+
+	// Таблица скриншотов результатов по дисциплинам
+	`CREATE TABLE IF NOT EXISTS result_screenshots (
+		id SERIAL PRIMARY KEY,
+		result_id INTEGER REFERENCES race_results(id) ON DELETE CASCADE,
+		discipline VARCHAR(100) NOT NULL,
+		file_id TEXT NOT NULL,
+		uploaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(result_id, discipline)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_race_results_confirmation_status ON race_results(confirmation_status)`,
+
+	// Таблица штрафов стюардов: снятие очков, потеря позиций, дисквалификация из дисциплины
+	`CREATE TABLE IF NOT EXISTS race_penalties (
+		id SERIAL PRIMARY KEY,
+		result_id INTEGER NOT NULL REFERENCES race_results(id) ON DELETE CASCADE,
+		type VARCHAR(20) NOT NULL CHECK (type IN ('points', 'position', 'dsq')),
+		discipline VARCHAR(100) NOT NULL DEFAULT '',
+		value INTEGER NOT NULL DEFAULT 0,
+		reason TEXT NOT NULL,
+		issued_by BIGINT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_race_penalties_result_id ON race_penalties(result_id)`,
+
+	// Статусы дисциплин DNS/DNF/DSQ в результатах и очки за них в таблице очков сезона
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT FROM information_schema.columns 
+			WHERE table_schema = 'public'
+			AND table_name = 'race_results'
+			AND column_name = 'statuses'
+		) THEN
+			ALTER TABLE race_results
+			ADD COLUMN statuses JSONB NOT NULL DEFAULT '{}';
+		END IF;
+	END $$;`,
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT FROM information_schema.columns 
+			WHERE table_schema = 'public'
+			AND table_name = 'season_scoring'
+			AND column_name = 'dns_points'
+		) THEN
+			ALTER TABLE season_scoring ADD COLUMN dns_points INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE season_scoring ADD COLUMN dnf_points INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE season_scoring ADD COLUMN dsq_points INTEGER NOT NULL DEFAULT 0;
+		END IF;
+	END $$;`,
+
+	// Бюллетени голосования в судейских дисциплинах: один бюллетень гонщика на дисциплину гонки
+	`CREATE TABLE IF NOT EXISTS race_votes (
+		id SERIAL PRIMARY KEY,
+		race_id INTEGER NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+		discipline VARCHAR(100) NOT NULL,
+		voter_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		ranking JSONB NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(race_id, discipline, voter_id)
+	)`,
+
+	// Рейтинг Эло гонщиков и история его изменений по гонкам
+	`CREATE TABLE IF NOT EXISTS driver_ratings (
+		driver_id INTEGER PRIMARY KEY REFERENCES drivers(id) ON DELETE CASCADE,
+		rating DOUBLE PRECISION NOT NULL DEFAULT 1500,
+		races INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS rating_history (
+		id SERIAL PRIMARY KEY,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		race_id INTEGER NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+		rating_before DOUBLE PRECISION NOT NULL,
+		rating_after DOUBLE PRECISION NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(driver_id, race_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_rating_history_driver_id ON rating_history(driver_id)`,
+
+	// Достижения гонщиков: каждое выдается один раз, чемпион сезона - один раз за сезон (season_id = 0 для остальных)
+	`CREATE TABLE IF NOT EXISTS driver_achievements (
+		id SERIAL PRIMARY KEY,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		code VARCHAR(50) NOT NULL,
+		season_id INTEGER NOT NULL DEFAULT 0,
+		race_id INTEGER REFERENCES races(id) ON DELETE SET NULL,
+		earned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(driver_id, code, season_id)
+	)`,
+
+	// Снимки турнирной таблицы после каждой завершенной гонки (season_id = 0 - таблица по всем сезонам)
+	`CREATE TABLE IF NOT EXISTS standings_snapshots (
+		season_id INTEGER NOT NULL,
+		race_id INTEGER NOT NULL REFERENCES races(id) ON DELETE CASCADE,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		points INTEGER NOT NULL,
+		gap INTEGER NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (season_id, race_id, driver_id)
+	)`,
+
+	// Команды сезона и их составы: гонщик может состоять только в одной команде сезона
+	`CREATE TABLE IF NOT EXISTS teams (
+		id SERIAL PRIMARY KEY,
+		season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+		name VARCHAR(100) NOT NULL,
+		badge VARCHAR(20) NOT NULL DEFAULT '🛡',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(season_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_members (
+		team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+		season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (season_id, driver_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_team_members_team_id ON team_members(team_id)`,
+
+	// Дивизионы сезона: tier 1 - высший дивизион, swap_count - сколько гонщиков меняются с дивизионом ниже
+	`CREATE TABLE IF NOT EXISTS divisions (
+		id SERIAL PRIMARY KEY,
+		season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+		name VARCHAR(100) NOT NULL,
+		tier INTEGER NOT NULL,
+		swap_count INTEGER NOT NULL DEFAULT 2,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(season_id, tier)
+	)`,
+	`CREATE TABLE IF NOT EXISTS division_members (
+		division_id INTEGER NOT NULL REFERENCES divisions(id) ON DELETE CASCADE,
+		season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (season_id, driver_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_division_members_division_id ON division_members(division_id)`,
+
+	// Дивизион гонки: гонки без записи открыты для всех гонщиков
+	`CREATE TABLE IF NOT EXISTS race_divisions (
+		race_id INTEGER PRIMARY KEY REFERENCES races(id) ON DELETE CASCADE,
+		division_id INTEGER NOT NULL REFERENCES divisions(id) ON DELETE CASCADE
+	)`,
+
+	// Повышения и понижения по итогам завершенного сезона
+	`CREATE TABLE IF NOT EXISTS division_moves (
+		season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		from_division_id INTEGER NOT NULL REFERENCES divisions(id) ON DELETE CASCADE,
+		to_division_id INTEGER NOT NULL REFERENCES divisions(id) ON DELETE CASCADE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (season_id, driver_id)
+	)`,
+
+	// Досрочно обеспеченные титулы: сообщение о чемпионе рассылается один раз
+	`CREATE TABLE IF NOT EXISTS title_clinches (
+		season_id INTEGER PRIMARY KEY REFERENCES seasons(id) ON DELETE CASCADE,
+		driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+		race_id INTEGER REFERENCES races(id) ON DELETE SET NULL,
+		clinched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+
+	// Версии каталога машин: каждый импорт создает версию с итогами
+	`CREATE TABLE IF NOT EXISTS car_catalog_versions (
+		id SERIAL PRIMARY KEY,
+		source TEXT NOT NULL,
+		created_by BIGINT,
+		total INTEGER NOT NULL DEFAULT 0,
+		added INTEGER NOT NULL DEFAULT 0,
+		updated INTEGER NOT NULL DEFAULT 0,
+		removed INTEGER NOT NULL DEFAULT 0,
+		unchanged INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
+
+	// Версии, в которых машина появилась и была выведена из каталога. Выведенные машины
+	// не удаляются, чтобы назначения прошлых гонок указывали на неизменные записи
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT FROM information_schema.columns
+			WHERE table_schema = 'public'
+			AND table_name = 'cars'
+			AND column_name = 'retired_version_id'
+		) THEN
+			ALTER TABLE cars ADD COLUMN added_version_id INTEGER REFERENCES car_catalog_versions(id) ON DELETE SET NULL;
+			ALTER TABLE cars ADD COLUMN retired_version_id INTEGER REFERENCES car_catalog_versions(id) ON DELETE SET NULL;
+		END IF;
+	END $$;`,
+	`CREATE INDEX IF NOT EXISTS idx_cars_retired_version_id ON cars(retired_version_id)`,
+
+	// Журнал изменений машин в каждой версии каталога
+	`CREATE TABLE IF NOT EXISTS car_catalog_changes (
+		id SERIAL PRIMARY KEY,
+		version_id INTEGER NOT NULL REFERENCES car_catalog_versions(id) ON DELETE CASCADE,
+		car_id INTEGER REFERENCES cars(id) ON DELETE SET NULL,
+		previous_car_id INTEGER REFERENCES cars(id) ON DELETE SET NULL,
+		change_type VARCHAR(10) NOT NULL,
+		car_name TEXT NOT NULL,
+		details TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_car_catalog_changes_version_id ON car_catalog_changes(version_id)`,
+
+	// Пул машин гонки: фильтры поверх класса, из которых выдаются и перевыдаются машины
+	`CREATE TABLE IF NOT EXISTS race_car_pools (
+		race_id INTEGER PRIMARY KEY REFERENCES races(id) ON DELETE CASCADE,
+		filters JSONB NOT NULL DEFAULT '{}',
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`,
 }
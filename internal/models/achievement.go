@@ -0,0 +1,185 @@
+package models
+
+// Коды достижений
+const (
+	AchievementFirstRace      = "first_race"
+	AchievementFirstWin       = "first_win"
+	AchievementHatTrick       = "hat_trick"
+	AchievementRaceWin        = "race_win"
+	AchievementRerollComeback = "reroll_comeback"
+	AchievementPodiumSweep    = "podium_sweep"
+	AchievementVeteran        = "veteran"
+	AchievementSeasonChampion = "season_champion"
+)
+
+// Пороги достижений
+const (
+	hatTrickWins      = 3  // побед в дисциплинах одной гонки для хет-трика
+	podiumSweepMin    = 3  // минимум дисциплин гонки для "Всегда на подиуме"
+	veteranRaceCount  = 10 // завершенных гонок для "Ветерана"
+	podiumLowestPlace = 3
+)
+
+// AchievementContext содержит данные для проверки достижений гонщика по итогам одной гонки.
+// Места и статусы результатов уже учитывают штрафы стюардов.
+type AchievementContext struct {
+	Result      *RaceResult   // результат гонщика
+	RaceResults []*RaceResult // все подтвержденные результаты гонки, включая результат гонщика
+	Races       int           // завершенных гонок гонщика, включая эту
+}
+
+// AchievementRule описывает правило выдачи достижения по итогам гонки
+type AchievementRule struct {
+	Code        string
+	Emoji       string
+	Name        string
+	Description string
+	Check       func(ctx *AchievementContext) bool
+}
+
+// seasonChampionInfo описывает достижение чемпиона сезона, которое выдается по итогам сезона, а не гонки
+var seasonChampionInfo = AchievementRule{
+	Code:        AchievementSeasonChampion,
+	Emoji:       "👑",
+	Name:        "Чемпион сезона",
+	Description: "Больше всех очков по итогам сезона",
+}
+
+// AchievementRules перечисляет правила, проверяемые после каждой завершенной гонки
+var AchievementRules = []AchievementRule{
+	{
+		Code:        AchievementFirstRace,
+		Emoji:       "🏁",
+		Name:        "Дебют",
+		Description: "Первая завершенная гонка",
+		Check: func(ctx *AchievementContext) bool {
+			return ctx.Races >= 1
+		},
+	},
+	{
+		Code:        AchievementFirstWin,
+		Emoji:       "🥇",
+		Name:        "Первая победа",
+		Description: "Первое место в дисциплине",
+		Check: func(ctx *AchievementContext) bool {
+			return disciplineWins(ctx.Result) >= 1
+		},
+	},
+	{
+		Code:        AchievementHatTrick,
+		Emoji:       "🎩",
+		Name:        "Хет-трик",
+		Description: "Первое место в трех дисциплинах одной гонки",
+		Check: func(ctx *AchievementContext) bool {
+			return disciplineWins(ctx.Result) >= hatTrickWins
+		},
+	},
+	{
+		Code:        AchievementRaceWin,
+		Emoji:       "🏆",
+		Name:        "Победитель гонки",
+		Description: "Больше всех очков в гонке",
+		Check: func(ctx *AchievementContext) bool {
+			return isRaceWinner(ctx)
+		},
+	},
+	{
+		Code:        AchievementRerollComeback,
+		Emoji:       "🎲",
+		Name:        "Камбэк после реролла",
+		Description: "Победа в гонке несмотря на штраф за реролл",
+		Check: func(ctx *AchievementContext) bool {
+			return ctx.Result.RerollPenalty > 0 && isRaceWinner(ctx)
+		},
+	},
+	{
+		Code:        AchievementPodiumSweep,
+		Emoji:       "🔥",
+		Name:        "Всегда на подиуме",
+		Description: "Места с 1 по 3 во всех дисциплинах гонки (от трех дисциплин)",
+		Check: func(ctx *AchievementContext) bool {
+			if len(ctx.Result.Results) < podiumSweepMin {
+				return false
+			}
+			for discipline, place := range ctx.Result.Results {
+				if ctx.Result.Statuses[discipline] != "" || place < 1 || place > podiumLowestPlace {
+					return false
+				}
+			}
+			return true
+		},
+	},
+	{
+		Code:        AchievementVeteran,
+		Emoji:       "🎖",
+		Name:        "Ветеран",
+		Description: "10 завершенных гонок",
+		Check: func(ctx *AchievementContext) bool {
+			return ctx.Races >= veteranRaceCount
+		},
+	},
+}
+
+// disciplineWins возвращает число первых мест результата
+func disciplineWins(result *RaceResult) int {
+	wins := 0
+	for discipline, place := range result.Results {
+		if place == 1 && result.Statuses[discipline] == "" {
+			wins++
+		}
+	}
+	return wins
+}
+
+// isRaceWinner проверяет, набрал ли гонщик больше всех очков в гонке (при равенстве побеждают все лидеры)
+func isRaceWinner(ctx *AchievementContext) bool {
+	if ctx.Result.TotalScore <= 0 {
+		return false
+	}
+	for _, result := range ctx.RaceResults {
+		if result.TotalScore > ctx.Result.TotalScore {
+			return false
+		}
+	}
+	return true
+}
+
+// EvaluateAchievements возвращает коды достижений, условия которых выполнены в контексте гонки
+func EvaluateAchievements(ctx *AchievementContext) []string {
+	var codes []string
+	for _, rule := range AchievementRules {
+		if rule.Check(ctx) {
+			codes = append(codes, rule.Code)
+		}
+	}
+	return codes
+}
+
+// FindAchievementRule возвращает описание достижения по коду
+func FindAchievementRule(code string) (AchievementRule, bool) {
+	if code == AchievementSeasonChampion {
+		return seasonChampionInfo, true
+	}
+	for _, rule := range AchievementRules {
+		if rule.Code == code {
+			return rule, true
+		}
+	}
+	return AchievementRule{}, false
+}
+
+// NewAchievement формирует достижение для отображения по коду и дате получения
+func NewAchievement(code, dateEarned string) Achievement {
+	rule, ok := FindAchievementRule(code)
+	if !ok {
+		return Achievement{Code: code, Name: code, DateEarned: dateEarned}
+	}
+
+	return Achievement{
+		Code:        rule.Code,
+		Emoji:       rule.Emoji,
+		Name:        rule.Name,
+		Description: rule.Description,
+		DateEarned:  dateEarned,
+	}
+}
@@ -0,0 +1,213 @@
+package models
+
+import "sort"
+
+// DisciplineBreakdown представляет статистику гонщика в одной дисциплине
+type DisciplineBreakdown struct {
+	DriverID     int     `json:"driver_id"`
+	DriverName   string  `json:"driver_name"`
+	Discipline   string  `json:"discipline"`
+	Entries      int     `json:"entries"`       // участий в дисциплине
+	Finishes     int     `json:"finishes"`      // участий с местом
+	AveragePlace float64 `json:"average_place"` // 0, если нет ни одного места
+	Wins         int     `json:"wins"`
+	Podiums      int     `json:"podiums"`
+	Statuses     int     `json:"statuses"` // дисциплины со статусом DNS/DNF/DSQ
+}
+
+// ClassBreakdown представляет статистику гонщика в гонках одного класса машин
+type ClassBreakdown struct {
+	DriverID     int     `json:"driver_id"`
+	DriverName   string  `json:"driver_name"`
+	CarClass     string  `json:"car_class"`
+	Races        int     `json:"races"`
+	Points       int     `json:"points"`
+	Finishes     int     `json:"finishes"`
+	AveragePlace float64 `json:"average_place"` // среднее место по всем дисциплинам, 0 - нет мест
+	Wins         int     `json:"wins"`          // первые места в дисциплинах
+	Podiums      int     `json:"podiums"`       // места с 1 по 3 в дисциплинах
+}
+
+// AveragePoints возвращает среднее число очков за гонку
+func (c *ClassBreakdown) AveragePoints() float64 {
+	if c.Races == 0 {
+		return 0
+	}
+	return float64(c.Points) / float64(c.Races)
+}
+
+// disciplineOrder возвращает позицию дисциплины в стандартном списке (нестандартные - в конце)
+func disciplineOrder(discipline string) int {
+	for i, d := range DefaultDisciplines {
+		if d == discipline {
+			return i
+		}
+	}
+	return len(DefaultDisciplines)
+}
+
+// classOrder возвращает позицию класса в списке от D до X (неизвестные - в конце)
+func classOrder(letter string) int {
+	for i, class := range CarClasses {
+		if class.Letter == letter {
+			return i
+		}
+	}
+	return len(CarClasses)
+}
+
+// SortDisciplineBreakdown упорядочивает статистику по дисциплинам в порядке стандартного списка
+func SortDisciplineBreakdown(items []*DisciplineBreakdown) {
+	sort.SliceStable(items, func(i, j int) bool {
+		oi, oj := disciplineOrder(items[i].Discipline), disciplineOrder(items[j].Discipline)
+		if oi != oj {
+			return oi < oj
+		}
+		return items[i].Discipline < items[j].Discipline
+	})
+}
+
+// SortClassBreakdown упорядочивает статистику по классам от D до X
+func SortClassBreakdown(items []*ClassBreakdown) {
+	sort.SliceStable(items, func(i, j int) bool {
+		oi, oj := classOrder(items[i].CarClass), classOrder(items[j].CarClass)
+		if oi != oj {
+			return oi < oj
+		}
+		return items[i].CarClass < items[j].CarClass
+	})
+}
+
+// breakdownKey идентифицирует строку статистики: гонщик и дисциплина или класс машин
+type breakdownKey struct {
+	driverID int
+	group    string
+}
+
+// CalculateDisciplineBreakdown считает статистику гонщиков по дисциплинам из подтвержденных результатов.
+// Места и статусы учитывают штрафы стюардов (penalties: ID результата -> штрафы), которые сдвигают
+// соседей по гонке, поэтому results содержат все подтвержденные результаты гонок.
+// Внутри дисциплины гонщики идут по алфавиту, дисциплины - в порядке стандартного списка.
+func CalculateDisciplineBreakdown(results []*RaceResult, penalties map[int][]*Penalty,
+	driverNames map[int]string) []*DisciplineBreakdown {
+	rows := make(map[breakdownKey]*DisciplineBreakdown)
+	placeSums := make(map[breakdownKey]int)
+	var items []*DisciplineBreakdown
+
+	adjusted := ApplyRacePenalties(results, penalties)
+
+	for _, result := range results {
+		places, statuses := adjusted[result.ID].Results, adjusted[result.ID].Statuses
+
+		for discipline, place := range places {
+			key := breakdownKey{driverID: result.DriverID, group: discipline}
+			item, ok := rows[key]
+			if !ok {
+				item = &DisciplineBreakdown{
+					DriverID:   result.DriverID,
+					DriverName: driverNames[result.DriverID],
+					Discipline: discipline,
+				}
+				rows[key] = item
+				items = append(items, item)
+			}
+
+			item.Entries++
+			if statuses[discipline] != "" {
+				item.Statuses++
+			}
+			if place > 0 {
+				item.Finishes++
+				placeSums[key] += place
+			}
+			if place == 1 {
+				item.Wins++
+			}
+			if place >= 1 && place <= 3 {
+				item.Podiums++
+			}
+		}
+	}
+
+	for key, item := range rows {
+		if item.Finishes > 0 {
+			item.AveragePlace = float64(placeSums[key]) / float64(item.Finishes)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].DriverName != items[j].DriverName {
+			return items[i].DriverName < items[j].DriverName
+		}
+		if items[i].DriverID != items[j].DriverID {
+			return items[i].DriverID < items[j].DriverID
+		}
+		return items[i].Discipline < items[j].Discipline
+	})
+	SortDisciplineBreakdown(items)
+
+	return items
+}
+
+// CalculateClassBreakdown считает статистику гонщиков по классам машин из подтвержденных результатов.
+// Места учитывают штрафы стюардов, очки берутся из итогового счета результата, где штрафы уже вычтены
+// (raceClasses: ID гонки -> класс машин). Внутри класса гонщики идут по алфавиту, классы - от D до X.
+func CalculateClassBreakdown(results []*RaceResult, penalties map[int][]*Penalty,
+	driverNames map[int]string, raceClasses map[int]string) []*ClassBreakdown {
+	rows := make(map[breakdownKey]*ClassBreakdown)
+	placeSums := make(map[breakdownKey]int)
+	var items []*ClassBreakdown
+
+	adjusted := ApplyRacePenalties(results, penalties)
+
+	for _, result := range results {
+		key := breakdownKey{driverID: result.DriverID, group: raceClasses[result.RaceID]}
+		item, ok := rows[key]
+		if !ok {
+			item = &ClassBreakdown{
+				DriverID:   result.DriverID,
+				DriverName: driverNames[result.DriverID],
+				CarClass:   key.group,
+			}
+			rows[key] = item
+			items = append(items, item)
+		}
+
+		item.Races++
+		item.Points += result.TotalScore
+
+		for _, place := range adjusted[result.ID].Results {
+			if place <= 0 {
+				continue
+			}
+
+			item.Finishes++
+			placeSums[key] += place
+			if place == 1 {
+				item.Wins++
+			}
+			if place <= 3 {
+				item.Podiums++
+			}
+		}
+	}
+
+	for key, item := range rows {
+		if item.Finishes > 0 {
+			item.AveragePlace = float64(placeSums[key]) / float64(item.Finishes)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].DriverName != items[j].DriverName {
+			return items[i].DriverName < items[j].DriverName
+		}
+		if items[i].DriverID != items[j].DriverID {
+			return items[i].DriverID < items[j].DriverID
+		}
+		return items[i].CarClass < items[j].CarClass
+	})
+	SortClassBreakdown(items)
+
+	return items
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateDisciplineBreakdown(t *testing.T) {
+	results := []*RaceResult{
+		{ID: 1, RaceID: 1, DriverID: 2, Results: map[string]int{"Драг": 1, "Офроад": 3}},
+		{ID: 2, RaceID: 2, DriverID: 2, Results: map[string]int{"Драг": 2, "Офроад": 0},
+			Statuses: map[string]string{"Офроад": DisciplineDNF}},
+		{ID: 3, RaceID: 1, DriverID: 1, Results: map[string]int{"Драг": 2}},
+	}
+	penalties := map[int][]*Penalty{
+		2: {{ResultID: 2, Type: PenaltyDisqualification, Discipline: "Драг"}},
+	}
+	names := map[int]string{1: "Анна", 2: "Борис"}
+
+	got := CalculateDisciplineBreakdown(results, penalties, names)
+
+	want := []DisciplineBreakdown{
+		{DriverID: 1, DriverName: "Анна", Discipline: "Драг", Entries: 1, Finishes: 1, AveragePlace: 2, Podiums: 1},
+		{DriverID: 2, DriverName: "Борис", Discipline: "Драг", Entries: 2, Finishes: 1, AveragePlace: 1, Wins: 1, Podiums: 1, Statuses: 1},
+		{DriverID: 2, DriverName: "Борис", Discipline: "Офроад", Entries: 2, Finishes: 1, AveragePlace: 3, Podiums: 1, Statuses: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("CalculateDisciplineBreakdown() returned %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(*got[i], want[i]) {
+			t.Errorf("row %d = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
+
+func TestCalculateClassBreakdown(t *testing.T) {
+	results := []*RaceResult{
+		{ID: 1, RaceID: 1, DriverID: 1, TotalScore: 5, Results: map[string]int{"Драг": 1, "Офроад": 2}},
+		{ID: 2, RaceID: 2, DriverID: 1, TotalScore: 1, Results: map[string]int{"Драг": 3, "Офроад": 1}},
+		{ID: 3, RaceID: 3, DriverID: 1, TotalScore: 3, Results: map[string]int{"Драг": 1}},
+		{ID: 4, RaceID: 2, DriverID: 2, TotalScore: 4, Results: map[string]int{"Офроад": 2}},
+	}
+	penalties := map[int][]*Penalty{
+		2: {{ResultID: 2, Type: PenaltyPosition, Discipline: "Офроад", Value: 3}},
+	}
+	names := map[int]string{1: "Анна", 2: "Борис"}
+	classes := map[int]string{1: "S1", 2: "S1", 3: "A"}
+
+	got := CalculateClassBreakdown(results, penalties, names, classes)
+
+	want := []ClassBreakdown{
+		{DriverID: 1, DriverName: "Анна", CarClass: "A", Races: 1, Points: 3, Finishes: 1, AveragePlace: 1, Wins: 1, Podiums: 1},
+		{DriverID: 1, DriverName: "Анна", CarClass: "S1", Races: 2, Points: 6, Finishes: 4, AveragePlace: 2, Wins: 1, Podiums: 4},
+		{DriverID: 2, DriverName: "Борис", CarClass: "S1", Races: 1, Points: 4, Finishes: 1, AveragePlace: 1, Wins: 1, Podiums: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("CalculateClassBreakdown() returned %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(*got[i], want[i]) {
+			t.Errorf("row %d = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
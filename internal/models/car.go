@@ -2,6 +2,9 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Car представляет автомобиль из Forza Horizon 4
@@ -23,6 +26,19 @@ type Car struct {
 	Source       string        `json:"source"`
 }
 
+// CarYearUnknown - год машины, если он не указан
+const CarYearUnknown = "нет информации"
+
+// Ограничения полей машины, как в таблице cars
+const (
+	maxCarNameLength   = 255
+	maxCarRarityLength = 50
+	maxCarClassLength  = 5
+	maxCarSourceLength = 100
+	maxCarStat         = 10
+	maxCarClassNumber  = 999
+)
+
 // CarClass представляет класс автомобиля
 type CarClass struct {
 	Letter string `json:"letter"`
@@ -66,3 +82,71 @@ func GetCarClassName(letter string) string {
 	}
 	return "Неизвестный класс"
 }
+
+// CarImportReport представляет итоги импорта каталога машин
+type CarImportReport struct {
+	VersionID int          `json:"version_id"` // версия каталога, созданная импортом
+	Total     int          `json:"total"`      // машин в источнике
+	Added     int          `json:"added"`      // новые машины
+	Updated   int          `json:"updated"`    // машины с измененными данными
+	Unchanged int          `json:"unchanged"`  // машины без изменений
+	Removed   int          `json:"removed"`    // машины, которых больше нет в источнике
+	Skipped   int          `json:"skipped"`    // повторы одной и той же машины в источнике
+	Changes   []*CarChange `json:"changes"`
+}
+
+// CarKey возвращает ключ машины для сопоставления при импорте: название и год без учета регистра
+func CarKey(name string, year sql.NullInt64) string {
+	key := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if year.Valid {
+		key += "|" + strconv.FormatInt(year.Int64, 10)
+	}
+	return key
+}
+
+// ValidateCar проверяет данные машины перед сохранением в каталог
+func ValidateCar(car *Car) error {
+	if car.Name == "" {
+		return fmt.Errorf("name: название машины не может быть пустым")
+	}
+	if len([]rune(car.Name)) > maxCarNameLength {
+		return fmt.Errorf("name: название длиннее %d символов", maxCarNameLength)
+	}
+	if car.YearRaw.Valid && (car.YearRaw.Int64 < 1900 || car.YearRaw.Int64 > 2100) {
+		return fmt.Errorf("year: год %d вне диапазона 1900-2100", car.YearRaw.Int64)
+	}
+	if car.Price < 0 {
+		return fmt.Errorf("price: цена не может быть отрицательной")
+	}
+	if len([]rune(car.Rarity)) > maxCarRarityLength {
+		return fmt.Errorf("rarity: редкость длиннее %d символов", maxCarRarityLength)
+	}
+
+	stats := map[string]float64{
+		"speed":        car.Speed,
+		"handling":     car.Handling,
+		"acceleration": car.Acceleration,
+		"launch":       car.Launch,
+		"braking":      car.Braking,
+	}
+	for _, name := range []string{"speed", "handling", "acceleration", "launch", "braking"} {
+		if stats[name] < 0 || stats[name] > maxCarStat {
+			return fmt.Errorf("%s: характеристика %.1f вне диапазона 0-%d", name, stats[name], maxCarStat)
+		}
+	}
+
+	if car.ClassLetter == "" {
+		return fmt.Errorf("class_letter: не указан класс машины")
+	}
+	if len([]rune(car.ClassLetter)) > maxCarClassLength {
+		return fmt.Errorf("class_letter: класс длиннее %d символов", maxCarClassLength)
+	}
+	if car.ClassNumber < 0 || car.ClassNumber > maxCarClassNumber {
+		return fmt.Errorf("class_number: индекс производительности %d вне диапазона 0-%d", car.ClassNumber, maxCarClassNumber)
+	}
+	if len([]rune(car.Source)) > maxCarSourceLength {
+		return fmt.Errorf("source: способ получения длиннее %d символов", maxCarSourceLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Характеристики машины, по которым можно ограничить пул
+const (
+	CarStatSpeed        = "speed"
+	CarStatHandling     = "handling"
+	CarStatAcceleration = "acceleration"
+	CarStatLaunch       = "launch"
+	CarStatBraking      = "braking"
+)
+
+// CarStat описывает характеристику машины
+type CarStat struct {
+	Key  string
+	Name string
+}
+
+// CarStats - характеристики в порядке отображения в карточке машины
+var CarStats = []CarStat{
+	{Key: CarStatSpeed, Name: "Скорость"},
+	{Key: CarStatHandling, Name: "Управление"},
+	{Key: CarStatAcceleration, Name: "Ускорение"},
+	{Key: CarStatLaunch, Name: "Старт"},
+	{Key: CarStatBraking, Name: "Торможение"},
+}
+
+// CarStatValue возвращает значение характеристики машины
+func CarStatValue(car *Car, key string) float64 {
+	switch key {
+	case CarStatSpeed:
+		return car.Speed
+	case CarStatHandling:
+		return car.Handling
+	case CarStatAcceleration:
+		return car.Acceleration
+	case CarStatLaunch:
+		return car.Launch
+	case CarStatBraking:
+		return car.Braking
+	default:
+		return 0
+	}
+}
+
+// CarStatRange - допустимые значения характеристики, 0 - без ограничения
+type CarStatRange struct {
+	Min float64 `json:"min,omitempty"`
+	Max float64 `json:"max,omitempty"`
+}
+
+// CarPoolBan - машина из бан-листа пула. Машина узнается и по ID, и по названию с годом,
+// поэтому бан сохраняется, когда импорт каталога создает для машины новую запись.
+type CarPoolBan struct {
+	CarID int    `json:"car_id"`
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+// CarPool - фильтры пула машин гонки поверх ее класса. Пустые поля не ограничивают пул.
+type CarPool struct {
+	YearFrom      int                     `json:"year_from,omitempty"`
+	YearTo        int                     `json:"year_to,omitempty"`
+	Rarities      []string                `json:"rarities,omitempty"`
+	MaxPrice      int                     `json:"max_price,omitempty"`
+	Stats         map[string]CarStatRange `json:"stats,omitempty"`
+	IncludeMakers []string                `json:"include_makers,omitempty"`
+	ExcludeMakers []string                `json:"exclude_makers,omitempty"`
+	Banned        []CarPoolBan            `json:"banned,omitempty"`
+}
+
+// IsEmpty проверяет, что пул не ограничен ничем, кроме класса
+func (p *CarPool) IsEmpty() bool {
+	return p.YearFrom == 0 && p.YearTo == 0 && len(p.Rarities) == 0 && p.MaxPrice == 0 &&
+		len(p.Stats) == 0 && len(p.IncludeMakers) == 0 && len(p.ExcludeMakers) == 0 && len(p.Banned) == 0
+}
+
+// Matches проверяет, что машина проходит все фильтры пула. Класс проверяется отдельно.
+func (p *CarPool) Matches(car *Car) bool {
+	if p.YearFrom != 0 || p.YearTo != 0 {
+		if !car.YearRaw.Valid {
+			return false
+		}
+		year := int(car.YearRaw.Int64)
+		if (p.YearFrom != 0 && year < p.YearFrom) || (p.YearTo != 0 && year > p.YearTo) {
+			return false
+		}
+	}
+
+	if len(p.Rarities) > 0 && !containsFold(p.Rarities, car.Rarity) {
+		return false
+	}
+
+	if p.MaxPrice != 0 && car.Price > p.MaxPrice {
+		return false
+	}
+
+	for key, limit := range p.Stats {
+		value := CarStatValue(car, key)
+		if (limit.Min != 0 && value < limit.Min) || (limit.Max != 0 && value > limit.Max) {
+			return false
+		}
+	}
+
+	if len(p.IncludeMakers) > 0 && !matchesAnyMaker(car.Name, p.IncludeMakers) {
+		return false
+	}
+
+	if matchesAnyMaker(car.Name, p.ExcludeMakers) {
+		return false
+	}
+
+	return !p.IsBanned(car)
+}
+
+// Filter оставляет машины, которые проходят фильтры пула
+func (p *CarPool) Filter(cars []*Car) []*Car {
+	var pool []*Car
+	for _, car := range cars {
+		if p.Matches(car) {
+			pool = append(pool, car)
+		}
+	}
+	return pool
+}
+
+// IsBanned проверяет, что машина в бан-листе пула
+func (p *CarPool) IsBanned(car *Car) bool {
+	key := CarKey(car.Name, car.YearRaw)
+	for _, ban := range p.Banned {
+		if ban.CarID == car.ID || ban.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Ban добавляет машину в бан-лист пула
+func (p *CarPool) Ban(car *Car) {
+	if p.IsBanned(car) {
+		return
+	}
+
+	p.Banned = append(p.Banned, CarPoolBan{
+		CarID: car.ID,
+		Key:   CarKey(car.Name, car.YearRaw),
+		Title: CarTitle(car.Name, car.YearRaw),
+	})
+}
+
+// Unban убирает машину из бан-листа пула
+func (p *CarPool) Unban(carID int) {
+	banned := p.Banned[:0]
+	for _, ban := range p.Banned {
+		if ban.CarID != carID {
+			banned = append(banned, ban)
+		}
+	}
+	p.Banned = banned
+}
+
+// ToggleRarity добавляет редкость в список разрешенных или убирает ее оттуда
+func (p *CarPool) ToggleRarity(rarity string) {
+	for i, allowed := range p.Rarities {
+		if strings.EqualFold(allowed, rarity) {
+			p.Rarities = append(p.Rarities[:i], p.Rarities[i+1:]...)
+			return
+		}
+	}
+	p.Rarities = append(p.Rarities, rarity)
+}
+
+// Describe перечисляет фильтры пула построчно. Пустой список - пул ограничен только классом.
+func (p *CarPool) Describe() []string {
+	var lines []string
+
+	if p.YearFrom != 0 || p.YearTo != 0 {
+		lines = append(lines, "📅 Годы: "+formatRange(float64(p.YearFrom), float64(p.YearTo), "%.0f"))
+	}
+	if len(p.Rarities) > 0 {
+		lines = append(lines, "⭐ Редкость: "+strings.Join(p.Rarities, ", "))
+	}
+	if p.MaxPrice != 0 {
+		lines = append(lines, fmt.Sprintf("💰 Цена: до %d CR", p.MaxPrice))
+	}
+	for _, stat := range CarStats {
+		if limit, ok := p.Stats[stat.Key]; ok {
+			lines = append(lines, fmt.Sprintf("📊 %s: %s", stat.Name, formatRange(limit.Min, limit.Max, "%.1f")))
+		}
+	}
+	if len(p.IncludeMakers) > 0 {
+		lines = append(lines, "🏭 Производители: "+strings.Join(p.IncludeMakers, ", "))
+	}
+	if len(p.ExcludeMakers) > 0 {
+		lines = append(lines, "🚷 Кроме производителей: "+strings.Join(p.ExcludeMakers, ", "))
+	}
+	if len(p.Banned) > 0 {
+		titles := make([]string, 0, len(p.Banned))
+		for _, ban := range p.Banned {
+			titles = append(titles, ban.Title)
+		}
+		lines = append(lines, "🚫 Запрещены: "+strings.Join(titles, ", "))
+	}
+
+	return lines
+}
+
+// formatRange форматирует диапазон, где 0 означает отсутствие границы
+func formatRange(from, to float64, format string) string {
+	switch {
+	case from != 0 && to != 0:
+		return fmt.Sprintf(format+"–"+format, from, to)
+	case from != 0:
+		return fmt.Sprintf("от "+format, from)
+	default:
+		return fmt.Sprintf("до "+format, to)
+	}
+}
+
+// containsFold проверяет, что значение есть в списке без учета регистра
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyMaker проверяет, что название машины начинается с одного из производителей.
+// Производитель совпадает целым словом: «mercedes» подходит к «Mercedes-Benz», но не к «Mercedesx».
+func matchesAnyMaker(name string, makers []string) bool {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	for _, maker := range makers {
+		maker = strings.ToLower(strings.Join(strings.Fields(maker), " "))
+		if maker == "" || !strings.HasPrefix(name, maker) {
+			continue
+		}
+
+		rest := name[len(maker):]
+		if rest == "" {
+			return true
+		}
+		if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// SerializeCarPool сериализует фильтры пула в JSON
+func SerializeCarPool(pool *CarPool) (string, error) {
+	data, err := json.Marshal(pool)
+	if err != nil {
+		return "", fmt.Errorf("ошибка сериализации пула машин: %v", err)
+	}
+	return string(data), nil
+}
+
+// DeserializeCarPool восстанавливает фильтры пула из JSON
+func DeserializeCarPool(data []byte) (*CarPool, error) {
+	pool := &CarPool{}
+	if len(data) == 0 {
+		return pool, nil
+	}
+	if err := json.Unmarshal(data, pool); err != nil {
+		return nil, fmt.Errorf("ошибка десериализации пула машин: %v", err)
+	}
+	return pool, nil
+}
+
+// parseBound разбирает границу диапазона, пустая строка - без границы
+func parseBound(text string) (float64, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, ",", "."))
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("неверное число %q", text)
+	}
+	return value, nil
+}
+
+// parseRange разбирает диапазон «от-до», «от-», «-до» или одно значение
+func parseRange(text string) (float64, float64, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "–", "-"))
+	if !strings.Contains(text, "-") {
+		value, err := parseBound(text)
+		if err == nil && value == 0 {
+			err = fmt.Errorf("укажите хотя бы одну границу")
+		}
+		return value, value, err
+	}
+
+	parts := strings.SplitN(text, "-", 2)
+	from, err := parseBound(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseBound(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from == 0 && to == 0 {
+		return 0, 0, fmt.Errorf("укажите хотя бы одну границу")
+	}
+	if to != 0 && from > to {
+		return 0, 0, fmt.Errorf("нижняя граница больше верхней")
+	}
+
+	return from, to, nil
+}
+
+// ParseYearRange разбирает диапазон годов выпуска: «1990-2005», «1990-», «-2005» или «2015»
+func ParseYearRange(text string) (int, int, error) {
+	from, to, err := parseRange(text)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, year := range []float64{from, to} {
+		if year != 0 && (year < 1900 || year > 2100 || year != float64(int(year))) {
+			return 0, 0, fmt.Errorf("неверный год %v", year)
+		}
+	}
+
+	return int(from), int(to), nil
+}
+
+// ParseCarStatRanges разбирает ограничения характеристик, по одной на строку:
+// «скорость 6-8», «торможение 5-», «старт -7»
+func ParseCarStatRanges(text string) (map[string]CarStatRange, error) {
+	stats := make(map[string]CarStatRange)
+
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("строка %q: ожидается «характеристика от-до»", strings.TrimSpace(line))
+		}
+
+		key := ""
+		for _, stat := range CarStats {
+			if strings.EqualFold(fields[0], stat.Name) || strings.EqualFold(fields[0], stat.Key) {
+				key = stat.Key
+			}
+		}
+		if key == "" {
+			return nil, fmt.Errorf("неизвестная характеристика %q", fields[0])
+		}
+
+		min, max, err := parseRange(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fields[0], err)
+		}
+		if min > maxCarStat || max > maxCarStat {
+			return nil, fmt.Errorf("%s: значение больше %d", fields[0], maxCarStat)
+		}
+
+		stats[key] = CarStatRange{Min: min, Max: max}
+	}
+
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("не указано ни одной характеристики")
+	}
+
+	return stats, nil
+}
+
+// ParseMakers разбирает список производителей через запятую или с новой строки
+func ParseMakers(text string) []string {
+	var makers []string
+	for _, maker := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if maker = strings.Join(strings.Fields(maker), " "); maker != "" && !containsFold(makers, maker) {
+			makers = append(makers, maker)
+		}
+	}
+	return makers
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+// testPoolCar возвращает машину для проверки фильтров пула
+func testPoolCar() *Car {
+	return &Car{
+		ID:           7,
+		Name:         "Lamborghini Huracán LP 610-4",
+		YearRaw:      sql.NullInt64{Int64: 2015, Valid: true},
+		Year:         "2015",
+		Rarity:       "Epic",
+		Price:        250000,
+		Speed:        7.5,
+		Handling:     8.1,
+		Acceleration: 9.4,
+		Launch:       9.9,
+		Braking:      7.2,
+		ClassLetter:  "S1",
+	}
+}
+
+func TestCarPoolMatches(t *testing.T) {
+	unknownYear := testPoolCar()
+	unknownYear.YearRaw = sql.NullInt64{}
+
+	reimported := testPoolCar()
+	reimported.ID = 42
+
+	tests := []struct {
+		name string
+		pool CarPool
+		car  *Car
+		want bool
+	}{
+		{name: "пустой пул", pool: CarPool{}, want: true},
+		{name: "год в диапазоне", pool: CarPool{YearFrom: 2010, YearTo: 2015}, want: true},
+		{name: "год вне диапазона", pool: CarPool{YearFrom: 2016}, want: false},
+		{name: "неизвестный год при фильтре по годам", pool: CarPool{YearTo: 2020}, car: unknownYear, want: false},
+		{name: "редкость без учета регистра", pool: CarPool{Rarities: []string{"epic"}}, want: true},
+		{name: "другая редкость", pool: CarPool{Rarities: []string{"Legendary"}}, want: false},
+		{name: "дороже лимита", pool: CarPool{MaxPrice: 200000}, want: false},
+		{name: "характеристика в диапазоне", pool: CarPool{Stats: map[string]CarStatRange{CarStatSpeed: {Min: 7, Max: 8}}}, want: true},
+		{name: "характеристика ниже минимума", pool: CarPool{Stats: map[string]CarStatRange{CarStatBraking: {Min: 8}}}, want: false},
+		{name: "разрешенный производитель", pool: CarPool{IncludeMakers: []string{"lamborghini"}}, want: true},
+		{name: "начало слова - не производитель", pool: CarPool{IncludeMakers: []string{"lambo"}}, want: false},
+		{name: "исключенный производитель", pool: CarPool{ExcludeMakers: []string{"Lamborghini"}}, want: false},
+		{name: "запрет по ID", pool: CarPool{Banned: []CarPoolBan{{CarID: 7}}}, want: false},
+		{
+			name: "запрет переживает повторный импорт",
+			pool: CarPool{Banned: []CarPoolBan{{CarID: 7, Key: "lamborghini huracán lp 610-4|2015"}}},
+			car:  reimported,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			car := tt.car
+			if car == nil {
+				car = testPoolCar()
+			}
+
+			if got := tt.pool.Matches(car); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarPoolBanUnban(t *testing.T) {
+	pool := &CarPool{}
+	car := testPoolCar()
+
+	pool.Ban(car)
+	pool.Ban(car)
+	if len(pool.Banned) != 1 {
+		t.Fatalf("Banned = %v, want one ban", pool.Banned)
+	}
+	if pool.Banned[0].Title != "Lamborghini Huracán LP 610-4 (2015)" {
+		t.Errorf("Title = %q", pool.Banned[0].Title)
+	}
+	if pool.IsEmpty() {
+		t.Errorf("IsEmpty() = true for pool with a ban")
+	}
+
+	pool.Unban(car.ID)
+	if len(pool.Banned) != 0 || !pool.IsEmpty() {
+		t.Errorf("after Unban Banned = %v, IsEmpty() = %v", pool.Banned, pool.IsEmpty())
+	}
+}
+
+func TestCarPoolToggleRarity(t *testing.T) {
+	pool := &CarPool{}
+
+	pool.ToggleRarity("Epic")
+	pool.ToggleRarity("Rare")
+	pool.ToggleRarity("epic")
+
+	if !reflect.DeepEqual(pool.Rarities, []string{"Rare"}) {
+		t.Errorf("Rarities = %v, want [Rare]", pool.Rarities)
+	}
+}
+
+func TestMatchesAnyMaker(t *testing.T) {
+	tests := []struct {
+		name   string
+		car    string
+		makers []string
+		want   bool
+	}{
+		{name: "производитель через дефис", car: "Mercedes-Benz AMG GT", makers: []string{"mercedes"}, want: true},
+		{name: "часть слова", car: "Mercedesx One", makers: []string{"mercedes"}, want: false},
+		{name: "лишние пробелы", car: "Aston Martin DB11", makers: []string{"aston   martin"}, want: true},
+		{name: "название целиком", car: "Ariel", makers: []string{"Ariel"}, want: true},
+		{name: "пустой производитель", car: "Ariel Nomad", makers: []string{""}, want: false},
+		{name: "нет производителей", car: "Ariel Nomad", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAnyMaker(tt.car, tt.makers); got != tt.want {
+				t.Errorf("matchesAnyMaker(%q, %v) = %v, want %v", tt.car, tt.makers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarPoolSerializeRoundTrip(t *testing.T) {
+	pool := &CarPool{
+		YearFrom: 1990,
+		Rarities: []string{"Epic"},
+		Stats:    map[string]CarStatRange{CarStatSpeed: {Min: 6}},
+		Banned:   []CarPoolBan{{CarID: 1, Key: "ariel nomad|2016", Title: "Ariel Nomad (2016)"}},
+	}
+
+	data, err := SerializeCarPool(pool)
+	if err != nil {
+		t.Fatalf("SerializeCarPool() error = %v", err)
+	}
+
+	got, err := DeserializeCarPool([]byte(data))
+	if err != nil {
+		t.Fatalf("DeserializeCarPool() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, pool) {
+		t.Errorf("round trip = %+v, want %+v", got, pool)
+	}
+
+	empty, err := DeserializeCarPool(nil)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("DeserializeCarPool(nil) = %+v, %v", empty, err)
+	}
+}
+
+func TestParseYearRange(t *testing.T) {
+	tests := []struct {
+		text     string
+		from, to int
+		wantErr  bool
+	}{
+		{text: "1990-2005", from: 1990, to: 2005},
+		{text: "1990-", from: 1990},
+		{text: "-2005", to: 2005},
+		{text: "2015", from: 2015, to: 2015},
+		{text: "1990–2005", from: 1990, to: 2005},
+		{text: "2005-1990", wantErr: true},
+		{text: "1800", wantErr: true},
+		{text: "2015.5", wantErr: true},
+		{text: "-", wantErr: true},
+		{text: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			from, to, err := ParseYearRange(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseYearRange(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if !tt.wantErr && (from != tt.from || to != tt.to) {
+				t.Errorf("ParseYearRange(%q) = %d, %d, want %d, %d", tt.text, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestParseCarStatRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    map[string]CarStatRange
+		wantErr bool
+	}{
+		{
+			name: "несколько строк",
+			text: "скорость 6-8\nТорможение 5,5-",
+			want: map[string]CarStatRange{
+				CarStatSpeed:   {Min: 6, Max: 8},
+				CarStatBraking: {Min: 5.5},
+			},
+		},
+		{
+			name: "ключ и одно значение",
+			text: "launch 7",
+			want: map[string]CarStatRange{CarStatLaunch: {Min: 7, Max: 7}},
+		},
+		{name: "неизвестная характеристика", text: "вес 5-6", wantErr: true},
+		{name: "больше максимума", text: "скорость 6-11", wantErr: true},
+		{name: "лишнее слово", text: "скорость от 6", wantErr: true},
+		{name: "пусто", text: "\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCarStatRanges(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCarStatRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCarStatRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMakers(t *testing.T) {
+	got := ParseMakers("BMW, audi\nbmw ,  Aston   Martin,,")
+	want := []string{"BMW", "audi", "Aston Martin"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMakers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CarSearchLimit - сколько машин возвращает поиск
+const CarSearchLimit = 50
+
+// CarSearchResult представляет найденную машину и степень совпадения с запросом
+type CarSearchResult struct {
+	Car   *Car    `json:"car"`
+	Score float64 `json:"score"` // от 0 до 1, 1 - точное совпадение
+}
+
+// carSearchWords разбивает строку на слова в нижнем регистре без знаков препинания
+func carSearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isCarSearchYear проверяет, что слово запроса - год выпуска
+func isCarSearchYear(word string) bool {
+	if len(word) != 4 {
+		return false
+	}
+	year, err := strconv.Atoi(word)
+	return err == nil && year >= 1900 && year <= 2100
+}
+
+// isDigits проверяет, что слово состоит только из цифр
+func isDigits(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// typoBudget возвращает, сколько опечаток допускается в слове такой длины
+func typoBudget(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance считает расстояние Дамерау-Левенштейна (вставка, удаление, замена, перестановка соседних букв)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			best := rows[i-1][j] + 1
+			if v := rows[i][j-1] + 1; v < best {
+				best = v
+			}
+			if v := rows[i-1][j-1] + cost; v < best {
+				best = v
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if v := rows[i-2][j-2] + 1; v < best {
+					best = v
+				}
+			}
+			rows[i][j] = best
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
+
+// wordScore оценивает, насколько слово запроса совпадает с одним из слов названия
+func wordScore(word string, nameWords []string, joined string) float64 {
+	best := 0.0
+	consider := func(score float64) {
+		if score > best {
+			best = score
+		}
+	}
+
+	budget := typoBudget(word)
+	if isDigits(word) {
+		// В номерах моделей опечатки не угадываем: 911 и 917 - разные машины
+		budget = 0
+	}
+
+	for _, nameWord := range nameWords {
+		switch {
+		case nameWord == word:
+			consider(1)
+		case strings.HasPrefix(nameWord, word) && len(word) >= 2:
+			consider(0.9)
+		case strings.Contains(nameWord, word) && len(word) >= 3:
+			consider(0.75)
+		}
+
+		if budget == 0 {
+			continue
+		}
+
+		if d := editDistance(word, nameWord); d <= budget {
+			consider(0.85 - 0.15*float64(d))
+		}
+
+		// Опечатка в начале длинного слова: «lambo» → «lamborghini», «lamdo» → «lamborghini»
+		if runes := []rune(nameWord); len(runes) > len([]rune(word)) && len([]rune(word)) >= 4 {
+			if d := editDistance(word, string(runes[:len([]rune(word))])); d <= budget {
+				consider(0.7 - 0.1*float64(d))
+			}
+		}
+	}
+
+	// Слитное написание: «mclaren» находит «Mc Laren»
+	if len(word) >= 4 && strings.Contains(joined, word) {
+		consider(0.8)
+	}
+
+	return best
+}
+
+// SearchCars ищет машины по названию и году с допуском опечаток. Каждое слово запроса должно
+// совпасть с названием (точно, по началу слова или с опечаткой), а год - с годом выпуска.
+// Результаты отсортированы по степени совпадения.
+func SearchCars(cars []*Car, query string, limit int) []*CarSearchResult {
+	var words, years []string
+	for _, word := range carSearchWords(query) {
+		if isCarSearchYear(word) {
+			years = append(years, word)
+		} else {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) == 0 && len(years) == 0 {
+		return nil
+	}
+
+	normalizedQuery := strings.Join(carSearchWords(query), " ")
+
+	var results []*CarSearchResult
+	for _, car := range cars {
+		nameWords := carSearchWords(car.Name)
+		joined := strings.Join(nameWords, "")
+
+		total := 0.0
+		matched := true
+
+		for _, year := range years {
+			if car.Year != year {
+				matched = false
+				break
+			}
+			total++
+		}
+
+		for _, word := range words {
+			if !matched {
+				break
+			}
+
+			score := wordScore(word, nameWords, joined)
+			if score == 0 {
+				matched = false
+				break
+			}
+			total += score
+		}
+
+		if !matched {
+			continue
+		}
+
+		score := total / float64(len(words)+len(years))
+		if strings.HasPrefix(strings.Join(nameWords, " "), normalizedQuery) {
+			score += 0.1
+		}
+		if score > 1 {
+			score = 1
+		}
+
+		results = append(results, &CarSearchResult{Car: car, Score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Car.Name != results[j].Car.Name {
+			return results[i].Car.Name < results[j].Car.Name
+		}
+		return results[i].Car.Year < results[j].Car.Year
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "abc", want: 3},
+		{a: "porsche", b: "porsche", want: 0},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "ab", b: "ba", want: 1},
+		{a: "lamborghini", b: "lamborghnii", want: 1},
+		{a: "porshe", b: "porsche", want: 1},
+		{a: "ё", b: "е", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := editDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestTypoBudget(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{word: "gt", want: 0},
+		{word: "bmw", want: 0},
+		{word: "audi", want: 1},
+		{word: "nissan", want: 1},
+		{word: "porsche", want: 2},
+		{word: "ауди", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := typoBudget(tt.word); got != tt.want {
+			t.Errorf("typoBudget(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSearchCars(t *testing.T) {
+	cars := []*Car{
+		{ID: 1, Name: "Lamborghini Huracán LP 610-4", Year: "2015"},
+		{ID: 2, Name: "Porsche 911 GT3 RS", Year: "2019"},
+		{ID: 3, Name: "Porsche 917 LH", Year: "1970"},
+		{ID: 4, Name: "McLaren P1", Year: "2013"},
+		{ID: 5, Name: "Mc Laren F1", Year: "1993"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []int
+	}{
+		{name: "начало названия", query: "lambo", want: []int{1}},
+		{name: "опечатка и диакритика", query: "lamborgini huracan", want: []int{1}},
+		{name: "номер модели без опечаток", query: "porshe 911", want: []int{2}},
+		{name: "год выпуска", query: "porsche 1970", want: []int{3}},
+		{name: "только год", query: "911 2018", want: nil},
+		{name: "слитное написание", query: "mclaren", want: []int{4, 5}},
+		{name: "лимит", query: "porsche", limit: 1, want: []int{2}},
+		{name: "пустой запрос", query: " - ", want: nil},
+		{name: "ничего не найдено", query: "ferrari", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := tt.limit
+			if limit == 0 {
+				limit = CarSearchLimit
+			}
+
+			var got []int
+			for _, result := range SearchCars(cars, tt.query, limit) {
+				if result.Score <= 0 || result.Score > 1 {
+					t.Errorf("car %d score = %v, want (0, 1]", result.Car.ID, result.Score)
+				}
+				got = append(got, result.Car.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchCars(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
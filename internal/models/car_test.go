@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestValidateCar(t *testing.T) {
+	valid := func() *Car {
+		return &Car{
+			Name:        "Toyota Supra RZ",
+			YearRaw:     sql.NullInt64{Int64: 1998, Valid: true},
+			Price:       75000,
+			Speed:       6.9,
+			ClassLetter: "A",
+			ClassNumber: 800,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(car *Car)
+		wantErr string // префикс поля в тексте ошибки, пусто - машина корректна
+	}{
+		{name: "корректная машина", modify: func(car *Car) {}},
+		{name: "год не указан", modify: func(car *Car) { car.YearRaw = sql.NullInt64{} }},
+		{name: "пустое название", modify: func(car *Car) { car.Name = "" }, wantErr: "name"},
+		{name: "длинное название", modify: func(car *Car) { car.Name = strings.Repeat("я", maxCarNameLength+1) }, wantErr: "name"},
+		{name: "год вне диапазона", modify: func(car *Car) { car.YearRaw = sql.NullInt64{Int64: 1800, Valid: true} }, wantErr: "year"},
+		{name: "отрицательная цена", modify: func(car *Car) { car.Price = -1 }, wantErr: "price"},
+		{name: "характеристика больше 10", modify: func(car *Car) { car.Braking = 10.5 }, wantErr: "braking"},
+		{name: "нет класса", modify: func(car *Car) { car.ClassLetter = "" }, wantErr: "class_letter"},
+		{name: "индекс вне диапазона", modify: func(car *Car) { car.ClassNumber = maxCarClassNumber + 1 }, wantErr: "class_number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			car := valid()
+			tt.modify(car)
+
+			err := ValidateCar(car)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateCar() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr+":") {
+				t.Errorf("ValidateCar() error = %v, want ошибку поля %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Типы изменений каталога машин
+const (
+	CarChangeAdded   = "added"
+	CarChangeUpdated = "updated"
+	CarChangeRemoved = "removed"
+)
+
+// CatalogVersion представляет версию каталога машин, созданную одним импортом
+type CatalogVersion struct {
+	ID        int       `json:"id"`
+	Source    string    `json:"source"`     // откуда загружен каталог: вики или файл
+	CreatedBy int64     `json:"created_by"` // Telegram ID администратора, 0 - из командной строки
+	CreatedAt time.Time `json:"created_at"`
+	Total     int       `json:"total"`
+	Added     int       `json:"added"`
+	Updated   int       `json:"updated"`
+	Removed   int       `json:"removed"`
+	Unchanged int       `json:"unchanged"`
+}
+
+// CarChange представляет изменение машины в версии каталога
+type CarChange struct {
+	ID            int    `json:"id"`
+	VersionID     int    `json:"version_id"`
+	CarID         int    `json:"car_id"`          // актуальная запись машины (для удаленной - последняя)
+	PreviousCarID int    `json:"previous_car_id"` // прежняя запись, если машина выдавалась на гонки и была сохранена как есть
+	Type          string `json:"type"`
+	CarName       string `json:"car_name"`
+	Details       string `json:"details"` // что изменилось, через "; "
+}
+
+// Icon возвращает значок типа изменения
+func (c *CarChange) Icon() string {
+	switch c.Type {
+	case CarChangeAdded:
+		return "➕"
+	case CarChangeRemoved:
+		return "➖"
+	default:
+		return "✏️"
+	}
+}
+
+// String возвращает изменение одной строкой для журнала
+func (c *CarChange) String() string {
+	if c.Details == "" {
+		return fmt.Sprintf("%s %s", c.Icon(), c.CarName)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Icon(), c.CarName, c.Details)
+}
+
+// CarTitle возвращает название машины с годом
+func CarTitle(name string, year sql.NullInt64) string {
+	if !year.Valid {
+		return name
+	}
+	return fmt.Sprintf("%s (%d)", name, year.Int64)
+}
+
+// carYearText возвращает год машины для журнала изменений
+func carYearText(year sql.NullInt64) string {
+	if !year.Valid {
+		return CarYearUnknown
+	}
+	return strconv.FormatInt(year.Int64, 10)
+}
+
+// CarDiff перечисляет отличия машины из источника от сохраненной: класс и индекс
+// производительности, характеристики и остальные поля. Пустой список - машина не изменилась.
+func CarDiff(stored, imported *Car) []string {
+	var diff []string
+
+	if stored.Name != imported.Name {
+		diff = append(diff, fmt.Sprintf("название %s → %s", stored.Name, imported.Name))
+	}
+	if stored.YearRaw != imported.YearRaw {
+		diff = append(diff, fmt.Sprintf("год %s → %s", carYearText(stored.YearRaw), carYearText(imported.YearRaw)))
+	}
+	if stored.ClassLetter != imported.ClassLetter || stored.ClassNumber != imported.ClassNumber {
+		diff = append(diff, fmt.Sprintf("класс %s %d → %s %d", stored.ClassLetter, stored.ClassNumber,
+			imported.ClassLetter, imported.ClassNumber))
+	}
+
+	stats := []struct {
+		name     string
+		old, new float64
+	}{
+		{"скорость", stored.Speed, imported.Speed},
+		{"управляемость", stored.Handling, imported.Handling},
+		{"разгон", stored.Acceleration, imported.Acceleration},
+		{"старт", stored.Launch, imported.Launch},
+		{"торможение", stored.Braking, imported.Braking},
+	}
+	for _, stat := range stats {
+		if stat.old != stat.new {
+			diff = append(diff, fmt.Sprintf("%s %.1f → %.1f", stat.name, stat.old, stat.new))
+		}
+	}
+
+	if stored.Price != imported.Price {
+		diff = append(diff, fmt.Sprintf("цена %d → %d", stored.Price, imported.Price))
+	}
+	if stored.Rarity != imported.Rarity {
+		diff = append(diff, fmt.Sprintf("редкость %s → %s", stored.Rarity, imported.Rarity))
+	}
+	if stored.Source != imported.Source {
+		diff = append(diff, fmt.Sprintf("получение %s → %s", stored.Source, imported.Source))
+	}
+	if stored.ImageURL != imported.ImageURL {
+		diff = append(diff, "новая картинка")
+	}
+
+	return diff
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestCarDiff(t *testing.T) {
+	stored := &Car{
+		Name:        "Toyota Supra RZ",
+		YearRaw:     sql.NullInt64{Int64: 1998, Valid: true},
+		ImageURL:    "https://img.example/supra.png",
+		Price:       75000,
+		Rarity:      "Rare",
+		Speed:       6.9,
+		Handling:    5.4,
+		ClassLetter: "A",
+		ClassNumber: 800,
+		Source:      "Autoshow",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(car *Car)
+		want   []string
+	}{
+		{name: "без изменений", modify: func(car *Car) {}},
+		{
+			name: "класс и характеристики",
+			modify: func(car *Car) {
+				car.ClassNumber = 810
+				car.Speed = 7
+				car.Handling = 5.1
+			},
+			want: []string{"класс A 800 → A 810", "скорость 6.9 → 7.0", "управляемость 5.4 → 5.1"},
+		},
+		{
+			name: "год снят",
+			modify: func(car *Car) {
+				car.YearRaw = sql.NullInt64{}
+			},
+			want: []string{"год 1998 → " + CarYearUnknown},
+		},
+		{
+			name: "остальные поля",
+			modify: func(car *Car) {
+				car.Price = 80000
+				car.Rarity = "Epic"
+				car.Source = "Festival Playlist"
+				car.ImageURL = "https://img.example/supra-new.png"
+			},
+			want: []string{"цена 75000 → 80000", "редкость Rare → Epic", "получение Autoshow → Festival Playlist", "новая картинка"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := *stored
+			tt.modify(&imported)
+
+			if got := CarDiff(stored, &imported); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CarDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarKey(t *testing.T) {
+	year := sql.NullInt64{Int64: 1998, Valid: true}
+
+	if CarKey("Toyota  Supra RZ ", year) != CarKey("toyota supra rz", year) {
+		t.Errorf("CarKey() зависит от регистра и пробелов")
+	}
+	if CarKey("Toyota Supra RZ", year) == CarKey("Toyota Supra RZ", sql.NullInt64{}) {
+		t.Errorf("CarKey() не различает машину с годом и без года")
+	}
+	if CarKey("Toyota Supra RZ", year) == CarKey("Toyota Supra RZ", sql.NullInt64{Int64: 2020, Valid: true}) {
+		t.Errorf("CarKey() не различает годы выпуска")
+	}
+}
+
+func TestCarChangeString(t *testing.T) {
+	tests := []struct {
+		change *CarChange
+		want   string
+	}{
+		{change: &CarChange{Type: CarChangeAdded, CarName: CarTitle("Warthog", sql.NullInt64{})}, want: "➕ Warthog"},
+		{
+			change: &CarChange{Type: CarChangeUpdated, CarName: CarTitle("Toyota Supra RZ", sql.NullInt64{Int64: 1998, Valid: true}), Details: "цена 1 → 2"},
+			want:   "✏️ Toyota Supra RZ (1998): цена 1 → 2",
+		},
+		{change: &CarChange{Type: CarChangeRemoved, CarName: "Ford GT"}, want: "➖ Ford GT"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
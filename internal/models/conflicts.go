@@ -0,0 +1,99 @@
+package models
+
+import "sort"
+
+// PlaceClaim представляет заявленное гонщиком место в дисциплине
+type PlaceClaim struct {
+	ResultID   int    `json:"result_id"`
+	DriverID   int    `json:"driver_id"`
+	DriverName string `json:"driver_name"`
+	Place      int    `json:"place"`
+	TimeMs     int    `json:"time_ms"` // 0, если время не указано
+}
+
+// PlaceConflict описывает конфликт мест в дисциплине:
+// несколько гонщиков заявили одно место или место больше числа участников
+type PlaceConflict struct {
+	Discipline string       `json:"discipline"`
+	Place      int          `json:"place"`
+	Duplicate  bool         `json:"duplicate"` // true - одно место у нескольких гонщиков, false - невозможное место
+	Claims     []PlaceClaim `json:"claims"`
+}
+
+// FindPlaceConflicts находит конфликты мест в одной дисциплине.
+// Одинаковое место допускается только при одинаковом указанном времени (ничья по времени).
+func FindPlaceConflicts(discipline string, claims []PlaceClaim, fieldSize int) []PlaceConflict {
+	byPlace := make(map[int][]PlaceClaim)
+	for _, claim := range claims {
+		if claim.Place > 0 {
+			byPlace[claim.Place] = append(byPlace[claim.Place], claim)
+		}
+	}
+
+	places := make([]int, 0, len(byPlace))
+	for place := range byPlace {
+		places = append(places, place)
+	}
+	sort.Ints(places)
+
+	var conflicts []PlaceConflict
+
+	for _, place := range places {
+		placeClaims := byPlace[place]
+
+		if place > fieldSize {
+			conflicts = append(conflicts, PlaceConflict{
+				Discipline: discipline,
+				Place:      place,
+				Claims:     placeClaims,
+			})
+			continue
+		}
+
+		if len(placeClaims) > 1 && !isTimedTie(placeClaims) {
+			conflicts = append(conflicts, PlaceConflict{
+				Discipline: discipline,
+				Place:      place,
+				Duplicate:  true,
+				Claims:     placeClaims,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// isTimedTie проверяет, что все заявки подтверждены одинаковым временем
+func isTimedTie(claims []PlaceClaim) bool {
+	for _, claim := range claims {
+		if claim.TimeMs == 0 || claim.TimeMs != claims[0].TimeMs {
+			return false
+		}
+	}
+	return true
+}
+
+// NearestFreePlace возвращает ближайшее свободное место, начиная с target и выше,
+// а если таких нет - ниже target. Возвращает 0, если свободных мест нет.
+func NearestFreePlace(taken map[int]bool, target, fieldSize int) int {
+	if target > fieldSize {
+		target = fieldSize
+	}
+	if target < 1 {
+		target = 1
+	}
+
+	for place := target; place <= fieldSize; place++ {
+		if !taken[place] {
+			return place
+		}
+	}
+
+	for place := target - 1; place >= 1; place-- {
+		if !taken[place] {
+			return place
+		}
+	}
+
+	return 0
+}
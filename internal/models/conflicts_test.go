@@ -0,0 +1,116 @@
+package models
+
+import "testing"
+
+func TestFindPlaceConflicts(t *testing.T) {
+	type conflict struct {
+		place     int
+		duplicate bool
+		claims    int
+	}
+
+	tests := []struct {
+		name      string
+		claims    []PlaceClaim
+		fieldSize int
+		want      []conflict
+	}{
+		{
+			name: "без конфликтов",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 1},
+				{ResultID: 2, Place: 2},
+				{ResultID: 3, Place: 0},
+				{ResultID: 4, Place: 0},
+			},
+			fieldSize: 4,
+		},
+		{
+			name: "одно место у двоих",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 1},
+				{ResultID: 2, Place: 1},
+				{ResultID: 3, Place: 2},
+			},
+			fieldSize: 3,
+			want:      []conflict{{place: 1, duplicate: true, claims: 2}},
+		},
+		{
+			name: "ничья по одинаковому времени",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 1, TimeMs: 60000},
+				{ResultID: 2, Place: 1, TimeMs: 60000},
+			},
+			fieldSize: 2,
+		},
+		{
+			name: "разное время на одном месте",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 1, TimeMs: 60000},
+				{ResultID: 2, Place: 1, TimeMs: 61000},
+			},
+			fieldSize: 2,
+			want:      []conflict{{place: 1, duplicate: true, claims: 2}},
+		},
+		{
+			name: "время указано не у всех",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 2, TimeMs: 60000},
+				{ResultID: 2, Place: 2},
+			},
+			fieldSize: 2,
+			want:      []conflict{{place: 2, duplicate: true, claims: 2}},
+		},
+		{
+			name: "место больше числа участников",
+			claims: []PlaceClaim{
+				{ResultID: 1, Place: 5},
+				{ResultID: 2, Place: 5},
+				{ResultID: 3, Place: 1},
+			},
+			fieldSize: 3,
+			want:      []conflict{{place: 5, claims: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindPlaceConflicts("Драг", tt.claims, tt.fieldSize)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FindPlaceConflicts() = %+v, want %+v", got, tt.want)
+			}
+
+			for i, want := range tt.want {
+				c := got[i]
+				if c.Discipline != "Драг" || c.Place != want.place || c.Duplicate != want.duplicate || len(c.Claims) != want.claims {
+					t.Errorf("conflict[%d] = %+v, want %+v", i, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNearestFreePlace(t *testing.T) {
+	tests := []struct {
+		name      string
+		taken     map[int]bool
+		target    int
+		fieldSize int
+		want      int
+	}{
+		{name: "место свободно", taken: map[int]bool{1: true}, target: 2, fieldSize: 4, want: 2},
+		{name: "следующее свободное", taken: map[int]bool{2: true, 3: true}, target: 2, fieldSize: 4, want: 4},
+		{name: "дальше заняты - ищем ближе к первому", taken: map[int]bool{3: true, 4: true}, target: 3, fieldSize: 4, want: 2},
+		{name: "цель больше числа участников", taken: map[int]bool{}, target: 7, fieldSize: 4, want: 4},
+		{name: "цель меньше единицы", taken: map[int]bool{}, target: 0, fieldSize: 4, want: 1},
+		{name: "все занято", taken: map[int]bool{1: true, 2: true}, target: 1, fieldSize: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NearestFreePlace(tt.taken, tt.target, tt.fieldSize); got != tt.want {
+				t.Errorf("NearestFreePlace() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
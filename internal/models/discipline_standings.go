@@ -0,0 +1,156 @@
+package models
+
+import "sort"
+
+// disciplineTitles - названия титулов чемпионов стандартных дисциплин
+var disciplineTitles = map[string]string{
+	"Визуал":         "Икона стиля",
+	"Драг":           "Король драга",
+	"Круговая гонка": "Властелин кольца",
+	"Офроад":         "Покоритель бездорожья",
+	"Гонка от А к Б": "Мастер спринта",
+	"Ралли":          "Мастер ралли",
+}
+
+// DisciplineTitle возвращает название титула чемпиона дисциплины
+func DisciplineTitle(discipline string) string {
+	if title, ok := disciplineTitles[discipline]; ok {
+		return title
+	}
+	return "Чемпион дисциплины «" + discipline + "»"
+}
+
+// DisciplineStanding представляет позицию гонщика в зачете одной дисциплины
+type DisciplineStanding struct {
+	Discipline string `json:"discipline"`
+	DriverID   int    `json:"driver_id"`
+	DriverName string `json:"driver_name"`
+	Position   int    `json:"position"`
+	Points     int    `json:"points"` // очки, набранные только в этой дисциплине
+	Entries    int    `json:"entries"`
+	Wins       int    `json:"wins"`
+	Podiums    int    `json:"podiums"`
+}
+
+// disciplineStandingLess сравнивает гонщиков в зачете дисциплины: очки, победы, подиумы
+func disciplineStandingLess(a, b *DisciplineStanding) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+	if a.Wins != b.Wins {
+		return a.Wins > b.Wins
+	}
+	return a.Podiums > b.Podiums
+}
+
+// CalculateDisciplineStandings считает зачеты по дисциплинам из подтвержденных результатов.
+// Места и статусы учитывают штрафы стюардов, очки начисляются по таблице очков сезона гонки
+// (rulesByRace: ID гонки -> таблица очков). Штраф за реролл и снятие очков относятся к гонке
+// целиком и в зачетах дисциплин не учитываются. Возвращает зачеты по дисциплинам в стандартном порядке.
+func CalculateDisciplineStandings(results []*RaceResult, penalties map[int][]*Penalty,
+	rulesByRace map[int]*ScoringRules, driverNames map[int]string) map[string][]*DisciplineStanding {
+	byDiscipline := make(map[string]map[int]*DisciplineStanding)
+
+	adjusted := ApplyRacePenalties(results, penalties)
+
+	for _, result := range results {
+		rules := rulesByRace[result.RaceID]
+		if rules == nil {
+			rules = DefaultScoringRules()
+		}
+
+		places, statuses := adjusted[result.ID].Results, adjusted[result.ID].Statuses
+
+		for discipline, place := range places {
+			status := statuses[discipline]
+			if place <= 0 && status == "" {
+				continue // место еще не указано
+			}
+
+			if byDiscipline[discipline] == nil {
+				byDiscipline[discipline] = make(map[int]*DisciplineStanding)
+			}
+
+			standing, ok := byDiscipline[discipline][result.DriverID]
+			if !ok {
+				standing = &DisciplineStanding{
+					Discipline: discipline,
+					DriverID:   result.DriverID,
+					DriverName: driverNames[result.DriverID],
+				}
+				byDiscipline[discipline][result.DriverID] = standing
+			}
+
+			standing.Entries++
+			standing.Points += rules.DisciplinePoints(place, status)
+			if status == "" && place == 1 {
+				standing.Wins++
+			}
+			if status == "" && place >= 1 && place <= 3 {
+				standing.Podiums++
+			}
+		}
+	}
+
+	standings := make(map[string][]*DisciplineStanding, len(byDiscipline))
+
+	for discipline, drivers := range byDiscipline {
+		table := make([]*DisciplineStanding, 0, len(drivers))
+		for _, standing := range drivers {
+			table = append(table, standing)
+		}
+
+		sort.Slice(table, func(i, j int) bool {
+			if disciplineStandingLess(table[i], table[j]) {
+				return true
+			}
+			if disciplineStandingLess(table[j], table[i]) {
+				return false
+			}
+			return table[i].DriverName < table[j].DriverName
+		})
+
+		// Гонщики с равными показателями делят позицию
+		for i, standing := range table {
+			standing.Position = i + 1
+			if i > 0 && !disciplineStandingLess(table[i-1], standing) {
+				standing.Position = table[i-1].Position
+			}
+		}
+
+		standings[discipline] = table
+	}
+
+	return standings
+}
+
+// SortedDisciplines возвращает дисциплины зачетов в порядке стандартного списка
+func SortedDisciplines(standings map[string][]*DisciplineStanding) []string {
+	disciplines := make([]string, 0, len(standings))
+	for discipline := range standings {
+		disciplines = append(disciplines, discipline)
+	}
+
+	sort.Slice(disciplines, func(i, j int) bool {
+		oi, oj := disciplineOrder(disciplines[i]), disciplineOrder(disciplines[j])
+		if oi != oj {
+			return oi < oj
+		}
+		return disciplines[i] < disciplines[j]
+	})
+
+	return disciplines
+}
+
+// DisciplineChampions возвращает лидеров зачета дисциплины (при равенстве - всех лидеров).
+// Гонщики без очков чемпионами не становятся.
+func DisciplineChampions(table []*DisciplineStanding) []*DisciplineStanding {
+	var champions []*DisciplineStanding
+	for _, standing := range table {
+		if standing.Position != 1 || standing.Points <= 0 {
+			break
+		}
+		champions = append(champions, standing)
+	}
+	return champions
+}
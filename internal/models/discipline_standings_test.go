@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateDisciplineStandings(t *testing.T) {
+	results := []*RaceResult{
+		{ID: 1, RaceID: 1, DriverID: 1, Results: map[string]int{"Драг": 1, "Офроад": 2}},
+		{ID: 2, RaceID: 1, DriverID: 2, Results: map[string]int{"Драг": 2, "Офроад": 1}},
+		{ID: 3, RaceID: 1, DriverID: 3, Results: map[string]int{"Драг": 3, "Офроад": 0},
+			Statuses: map[string]string{"Офроад": DisciplineDNS}},
+		{ID: 4, RaceID: 2, DriverID: 1, Results: map[string]int{"Драг": 0},
+			Statuses: map[string]string{"Драг": DisciplineDNF}},
+		{ID: 5, RaceID: 2, DriverID: 2, Results: map[string]int{"Драг": 1}, RerollPenalty: 1},
+		{ID: 6, RaceID: 2, DriverID: 3, Results: map[string]int{"Драг": 2}},
+	}
+	penalties := map[int][]*Penalty{
+		5: {
+			{ResultID: 5, Type: PenaltyPosition, Discipline: "Драг", Value: 1},
+			{ResultID: 5, Type: PenaltyPoints, Value: 4},
+		},
+	}
+	seasonRules := &ScoringRules{PlacePoints: []int{10, 5}, DNFPoints: -1}
+	rulesByRace := map[int]*ScoringRules{1: DefaultScoringRules(), 2: seasonRules}
+	names := map[int]string{1: "Анна", 2: "Борис", 3: "Вера"}
+
+	got := CalculateDisciplineStandings(results, penalties, rulesByRace, names)
+
+	want := map[string][]DisciplineStanding{
+		"Драг": {
+			{Discipline: "Драг", DriverID: 3, DriverName: "Вера", Position: 1, Points: 11, Entries: 2, Wins: 1, Podiums: 2},
+			{Discipline: "Драг", DriverID: 2, DriverName: "Борис", Position: 2, Points: 7, Entries: 2, Podiums: 2},
+			{Discipline: "Драг", DriverID: 1, DriverName: "Анна", Position: 3, Points: 2, Entries: 2, Wins: 1, Podiums: 1},
+		},
+		"Офроад": {
+			{Discipline: "Офроад", DriverID: 2, DriverName: "Борис", Position: 1, Points: 3, Entries: 1, Wins: 1, Podiums: 1},
+			{Discipline: "Офроад", DriverID: 1, DriverName: "Анна", Position: 2, Points: 2, Entries: 1, Podiums: 1},
+			{Discipline: "Офроад", DriverID: 3, DriverName: "Вера", Position: 3, Entries: 1},
+		},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("CalculateDisciplineStandings() вернул %d зачетов, want %d", len(got), len(want))
+	}
+	for discipline, table := range want {
+		if len(got[discipline]) != len(table) {
+			t.Fatalf("%s: %d строк, want %d", discipline, len(got[discipline]), len(table))
+		}
+		for i := range table {
+			if !reflect.DeepEqual(*got[discipline][i], table[i]) {
+				t.Errorf("%s[%d] = %+v, want %+v", discipline, i, *got[discipline][i], table[i])
+			}
+		}
+	}
+}
+
+func TestCalculateDisciplineStandingsSharedPosition(t *testing.T) {
+	results := []*RaceResult{
+		{ID: 1, RaceID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+		{ID: 2, RaceID: 2, DriverID: 2, Results: map[string]int{"Драг": 1}},
+		{ID: 3, RaceID: 2, DriverID: 3, Results: map[string]int{"Драг": 2}},
+	}
+	names := map[int]string{1: "Борис", 2: "Анна", 3: "Вера"}
+
+	got := CalculateDisciplineStandings(results, nil, nil, names)["Драг"]
+
+	wantNames := []string{"Анна", "Борис", "Вера"}
+	wantPositions := []int{1, 1, 3}
+	for i := range got {
+		if got[i].DriverName != wantNames[i] || got[i].Position != wantPositions[i] {
+			t.Errorf("строка %d = %s, позиция %d, want %s, позиция %d",
+				i, got[i].DriverName, got[i].Position, wantNames[i], wantPositions[i])
+		}
+	}
+}
+
+func TestSortedDisciplines(t *testing.T) {
+	standings := map[string][]*DisciplineStanding{
+		"Ралли":   nil,
+		"Дрифт":   nil,
+		"Драг":    nil,
+		"Визуал":  nil,
+		"Авиашоу": nil,
+	}
+
+	want := []string{"Визуал", "Драг", "Ралли", "Авиашоу", "Дрифт"}
+	if got := SortedDisciplines(standings); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedDisciplines() = %v, want %v", got, want)
+	}
+}
+
+func TestDisciplineTitle(t *testing.T) {
+	if got := DisciplineTitle("Драг"); got != "Король драга" {
+		t.Errorf("DisciplineTitle(Драг) = %q", got)
+	}
+	if got := DisciplineTitle("Дрифт"); got != "Чемпион дисциплины «Дрифт»" {
+		t.Errorf("DisciplineTitle(Дрифт) = %q", got)
+	}
+}
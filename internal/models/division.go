@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultDivisionSwapCount - сколько гонщиков по умолчанию меняются местами между соседними дивизионами
+const DefaultDivisionSwapCount = 2
+
+// MaxDivisionSwapCount - верхняя граница числа гонщиков для обмена между дивизионами
+const MaxDivisionSwapCount = 5
+
+// Division представляет дивизион сезона. Tier 1 - высший дивизион.
+type Division struct {
+	ID        int       `json:"id"`
+	SeasonID  int       `json:"season_id"`
+	Name      string    `json:"name"`
+	Tier      int       `json:"tier"`
+	SwapCount int       `json:"swap_count"` // сколько худших гонщиков меняются с лучшими из дивизиона ниже
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DivisionMove представляет переход гонщика между дивизионами по итогам сезона
+type DivisionMove struct {
+	SeasonID       int    `json:"season_id"`
+	DriverID       int    `json:"driver_id"`
+	DriverName     string `json:"driver_name"`
+	FromDivisionID int    `json:"from_division_id"`
+	ToDivisionID   int    `json:"to_division_id"`
+	Promoted       bool   `json:"promoted"`
+}
+
+// ValidateDivisionName проверяет название дивизиона
+func ValidateDivisionName(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < 2 || length > 30 {
+		return fmt.Errorf("название дивизиона должно содержать от 2 до 30 символов")
+	}
+	return nil
+}
+
+// PlanDivisionMoves рассчитывает повышения и понижения по итоговым таблицам дивизионов.
+// Дивизионы передаются от высшего к низшему, standings - таблицы дивизионов (ID дивизиона -> таблица
+// по убыванию). Между каждой парой соседних дивизионов худшие SwapCount гонщиков верхнего меняются
+// с лучшими гонщиками нижнего. Гонщик, уже повышенный из дивизиона, не может быть из него понижен,
+// поэтому при маленьком составе обмен сокращается, а размеры дивизионов сохраняются.
+func PlanDivisionMoves(divisions []*Division, standings map[int][]*StandingsEntry) []*DivisionMove {
+	var moves []*DivisionMove
+	moved := make(map[int]bool)
+
+	for i := 0; i+1 < len(divisions); i++ {
+		upper, lower := divisions[i], divisions[i+1]
+
+		// Кандидаты на понижение - с конца таблицы верхнего дивизиона, кроме только что повышенных
+		var relegated []*StandingsEntry
+		table := standings[upper.ID]
+		for j := len(table) - 1; j >= 0 && len(relegated) < upper.SwapCount; j-- {
+			if !moved[table[j].DriverID] {
+				relegated = append(relegated, table[j])
+			}
+		}
+
+		var promoted []*StandingsEntry
+		for _, entry := range standings[lower.ID] {
+			if len(promoted) >= upper.SwapCount {
+				break
+			}
+			promoted = append(promoted, entry)
+		}
+
+		count := len(relegated)
+		if len(promoted) < count {
+			count = len(promoted)
+		}
+
+		for _, entry := range promoted[:count] {
+			moved[entry.DriverID] = true
+			moves = append(moves, &DivisionMove{
+				SeasonID:       upper.SeasonID,
+				DriverID:       entry.DriverID,
+				DriverName:     entry.DriverName,
+				FromDivisionID: lower.ID,
+				ToDivisionID:   upper.ID,
+				Promoted:       true,
+			})
+		}
+
+		for _, entry := range relegated[:count] {
+			moved[entry.DriverID] = true
+			moves = append(moves, &DivisionMove{
+				SeasonID:       upper.SeasonID,
+				DriverID:       entry.DriverID,
+				DriverName:     entry.DriverName,
+				FromDivisionID: upper.ID,
+				ToDivisionID:   lower.ID,
+			})
+		}
+	}
+
+	return moves
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValidateDivisionName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "Лига А"},
+		{name: "Д1"},
+		{name: "Д", wantErr: true},
+		{name: "Очень длинное название дивизиона", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateDivisionName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateDivisionName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// divisionTable строит таблицу дивизиона из имен гонщиков по убыванию мест
+func divisionTable(names ...string) []*StandingsEntry {
+	table := make([]*StandingsEntry, 0, len(names))
+	for i, name := range names {
+		table = append(table, &StandingsEntry{DriverID: int(name[0])*100 + int(name[1]), DriverName: name, Position: i + 1})
+	}
+	return table
+}
+
+func TestPlanDivisionMoves(t *testing.T) {
+	tests := []struct {
+		name      string
+		divisions []*Division
+		standings map[int][]*StandingsEntry
+		want      []string
+	}{
+		{
+			name: "обмен двумя гонщиками",
+			divisions: []*Division{
+				{ID: 1, Tier: 1, SwapCount: 2},
+				{ID: 2, Tier: 2, SwapCount: 2},
+			},
+			standings: map[int][]*StandingsEntry{
+				1: divisionTable("a1", "a2", "a3", "a4"),
+				2: divisionTable("b1", "b2", "b3"),
+			},
+			want: []string{"b1 2->1", "b2 2->1", "a4 1->2", "a3 1->2"},
+		},
+		{
+			name: "маленький нижний дивизион сокращает обмен",
+			divisions: []*Division{
+				{ID: 1, Tier: 1, SwapCount: 2},
+				{ID: 2, Tier: 2, SwapCount: 2},
+			},
+			standings: map[int][]*StandingsEntry{
+				1: divisionTable("a1", "a2", "a3"),
+				2: divisionTable("b1"),
+			},
+			want: []string{"b1 2->1", "a3 1->2"},
+		},
+		{
+			name: "повышенный гонщик не понижается из своего дивизиона",
+			divisions: []*Division{
+				{ID: 1, Tier: 1, SwapCount: 1},
+				{ID: 2, Tier: 2, SwapCount: 2},
+				{ID: 3, Tier: 3, SwapCount: 2},
+			},
+			standings: map[int][]*StandingsEntry{
+				1: divisionTable("a1", "a2"),
+				2: divisionTable("b1", "b2"),
+				3: divisionTable("c1", "c2"),
+			},
+			want: []string{"b1 2->1", "a2 1->2", "c1 3->2", "b2 2->3"},
+		},
+		{
+			name: "без обмена",
+			divisions: []*Division{
+				{ID: 1, Tier: 1, SwapCount: 0},
+				{ID: 2, Tier: 2, SwapCount: 2},
+			},
+			standings: map[int][]*StandingsEntry{
+				1: divisionTable("a1", "a2"),
+				2: divisionTable("b1", "b2"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, move := range PlanDivisionMoves(tt.divisions, tt.standings) {
+				if move.Promoted != (move.ToDivisionID < move.FromDivisionID) {
+					t.Errorf("%s: Promoted = %v for %d->%d", move.DriverName, move.Promoted,
+						move.FromDivisionID, move.ToDivisionID)
+				}
+				got = append(got, fmt.Sprintf("%s %d->%d", move.DriverName, move.FromDivisionID, move.ToDivisionID))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanDivisionMoves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
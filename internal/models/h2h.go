@@ -0,0 +1,118 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// HeadToHeadMeeting представляет гонку, в которой участвовали оба гонщика.
+// Места и статусы результатов уже учитывают штрафы стюардов.
+type HeadToHeadMeeting struct {
+	RaceID   int         `json:"race_id"`
+	RaceName string      `json:"race_name"`
+	RaceDate time.Time   `json:"race_date"`
+	CarClass string      `json:"car_class"`
+	First    *RaceResult `json:"first"`
+	Second   *RaceResult `json:"second"`
+}
+
+// HeadToHeadDiscipline представляет счет очных встреч в одной дисциплине
+type HeadToHeadDiscipline struct {
+	Discipline  string `json:"discipline"`
+	FirstWins   int    `json:"first_wins"`
+	SecondWins  int    `json:"second_wins"`
+	Draws       int    `json:"draws"`
+	Comparisons int    `json:"comparisons"`
+}
+
+// HeadToHeadSummary представляет итог очного противостояния двух гонщиков
+type HeadToHeadSummary struct {
+	Meetings         int                     `json:"meetings"`
+	FirstRaceWins    int                     `json:"first_race_wins"` // гонки, где первый набрал больше очков
+	SecondRaceWins   int                     `json:"second_race_wins"`
+	FirstPoints      int                     `json:"first_points"`
+	SecondPoints     int                     `json:"second_points"`
+	FirstDiscipline  int                     `json:"first_discipline"` // выигранные дисциплины за все встречи
+	SecondDiscipline int                     `json:"second_discipline"`
+	Disciplines      []*HeadToHeadDiscipline `json:"disciplines"`
+}
+
+// CompareDiscipline сравнивает места двух гонщиков в дисциплине: 1 - выше первый, -1 - второй, 0 - ничья.
+// ok = false, если сравнение невозможно (один из гонщиков не стартовал или не указал место).
+func CompareDiscipline(first, second *RaceResult, discipline string) (int, bool) {
+	rankFirst, ok := ratingRank(first.Results[discipline], first.Statuses[discipline])
+	if !ok {
+		return 0, false
+	}
+	rankSecond, ok := ratingRank(second.Results[discipline], second.Statuses[discipline])
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case rankFirst < rankSecond:
+		return 1, true
+	case rankFirst > rankSecond:
+		return -1, true
+	default:
+		return 0, true
+	}
+}
+
+// SummarizeHeadToHead подсчитывает итог очных встреч по дисциплинам и очкам
+func SummarizeHeadToHead(meetings []*HeadToHeadMeeting) *HeadToHeadSummary {
+	summary := &HeadToHeadSummary{Meetings: len(meetings)}
+	byDiscipline := make(map[string]*HeadToHeadDiscipline)
+
+	for _, meeting := range meetings {
+		summary.FirstPoints += meeting.First.TotalScore
+		summary.SecondPoints += meeting.Second.TotalScore
+
+		switch {
+		case meeting.First.TotalScore > meeting.Second.TotalScore:
+			summary.FirstRaceWins++
+		case meeting.First.TotalScore < meeting.Second.TotalScore:
+			summary.SecondRaceWins++
+		}
+
+		for discipline := range meeting.First.Results {
+			outcome, ok := CompareDiscipline(meeting.First, meeting.Second, discipline)
+			if !ok {
+				continue
+			}
+
+			stats, exists := byDiscipline[discipline]
+			if !exists {
+				stats = &HeadToHeadDiscipline{Discipline: discipline}
+				byDiscipline[discipline] = stats
+			}
+			stats.Comparisons++
+
+			switch outcome {
+			case 1:
+				stats.FirstWins++
+				summary.FirstDiscipline++
+			case -1:
+				stats.SecondWins++
+				summary.SecondDiscipline++
+			default:
+				stats.Draws++
+			}
+		}
+	}
+
+	for _, stats := range byDiscipline {
+		summary.Disciplines = append(summary.Disciplines, stats)
+	}
+
+	// Дисциплины в порядке стандартного списка, остальные - по алфавиту
+	sort.Slice(summary.Disciplines, func(i, j int) bool {
+		oi, oj := disciplineOrder(summary.Disciplines[i].Discipline), disciplineOrder(summary.Disciplines[j].Discipline)
+		if oi != oj {
+			return oi < oj
+		}
+		return summary.Disciplines[i].Discipline < summary.Disciplines[j].Discipline
+	})
+
+	return summary
+}
@@ -0,0 +1,97 @@
+package models
+
+import "testing"
+
+func TestCompareDiscipline(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  *RaceResult
+		second *RaceResult
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "первый выше",
+			first:  &RaceResult{Results: map[string]int{"Драг": 1}},
+			second: &RaceResult{Results: map[string]int{"Драг": 3}},
+			want:   1, wantOK: true,
+		},
+		{
+			name:   "второй выше",
+			first:  &RaceResult{Results: map[string]int{"Драг": 4}},
+			second: &RaceResult{Results: map[string]int{"Драг": 2}},
+			want:   -1, wantOK: true,
+		},
+		{
+			name:   "ничья",
+			first:  &RaceResult{Results: map[string]int{"Драг": 2}},
+			second: &RaceResult{Results: map[string]int{"Драг": 2}},
+			want:   0, wantOK: true,
+		},
+		{
+			name:   "DNF проигрывает финишу",
+			first:  &RaceResult{Results: map[string]int{"Драг": 0}, Statuses: map[string]string{"Драг": DisciplineDNF}},
+			second: &RaceResult{Results: map[string]int{"Драг": 6}},
+			want:   -1, wantOK: true,
+		},
+		{
+			name:   "DNS не сравнивается",
+			first:  &RaceResult{Results: map[string]int{"Драг": 0}, Statuses: map[string]string{"Драг": DisciplineDNS}},
+			second: &RaceResult{Results: map[string]int{"Драг": 1}},
+			wantOK: false,
+		},
+		{
+			name:   "место не указано",
+			first:  &RaceResult{Results: map[string]int{"Драг": 1}},
+			second: &RaceResult{Results: map[string]int{}},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CompareDiscipline(tt.first, tt.second, "Драг")
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("CompareDiscipline() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSummarizeHeadToHead(t *testing.T) {
+	meetings := []*HeadToHeadMeeting{
+		{
+			First:  &RaceResult{TotalScore: 5, Results: map[string]int{"Офроад": 1, "Драг": 2, "Ралли": 0}, Statuses: map[string]string{"Ралли": DisciplineDNS}},
+			Second: &RaceResult{TotalScore: 3, Results: map[string]int{"Офроад": 2, "Драг": 1, "Ралли": 1}},
+		},
+		{
+			First:  &RaceResult{TotalScore: 2, Results: map[string]int{"Офроад": 3, "Драг": 2}},
+			Second: &RaceResult{TotalScore: 2, Results: map[string]int{"Офроад": 1, "Драг": 2}},
+		},
+	}
+
+	summary := SummarizeHeadToHead(meetings)
+
+	if summary.Meetings != 2 || summary.FirstRaceWins != 1 || summary.SecondRaceWins != 0 {
+		t.Errorf("встречи = %d, победы %d:%d, want 2, 1:0", summary.Meetings, summary.FirstRaceWins, summary.SecondRaceWins)
+	}
+	if summary.FirstPoints != 7 || summary.SecondPoints != 5 {
+		t.Errorf("очки = %d:%d, want 7:5", summary.FirstPoints, summary.SecondPoints)
+	}
+	if summary.FirstDiscipline != 1 || summary.SecondDiscipline != 2 {
+		t.Errorf("дисциплины = %d:%d, want 1:2", summary.FirstDiscipline, summary.SecondDiscipline)
+	}
+
+	want := []HeadToHeadDiscipline{
+		{Discipline: "Драг", FirstWins: 0, SecondWins: 1, Draws: 1, Comparisons: 2},
+		{Discipline: "Офроад", FirstWins: 1, SecondWins: 1, Draws: 0, Comparisons: 2},
+	}
+	if len(summary.Disciplines) != len(want) {
+		t.Fatalf("дисциплин %d, want %d", len(summary.Disciplines), len(want))
+	}
+	for i, w := range want {
+		if *summary.Disciplines[i] != w {
+			t.Errorf("Disciplines[%d] = %+v, want %+v", i, *summary.Disciplines[i], w)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TimedDisciplines перечисляет дисциплины, в которых результат фиксируется временем
+var TimedDisciplines = []string{
+	"Драг",
+	"Гонка от А к Б",
+	"Ралли",
+}
+
+// lapTimePattern описывает формат времени m:ss.mmm
+var lapTimePattern = regexp.MustCompile(`^(\d{1,2}):([0-5]\d)\.(\d{3})$`)
+
+// TimeRecord представляет лучшее время в дисциплине
+type TimeRecord struct {
+	Discipline string    `json:"discipline"`
+	CarClass   string    `json:"car_class"`
+	TimeMs     int       `json:"time_ms"`
+	DriverID   int       `json:"driver_id"`
+	DriverName string    `json:"driver_name"`
+	CarName    string    `json:"car_name"`
+	RaceID     int       `json:"race_id"`
+	RaceName   string    `json:"race_name"`
+	SeasonID   int       `json:"season_id"`
+	Date       time.Time `json:"date"`
+}
+
+// IsTimedDiscipline проверяет, фиксируется ли результат дисциплины временем
+func IsTimedDiscipline(discipline string) bool {
+	for _, d := range TimedDisciplines {
+		if d == discipline {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLapTime разбирает время в формате m:ss.mmm и возвращает его в миллисекундах
+func ParseLapTime(s string) (int, error) {
+	matches := lapTimePattern.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, fmt.Errorf("неверный формат времени %q, ожидается m:ss.mmm", s)
+	}
+
+	minutes, _ := strconv.Atoi(matches[1])
+	seconds, _ := strconv.Atoi(matches[2])
+	millis, _ := strconv.Atoi(matches[3])
+
+	total := (minutes*60+seconds)*1000 + millis
+	if total == 0 {
+		return 0, fmt.Errorf("время не может быть нулевым")
+	}
+
+	return total, nil
+}
+
+// FormatLapTime форматирует время в миллисекундах как m:ss.mmm
+func FormatLapTime(ms int) string {
+	return fmt.Sprintf("%d:%02d.%03d", ms/60000, (ms/1000)%60, ms%1000)
+}
+
+// SerializeTimes сериализует карту времен в JSON
+func SerializeTimes(times map[string]int) (string, error) {
+	if times == nil {
+		return "{}", nil
+	}
+
+	jsonData, err := json.Marshal(times)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// DeserializeTimes десериализует карту времен из JSON
+func DeserializeTimes(data string) (map[string]int, error) {
+	times := make(map[string]int)
+	if data == "" {
+		return times, nil
+	}
+
+	err := json.Unmarshal([]byte(data), &times)
+	if err != nil {
+		return nil, err
+	}
+
+	if times == nil {
+		times = make(map[string]int)
+	}
+	return times, nil
+}
+
+// RankByTimes распределяет места в дисциплине по времени.
+// times содержит время результатов, для которых оно указано, places - текущие места всех результатов.
+// Результаты со временем занимают места первыми (одинаковое время - одинаковое место),
+// результаты с местом без времени следуют за ними в прежнем порядке, место 0 сохраняется.
+func RankByTimes(times map[int]int, places map[int]int) map[int]int {
+	ranked := make(map[int]int, len(places))
+
+	timed := make([]int, 0, len(times))
+	for id := range times {
+		timed = append(timed, id)
+	}
+	sort.Slice(timed, func(i, j int) bool {
+		if times[timed[i]] != times[timed[j]] {
+			return times[timed[i]] < times[timed[j]]
+		}
+		return timed[i] < timed[j]
+	})
+
+	for i, id := range timed {
+		if i > 0 && times[id] == times[timed[i-1]] {
+			ranked[id] = ranked[timed[i-1]]
+		} else {
+			ranked[id] = i + 1
+		}
+	}
+
+	var untimed []int
+	for id, place := range places {
+		if _, ok := times[id]; ok {
+			continue
+		}
+		if place <= 0 {
+			ranked[id] = place
+			continue
+		}
+		untimed = append(untimed, id)
+	}
+	sort.Slice(untimed, func(i, j int) bool {
+		if places[untimed[i]] != places[untimed[j]] {
+			return places[untimed[i]] < places[untimed[j]]
+		}
+		return untimed[i] < untimed[j]
+	})
+
+	for i, id := range untimed {
+		ranked[id] = len(timed) + i + 1
+	}
+
+	return ranked
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLapTime(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{text: "1:23.456", want: 83456},
+		{text: "0:09.001", want: 9001},
+		{text: "12:00.000", want: 720000},
+		{text: "0:00.000", wantErr: true},
+		{text: "1:60.000", wantErr: true},
+		{text: "1:23.45", wantErr: true},
+		{text: "123:00.000", wantErr: true},
+		{text: "83.456", wantErr: true},
+		{text: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseLapTime(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLapTime(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLapTime(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+			if !tt.wantErr && FormatLapTime(got) != tt.text {
+				t.Errorf("FormatLapTime(%d) = %q, want %q", got, FormatLapTime(got), tt.text)
+			}
+		})
+	}
+}
+
+func TestRankByTimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		times  map[int]int
+		places map[int]int
+		want   map[int]int
+	}{
+		{
+			name:   "места по времени",
+			times:  map[int]int{1: 60000, 2: 59000, 3: 61000},
+			places: map[int]int{1: 1, 2: 2, 3: 3},
+			want:   map[int]int{1: 2, 2: 1, 3: 3},
+		},
+		{
+			name:   "одинаковое время - одинаковое место",
+			times:  map[int]int{1: 60000, 2: 59000, 3: 60000},
+			places: map[int]int{1: 1, 2: 2, 3: 3},
+			want:   map[int]int{1: 2, 2: 1, 3: 2},
+		},
+		{
+			name:   "результаты без времени после результатов со временем",
+			times:  map[int]int{3: 70000},
+			places: map[int]int{1: 2, 2: 1, 3: 3, 4: 0},
+			want:   map[int]int{1: 3, 2: 2, 3: 1, 4: 0},
+		},
+		{
+			name:   "без времени порядок сохраняется",
+			places: map[int]int{1: 3, 2: 1},
+			want:   map[int]int{1: 2, 2: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RankByTimes(tt.times, tt.places); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RankByTimes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -57,15 +57,40 @@ type Race struct {
 
 // Update RaceResult to include reroll penalty
 type RaceResult struct {
-	ID            int            `json:"id"`
-	RaceID        int            `json:"race_id"`
-	DriverID      int            `json:"driver_id"`
-	CarNumber     int            `json:"car_number"`
-	CarName       string         `json:"car_name"`
-	CarPhotoURL   string         `json:"car_photo_url"`
-	Results       map[string]int `json:"results"` // discipline -> place
-	TotalScore    int            `json:"total_score"`
-	RerollPenalty int            `json:"reroll_penalty"`
+	ID            int               `json:"id"`
+	RaceID        int               `json:"race_id"`
+	DriverID      int               `json:"driver_id"`
+	CarNumber     int               `json:"car_number"`
+	CarName       string            `json:"car_name"`
+	CarPhotoURL   string            `json:"car_photo_url"`
+	Results       map[string]int    `json:"results"` // discipline -> place
+	TotalScore    int               `json:"total_score"`
+	RerollPenalty int               `json:"reroll_penalty"`
+	Times         map[string]int    `json:"times"`    // discipline -> time in ms (only timed disciplines)
+	Statuses      map[string]string `json:"statuses"` // discipline -> DNS/DNF/DSQ (place is 0)
+
+	ConfirmationStatus string `json:"confirmation_status"` // pending или confirmed
+	ConfirmedBy        int64  `json:"confirmed_by"`        // Telegram ID подтвердившего, 0 если не подтвержден
+}
+
+// Статусы подтверждения результата
+const (
+	ResultStatusPending   = "pending"
+	ResultStatusConfirmed = "confirmed"
+)
+
+// IsConfirmed проверяет, подтвержден ли результат и учитывается ли он в зачете
+func (r *RaceResult) IsConfirmed() bool {
+	return r.ConfirmationStatus != ResultStatusPending
+}
+
+// ResultScreenshot скриншот, подтверждающий результат в дисциплине
+type ResultScreenshot struct {
+	ID         int       `json:"id"`
+	ResultID   int       `json:"result_id"`
+	Discipline string    `json:"discipline"`
+	FileID     string    `json:"file_id"`
+	UploadedAt time.Time `json:"uploaded_at"`
 }
 
 // Update RaceCarAssignment to track rerolls
@@ -127,6 +152,10 @@ type DriverStats struct {
 	TotalScore   int             `json:"total_score"`
 	RecentRaces  []RaceScorePair `json:"recent_races"`
 	TotalRaces   int             `json:"total_races"`
+	AveragePlace float64         `json:"average_place"` // 0, если нет ни одного финиша
+	DNS          int             `json:"dns"`           // дисциплины со статусом DNS
+	DNF          int             `json:"dnf"`           // дисциплины со статусом DNF
+	DSQ          int             `json:"dsq"`           // дисциплины со статусом DSQ
 	Achievements []Achievement   `json:"achievements"`
 }
 
@@ -138,6 +167,8 @@ type RaceScorePair struct {
 
 // Achievement достижение гонщика
 type Achievement struct {
+	Code        string `json:"code"`
+	Emoji       string `json:"emoji"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	DateEarned  string `json:"date_earned"`
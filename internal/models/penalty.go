@@ -0,0 +1,214 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Типы штрафов
+const (
+	PenaltyPoints           = "points"   // снятие очков за гонку
+	PenaltyPosition         = "position" // потеря позиций в дисциплине
+	PenaltyDisqualification = "dsq"      // дисквалификация из дисциплины
+)
+
+// Penalty представляет штраф, назначенный администратором результату гонки
+type Penalty struct {
+	ID         int       `json:"id"`
+	ResultID   int       `json:"result_id"`
+	Type       string    `json:"type"`
+	Discipline string    `json:"discipline"` // пусто для штрафа очками
+	Value      int       `json:"value"`      // очки или позиции; 0 для дисквалификации
+	Reason     string    `json:"reason"`
+	IssuedBy   int64     `json:"issued_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IsValidPenaltyType проверяет тип штрафа
+func IsValidPenaltyType(penaltyType string) bool {
+	switch penaltyType {
+	case PenaltyPoints, PenaltyPosition, PenaltyDisqualification:
+		return true
+	}
+	return false
+}
+
+// PenaltyTypeName возвращает название типа штрафа
+func PenaltyTypeName(penaltyType string) string {
+	switch penaltyType {
+	case PenaltyPoints:
+		return "Снятие очков"
+	case PenaltyPosition:
+		return "Потеря позиций"
+	case PenaltyDisqualification:
+		return "Дисквалификация"
+	default:
+		return penaltyType
+	}
+}
+
+// Describe возвращает краткое описание штрафа без причины
+func (p *Penalty) Describe() string {
+	switch p.Type {
+	case PenaltyPoints:
+		return fmt.Sprintf("-%d очк.", p.Value)
+	case PenaltyPosition:
+		return fmt.Sprintf("%s: -%d поз.", p.Discipline, p.Value)
+	case PenaltyDisqualification:
+		return fmt.Sprintf("%s: DSQ", p.Discipline)
+	default:
+		return p.Type
+	}
+}
+
+// PenalizedResult содержит места и статусы результата с учетом штрафов стюардов и сумму снятых очков
+type PenalizedResult struct {
+	Results   map[string]int
+	Statuses  map[string]string
+	Deduction int
+}
+
+// ApplyRacePenalties применяет штрафы (ID результата -> штрафы) к результатам гонок и возвращает
+// места, статусы и снятые очки по ID результата. Места меняются в пределах гонки: дисквалифицированный
+// гонщик освобождает место, и гонщики позади поднимаются на позицию; потеря позиций опускает гонщика
+// не ниже последнего места, а гонщики между старым и новым местом поднимаются на позицию.
+// Дисквалификации применяются раньше потери позиций, потеря позиций - в порядке назначения.
+// Исходные результаты не изменяются.
+func ApplyRacePenalties(results []*RaceResult, penalties map[int][]*Penalty) map[int]*PenalizedResult {
+	adjusted := make(map[int]*PenalizedResult, len(results))
+	byRace := make(map[int][]*RaceResult)
+
+	for _, result := range results {
+		penalized := &PenalizedResult{
+			Results:  make(map[string]int, len(result.Results)),
+			Statuses: make(map[string]string, len(result.Statuses)),
+		}
+		for discipline, place := range result.Results {
+			penalized.Results[discipline] = place
+		}
+		for discipline, status := range result.Statuses {
+			penalized.Statuses[discipline] = status
+		}
+
+		for _, penalty := range penalties[result.ID] {
+			if penalty.Type == PenaltyPoints {
+				penalized.Deduction += penalty.Value
+			}
+		}
+
+		adjusted[result.ID] = penalized
+		byRace[result.RaceID] = append(byRace[result.RaceID], result)
+	}
+
+	for _, raceResults := range byRace {
+		applyDisqualifications(raceResults, penalties, adjusted)
+		applyPositionPenalties(raceResults, penalties, adjusted)
+	}
+
+	return adjusted
+}
+
+// applyDisqualifications переводит дисциплины дисквалифицированных гонщиков в DSQ
+// и поднимает гонщиков, стоявших ниже, на освободившиеся места
+func applyDisqualifications(raceResults []*RaceResult, penalties map[int][]*Penalty, adjusted map[int]*PenalizedResult) {
+	freed := make(map[string][]int)
+
+	for _, result := range raceResults {
+		penalized := adjusted[result.ID]
+		for _, penalty := range penalties[result.ID] {
+			if penalty.Type != PenaltyDisqualification {
+				continue
+			}
+
+			place, ok := penalized.Results[penalty.Discipline]
+			if !ok {
+				continue // гонщик не выступал в дисциплине
+			}
+			if place > 0 {
+				freed[penalty.Discipline] = append(freed[penalty.Discipline], place)
+			}
+			SetDisciplineResult(penalized.Results, penalized.Statuses, penalty.Discipline, 0, DisciplineDSQ)
+		}
+	}
+
+	for discipline, places := range freed {
+		for _, result := range raceResults {
+			penalized := adjusted[result.ID]
+			place := penalized.Results[discipline]
+			if place <= 0 {
+				continue
+			}
+
+			shift := 0
+			for _, freedPlace := range places {
+				if freedPlace < place {
+					shift++
+				}
+			}
+			penalized.Results[discipline] = place - shift
+		}
+	}
+}
+
+// applyPositionPenalties опускает оштрафованных гонщиков на указанное число позиций
+// и поднимает гонщиков между старым и новым местом
+func applyPositionPenalties(raceResults []*RaceResult, penalties map[int][]*Penalty, adjusted map[int]*PenalizedResult) {
+	var positions []*Penalty
+	for _, result := range raceResults {
+		for _, penalty := range penalties[result.ID] {
+			if penalty.Type == PenaltyPosition {
+				positions = append(positions, penalty)
+			}
+		}
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positions[i].ID < positions[j].ID
+	})
+
+	for _, penalty := range positions {
+		penalized := adjusted[penalty.ResultID]
+		place := penalized.Results[penalty.Discipline]
+		if place <= 0 || penalty.Value <= 0 {
+			continue // место не указано или гонщик не финишировал
+		}
+
+		last := 0
+		for _, result := range raceResults {
+			if other := adjusted[result.ID].Results[penalty.Discipline]; other > last {
+				last = other
+			}
+		}
+
+		target := place + penalty.Value
+		if target > last {
+			target = last
+		}
+
+		for _, result := range raceResults {
+			if result.ID == penalty.ResultID {
+				continue
+			}
+
+			other := adjusted[result.ID]
+			if otherPlace := other.Results[penalty.Discipline]; otherPlace > place && otherPlace <= target {
+				other.Results[penalty.Discipline] = otherPlace - 1
+			}
+		}
+		penalized.Results[penalty.Discipline] = target
+	}
+}
+
+// CalculateRaceScores считает итоговые очки результатов гонок (ID результата -> очки) с учетом
+// статусов, штрафа за реролл и штрафов стюардов
+func (s *ScoringRules) CalculateRaceScores(results []*RaceResult, penalties map[int][]*Penalty) map[int]int {
+	adjusted := ApplyRacePenalties(results, penalties)
+
+	scores := make(map[int]int, len(results))
+	for _, result := range results {
+		penalized := adjusted[result.ID]
+		scores[result.ID] = s.CalculateTotalScore(penalized.Results, penalized.Statuses, result.RerollPenalty) - penalized.Deduction
+	}
+
+	return scores
+}
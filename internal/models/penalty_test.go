@@ -0,0 +1,243 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyRacePenalties(t *testing.T) {
+	tests := []struct {
+		name          string
+		results       []*RaceResult
+		penalties     map[int][]*Penalty
+		wantResults   map[int]map[string]int
+		wantStatuses  map[int]map[string]string
+		wantDeduction map[int]int
+	}{
+		{
+			name: "без штрафов",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1, "Офроад": 2}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2, "Офроад": 1}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 1, "Офроад": 2},
+				2: {"Драг": 2, "Офроад": 1},
+			},
+		},
+		{
+			name: "снятие очков суммируется и не меняет места",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {
+					{ResultID: 1, Type: PenaltyPoints, Value: 3},
+					{ResultID: 1, Type: PenaltyPoints, Value: 2},
+				},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 1},
+				2: {"Драг": 2},
+			},
+			wantDeduction: map[int]int{1: 5},
+		},
+		{
+			name: "потеря позиций поднимает гонщиков между старым и новым местом",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1, "Офроад": 1}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2, "Офроад": 2}},
+				{ID: 3, RaceID: 1, Results: map[string]int{"Драг": 3, "Офроад": 3}},
+				{ID: 4, RaceID: 1, Results: map[string]int{"Драг": 4, "Офроад": 4}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 2}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 3, "Офроад": 1},
+				2: {"Драг": 1, "Офроад": 2},
+				3: {"Драг": 2, "Офроад": 3},
+				4: {"Драг": 4, "Офроад": 4},
+			},
+		},
+		{
+			name: "потеря позиций не опускает ниже последнего места",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 2}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 3, RaceID: 1, Results: map[string]int{"Драг": 3}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 5}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 3},
+				2: {"Драг": 1},
+				3: {"Драг": 2},
+			},
+		},
+		{
+			name: "штрафы на позиции применяются в порядке назначения",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2}},
+				{ID: 3, RaceID: 1, Results: map[string]int{"Драг": 3}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ID: 2, ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 1}},
+				2: {{ID: 1, ResultID: 2, Type: PenaltyPosition, Discipline: "Драг", Value: 1}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 2},
+				2: {"Драг": 3},
+				3: {"Драг": 1},
+			},
+		},
+		{
+			name: "потеря позиций не трогает дисциплину без места",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1, "Офроад": 0}, Statuses: map[string]string{"Офроад": DisciplineDNS}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2, "Офроад": 1}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Офроад", Value: 1}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 1, "Офроад": 0},
+				2: {"Драг": 2, "Офроад": 1},
+			},
+			wantStatuses: map[int]map[string]string{1: {"Офроад": DisciplineDNS}},
+		},
+		{
+			name: "дисквалификация освобождает место и сильнее потери позиций",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1, "Офроад": 2}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2, "Офроад": 1}},
+				{ID: 3, RaceID: 1, Results: map[string]int{"Драг": 3, "Офроад": 3}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {
+					{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 1},
+					{ResultID: 1, Type: PenaltyDisqualification, Discipline: "Драг"},
+				},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 0, "Офроад": 2},
+				2: {"Драг": 1, "Офроад": 1},
+				3: {"Драг": 2, "Офроад": 3},
+			},
+			wantStatuses: map[int]map[string]string{1: {"Драг": DisciplineDSQ}},
+		},
+		{
+			name: "дисквалификация из дисциплины, где гонщик не выступал",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyDisqualification, Discipline: "Ралли"}},
+			},
+			wantResults: map[int]map[string]int{1: {"Драг": 1}},
+		},
+		{
+			name: "штраф действует только в своей гонке",
+			results: []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2}},
+				{ID: 3, RaceID: 2, Results: map[string]int{"Драг": 2}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 1}},
+			},
+			wantResults: map[int]map[string]int{
+				1: {"Драг": 2},
+				2: {"Драг": 1},
+				3: {"Драг": 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := make(map[int]map[string]int, len(tt.results))
+			for _, result := range tt.results {
+				original[result.ID] = copyPlaces(result.Results)
+			}
+
+			got := ApplyRacePenalties(tt.results, tt.penalties)
+
+			for _, result := range tt.results {
+				penalized := got[result.ID]
+				if !reflect.DeepEqual(penalized.Results, tt.wantResults[result.ID]) {
+					t.Errorf("результат %d: места = %v, want %v", result.ID, penalized.Results, tt.wantResults[result.ID])
+				}
+
+				wantStatuses := tt.wantStatuses[result.ID]
+				if wantStatuses == nil {
+					wantStatuses = map[string]string{}
+				}
+				if !reflect.DeepEqual(penalized.Statuses, wantStatuses) {
+					t.Errorf("результат %d: статусы = %v, want %v", result.ID, penalized.Statuses, wantStatuses)
+				}
+				if penalized.Deduction != tt.wantDeduction[result.ID] {
+					t.Errorf("результат %d: снято очков = %d, want %d", result.ID, penalized.Deduction, tt.wantDeduction[result.ID])
+				}
+				if !reflect.DeepEqual(result.Results, original[result.ID]) {
+					t.Errorf("результат %d: исходные места изменены: %v, было %v", result.ID, result.Results, original[result.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateRaceScores(t *testing.T) {
+	rules := DefaultScoringRules()
+
+	tests := []struct {
+		name      string
+		reroll    int
+		penalties map[int][]*Penalty
+		want      map[int]int
+	}{
+		{name: "без штрафов", want: map[int]int{1: 5, 2: 3, 3: 4}},
+		{name: "штраф за реролл", reroll: 1, want: map[int]int{1: 4, 2: 3, 3: 4}},
+		{
+			name:      "снятие очков",
+			penalties: map[int][]*Penalty{1: {{ResultID: 1, Type: PenaltyPoints, Value: 2}}},
+			want:      map[int]int{1: 3, 2: 3, 3: 4},
+		},
+		{
+			name:      "потеря позиции меняет очки соседа",
+			penalties: map[int][]*Penalty{1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 1}}},
+			want:      map[int]int{1: 4, 2: 4, 3: 4},
+		},
+		{
+			name:      "дисквалификация поднимает гонщиков позади",
+			penalties: map[int][]*Penalty{1: {{ResultID: 1, Type: PenaltyDisqualification, Discipline: "Офроад"}}},
+			want:      map[int]int{1: 3, 2: 4, 3: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []*RaceResult{
+				{ID: 1, RaceID: 1, Results: map[string]int{"Драг": 1, "Офроад": 2}, RerollPenalty: tt.reroll},
+				{ID: 2, RaceID: 1, Results: map[string]int{"Драг": 2, "Офроад": 3}},
+				{ID: 3, RaceID: 1, Results: map[string]int{"Драг": 3, "Офроад": 1}},
+			}
+
+			if got := rules.CalculateRaceScores(results, tt.penalties); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CalculateRaceScores() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// copyPlaces копирует места, чтобы проверить, что функция не меняет исходную карту
+func copyPlaces(places map[string]int) map[string]int {
+	copied := make(map[string]int, len(places))
+	for discipline, place := range places {
+		copied[discipline] = place
+	}
+	return copied
+}
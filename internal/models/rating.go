@@ -0,0 +1,133 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Параметры рейтинга Эло
+const (
+	DefaultRating = 1500.0 // начальный рейтинг гонщика
+	RatingK       = 32.0   // максимальное изменение рейтинга за гонку
+)
+
+// DriverRating представляет текущий рейтинг гонщика
+type DriverRating struct {
+	DriverID   int       `json:"driver_id"`
+	DriverName string    `json:"driver_name"`
+	Rating     float64   `json:"rating"`
+	Races      int       `json:"races"`      // число гонок, учтенных в рейтинге
+	LastDelta  float64   `json:"last_delta"` // изменение рейтинга в последней учтенной гонке
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RatingChange представляет изменение рейтинга гонщика по итогам одной гонки
+type RatingChange struct {
+	DriverID  int       `json:"driver_id"`
+	RaceID    int       `json:"race_id"`
+	RaceName  string    `json:"race_name"`
+	RaceDate  time.Time `json:"race_date"`
+	Before    float64   `json:"before"`
+	After     float64   `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delta возвращает изменение рейтинга
+func (c *RatingChange) Delta() float64 {
+	return c.After - c.Before
+}
+
+// ExpectedScore возвращает ожидаемый результат гонщика с рейтингом a против гонщика с рейтингом b
+func ExpectedScore(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// ratingRank возвращает позицию результата в дисциплине для попарного сравнения.
+// DNF и DSQ проигрывают всем классифицированным, DNS и неучастие в сравнении не участвуют.
+func ratingRank(place int, status string) (int, bool) {
+	switch status {
+	case DisciplineDNF, DisciplineDSQ:
+		return math.MaxInt32, true
+	case DisciplineDNS:
+		return 0, false
+	}
+
+	if place <= 0 {
+		return 0, false
+	}
+	return place, true
+}
+
+// CalculateRatingDeltas считает изменения рейтинга участников одной гонки по попарным сравнениям
+// мест в каждой дисциплине с учетом штрафов. Сумма (фактический - ожидаемый результат) по всем парам
+// нормируется на число сравнений гонщика, так что за гонку рейтинг меняется не больше чем на RatingK.
+// Гонщики без единого сравнения в результат не попадают.
+func CalculateRatingDeltas(ratings map[int]float64, results []*RaceResult, penalties map[int][]*Penalty) map[int]float64 {
+	type entry struct {
+		driverID int
+		places   map[string]int
+		statuses map[string]string
+	}
+
+	entries := make([]entry, 0, len(results))
+	disciplines := make(map[string]bool)
+
+	adjusted := ApplyRacePenalties(results, penalties)
+
+	for _, result := range results {
+		places, statuses := adjusted[result.ID].Results, adjusted[result.ID].Statuses
+		entries = append(entries, entry{driverID: result.DriverID, places: places, statuses: statuses})
+
+		for discipline := range places {
+			disciplines[discipline] = true
+		}
+	}
+
+	rating := func(driverID int) float64 {
+		if r, ok := ratings[driverID]; ok {
+			return r
+		}
+		return DefaultRating
+	}
+
+	surplus := make(map[int]float64)
+	comparisons := make(map[int]int)
+
+	for discipline := range disciplines {
+		for i := range entries {
+			rankI, ok := ratingRank(entries[i].places[discipline], entries[i].statuses[discipline])
+			if !ok {
+				continue
+			}
+
+			for j := i + 1; j < len(entries); j++ {
+				rankJ, ok := ratingRank(entries[j].places[discipline], entries[j].statuses[discipline])
+				if !ok {
+					continue
+				}
+
+				score := 0.5
+				if rankI < rankJ {
+					score = 1
+				} else if rankI > rankJ {
+					score = 0
+				}
+
+				a, b := entries[i].driverID, entries[j].driverID
+				expected := ExpectedScore(rating(a), rating(b))
+
+				surplus[a] += score - expected
+				surplus[b] += expected - score
+				comparisons[a]++
+				comparisons[b]++
+			}
+		}
+	}
+
+	deltas := make(map[int]float64, len(comparisons))
+	for driverID, count := range comparisons {
+		deltas[driverID] = RatingK * surplus[driverID] / float64(count)
+	}
+
+	return deltas
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpectedScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b float64
+		want float64
+	}{
+		{name: "равные рейтинги", a: 1500, b: 1500, want: 0.5},
+		{name: "фаворит на 400", a: 1900, b: 1500, want: 1 / 1.1},
+		{name: "аутсайдер на 400", a: 1500, b: 1900, want: 1 - 1/1.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpectedScore(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ExpectedScore(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRatingDeltas(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratings   map[int]float64
+		results   []*RaceResult
+		penalties map[int][]*Penalty
+		want      map[int]float64
+	}{
+		{
+			name: "победа при равных рейтингах",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 2}},
+			},
+			want: map[int]float64{1: 16, 2: -16},
+		},
+		{
+			name: "ничья",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 1}},
+			},
+			want: map[int]float64{1: 0, 2: 0},
+		},
+		{
+			name: "DNS не участвует в сравнении",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 0}, Statuses: map[string]string{"Драг": DisciplineDNS}},
+			},
+			want: map[int]float64{},
+		},
+		{
+			name: "DNF проигрывает классифицированному",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 0}, Statuses: map[string]string{"Драг": DisciplineDNF}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 5}},
+			},
+			want: map[int]float64{1: -16, 2: 16},
+		},
+		{
+			name: "штраф позициями меняет исход",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 2}},
+			},
+			penalties: map[int][]*Penalty{
+				1: {{ResultID: 1, Type: PenaltyPosition, Discipline: "Драг", Value: 2}},
+			},
+			want: map[int]float64{1: -16, 2: 16},
+		},
+		{
+			name:    "победа фаворита приносит меньше",
+			ratings: map[int]float64{1: 1900},
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 2}},
+			},
+			want: map[int]float64{1: 32 * (1 - 1/1.1), 2: -32 * (1 - 1/1.1)},
+		},
+		{
+			name: "изменение нормируется на число сравнений",
+			results: []*RaceResult{
+				{ID: 1, DriverID: 1, Results: map[string]int{"Драг": 1, "Офроад": 1}},
+				{ID: 2, DriverID: 2, Results: map[string]int{"Драг": 2, "Офроад": 2}},
+				{ID: 3, DriverID: 3, Results: map[string]int{"Драг": 3, "Офроад": 3}},
+			},
+			want: map[int]float64{1: 16, 2: 0, 3: -16},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateRatingDeltas(tt.ratings, tt.results, tt.penalties)
+			if len(got) != len(tt.want) {
+				t.Fatalf("CalculateRatingDeltas() = %v, want %v", got, tt.want)
+			}
+			for driverID, want := range tt.want {
+				if delta, ok := got[driverID]; !ok || math.Abs(delta-want) > 1e-9 {
+					t.Errorf("delta[%d] = %v, want %v", driverID, delta, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// RecordsLimit - сколько строк показывается в каждой таблице зала славы
+const RecordsLimit = 10
+
+// RaceFinish представляет итоговое место гонщика в гонке по сумме очков
+type RaceFinish struct {
+	RaceID     int       `json:"race_id"`
+	RaceName   string    `json:"race_name"`
+	Date       time.Time `json:"date"`
+	DriverID   int       `json:"driver_id"`
+	DriverName string    `json:"driver_name"`
+	Score      int       `json:"score"`
+	Rank       int       `json:"rank"` // гонщики с равными очками делят место
+}
+
+// IsRaceWin проверяет, что гонщик выиграл гонку (как в достижении «Победа в гонке»)
+func (f *RaceFinish) IsRaceWin() bool {
+	return f.Rank == 1 && f.Score > 0
+}
+
+// IsPodium проверяет, что гонщик попал в тройку гонки
+func (f *RaceFinish) IsPodium() bool {
+	return f.Rank >= 1 && f.Rank <= 3
+}
+
+// RecordEntry представляет строку таблицы рекордов
+type RecordEntry struct {
+	DriverID   int    `json:"driver_id"`
+	DriverName string `json:"driver_name"`
+	Value      int    `json:"value"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// CarUsage представляет, сколько раз машина выдавалась на гонки
+type CarUsage struct {
+	CarID       int    `json:"car_id"`
+	CarName     string `json:"car_name"`
+	Year        string `json:"year"`
+	ClassLetter string `json:"class_letter"`
+	ClassNumber int    `json:"class_number"`
+	Races       int    `json:"races"`
+	Drivers     int    `json:"drivers"` // сколько разных гонщиков ездили на машине
+}
+
+// sortRecords сортирует таблицу рекордов по убыванию значения и обрезает ее до limit строк
+func sortRecords(entries []*RecordEntry, limit int) []*RecordEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].DriverName < entries[j].DriverName
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// CountFinishes считает для каждого гонщика гонки, подходящие под условие, и возвращает лучших
+func CountFinishes(finishes []*RaceFinish, match func(*RaceFinish) bool, limit int) []*RecordEntry {
+	byDriver := make(map[int]*RecordEntry)
+	var entries []*RecordEntry
+
+	for _, finish := range finishes {
+		if !match(finish) {
+			continue
+		}
+
+		entry, ok := byDriver[finish.DriverID]
+		if !ok {
+			entry = &RecordEntry{DriverID: finish.DriverID, DriverName: finish.DriverName}
+			byDriver[finish.DriverID] = entry
+			entries = append(entries, entry)
+		}
+		entry.Value++
+	}
+
+	return sortRecords(entries, limit)
+}
+
+// LongestPodiumStreaks находит самые длинные серии подиумов подряд. Серия прерывается гонкой,
+// в которой гонщик участвовал и не попал в тройку; пропущенные гонки серию не прерывают.
+// finishes должны быть упорядочены по дате гонки.
+func LongestPodiumStreaks(finishes []*RaceFinish, limit int) []*RecordEntry {
+	current := make(map[int]int)
+	best := make(map[int]*RecordEntry)
+	var entries []*RecordEntry
+
+	for _, finish := range finishes {
+		if !finish.IsPodium() {
+			current[finish.DriverID] = 0
+			continue
+		}
+
+		current[finish.DriverID]++
+
+		entry, ok := best[finish.DriverID]
+		if !ok {
+			entry = &RecordEntry{DriverID: finish.DriverID, DriverName: finish.DriverName}
+			best[finish.DriverID] = entry
+			entries = append(entries, entry)
+		}
+		if current[finish.DriverID] > entry.Value {
+			entry.Value = current[finish.DriverID]
+		}
+	}
+
+	// Отмечаем серии, которые продолжаются до сих пор
+	for driverID, length := range current {
+		if entry, ok := best[driverID]; ok && length > 0 && length == entry.Value {
+			entry.Detail = "продолжается"
+		}
+	}
+
+	return sortRecords(entries, limit)
+}
+
+// FastestByDiscipline выбирает абсолютно лучшее время каждой дисциплины среди рекордов по классам
+func FastestByDiscipline(records []*TimeRecord) []*TimeRecord {
+	best := make(map[string]*TimeRecord)
+	for _, record := range records {
+		if current, ok := best[record.Discipline]; !ok || record.TimeMs < current.TimeMs {
+			best[record.Discipline] = record
+		}
+	}
+
+	fastest := make([]*TimeRecord, 0, len(best))
+	for _, record := range best {
+		fastest = append(fastest, record)
+	}
+
+	sort.Slice(fastest, func(i, j int) bool {
+		return disciplineOrder(fastest[i].Discipline) < disciplineOrder(fastest[j].Discipline)
+	})
+
+	return fastest
+}
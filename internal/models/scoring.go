@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ScoringRules представляет таблицу начисления очков сезона
+type ScoringRules struct {
+	SeasonID            int       `json:"season_id"`
+	PlacePoints         []int     `json:"place_points"`         // очки за 1, 2, 3... место
+	ParticipationPoints int       `json:"participation_points"` // очки за участие в дисциплине
+	RerollPenalty       int       `json:"reroll_penalty"`       // штраф за реролл машины
+	DNSPoints           int       `json:"dns_points"`           // очки за статус DNS (могут быть отрицательными)
+	DNFPoints           int       `json:"dnf_points"`           // очки за статус DNF
+	DSQPoints           int       `json:"dsq_points"`           // очки за статус DSQ
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// DefaultScoringRules возвращает классическую таблицу очков: 3/2/1 и штраф -1 за реролл
+func DefaultScoringRules() *ScoringRules {
+	return &ScoringRules{
+		PlacePoints:         []int{3, 2, 1},
+		ParticipationPoints: 0,
+		RerollPenalty:       1,
+	}
+}
+
+// PointsForPlace возвращает очки за место в одной дисциплине.
+// Место 0 означает, что гонщик не участвовал в дисциплине.
+func (s *ScoringRules) PointsForPlace(place int) int {
+	if place <= 0 {
+		return 0
+	}
+
+	points := s.ParticipationPoints
+	if place <= len(s.PlacePoints) {
+		points += s.PlacePoints[place-1]
+	}
+
+	return points
+}
+
+// PointsForStatus возвращает очки за статус дисциплины (DNS, DNF, DSQ)
+func (s *ScoringRules) PointsForStatus(status string) int {
+	switch status {
+	case DisciplineDNS:
+		return s.DNSPoints
+	case DisciplineDNF:
+		return s.DNFPoints
+	case DisciplineDSQ:
+		return s.DSQPoints
+	default:
+		return 0
+	}
+}
+
+// CalculateTotalScore считает итоговые очки гонщика за гонку с учетом статусов дисциплин и штрафа за реролл.
+// Дисциплина со статусом приносит очки за статус вместо очков за место.
+func (s *ScoringRules) CalculateTotalScore(results map[string]int, statuses map[string]string, rerollPenalty int) int {
+	total := 0
+	for discipline, place := range results {
+		total += s.DisciplinePoints(place, statuses[discipline])
+	}
+
+	return total - rerollPenalty
+}
+
+// DisciplinePoints возвращает очки за одну дисциплину: за статус, если он указан, иначе за место
+func (s *ScoringRules) DisciplinePoints(place int, status string) int {
+	if status != "" {
+		return s.PointsForStatus(status)
+	}
+	return s.PointsForPlace(place)
+}
+
+// MaxRacePoints возвращает максимум очков за гонку с указанным числом дисциплин
+func (s *ScoringRules) MaxRacePoints(disciplines int) int {
+	return disciplines * s.PointsForPlace(1)
+}
+
+// SerializePlacePoints сериализует очки за места в JSON
+func SerializePlacePoints(points []int) (string, error) {
+	jsonData, err := json.Marshal(points)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// DeserializePlacePoints десериализует очки за места из JSON
+func DeserializePlacePoints(data string) ([]int, error) {
+	var points []int
+	err := json.Unmarshal([]byte(data), &points)
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPointsForPlace(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules ScoringRules
+		place int
+		want  int
+	}{
+		{name: "победа по умолчанию", rules: *DefaultScoringRules(), place: 1, want: 3},
+		{name: "третье место по умолчанию", rules: *DefaultScoringRules(), place: 3, want: 1},
+		{name: "место ниже таблицы", rules: *DefaultScoringRules(), place: 4, want: 0},
+		{name: "не участвовал", rules: *DefaultScoringRules(), place: 0, want: 0},
+		{name: "очки за участие добавляются к месту", rules: ScoringRules{PlacePoints: []int{10, 6}, ParticipationPoints: 1}, place: 2, want: 7},
+		{name: "очки за участие ниже таблицы", rules: ScoringRules{PlacePoints: []int{10, 6}, ParticipationPoints: 1}, place: 5, want: 1},
+		{name: "очки за участие без места не начисляются", rules: ScoringRules{ParticipationPoints: 1}, place: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.PointsForPlace(tt.place); got != tt.want {
+				t.Errorf("PointsForPlace(%d) = %d, want %d", tt.place, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateTotalScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   *ScoringRules
+		results map[string]int
+		reroll  int
+		want    int
+	}{
+		{name: "сумма по дисциплинам", rules: DefaultScoringRules(), results: map[string]int{"Драг": 1, "Ралли": 2, "Офроад": 4}, want: 5},
+		{name: "штраф за реролл", rules: DefaultScoringRules(), results: map[string]int{"Драг": 3}, reroll: 1, want: 0},
+		{name: "итог может быть отрицательным", rules: DefaultScoringRules(), results: map[string]int{"Драг": 0}, reroll: 2, want: -2},
+		{
+			name:    "собственная таблица сезона",
+			rules:   &ScoringRules{PlacePoints: []int{25, 18, 15}, ParticipationPoints: 2},
+			results: map[string]int{"Драг": 1, "Ралли": 3},
+			want:    44,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.CalculateTotalScore(tt.results, nil, tt.reroll); got != tt.want {
+				t.Errorf("CalculateTotalScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxRacePoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       *ScoringRules
+		disciplines int
+		want        int
+	}{
+		{name: "по умолчанию", rules: DefaultScoringRules(), disciplines: 6, want: 18},
+		{name: "с очками за участие", rules: &ScoringRules{PlacePoints: []int{5}, ParticipationPoints: 1}, disciplines: 3, want: 18},
+		{name: "пустая таблица", rules: &ScoringRules{}, disciplines: 3, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.MaxRacePoints(tt.disciplines); got != tt.want {
+				t.Errorf("MaxRacePoints(%d) = %d, want %d", tt.disciplines, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlacePointsRoundTrip(t *testing.T) {
+	points := []int{25, 18, 15, 12}
+
+	data, err := SerializePlacePoints(points)
+	if err != nil {
+		t.Fatalf("SerializePlacePoints() error = %v", err)
+	}
+
+	got, err := DeserializePlacePoints(data)
+	if err != nil {
+		t.Fatalf("DeserializePlacePoints(%q) error = %v", data, err)
+	}
+	if !reflect.DeepEqual(got, points) {
+		t.Errorf("round trip = %v, want %v", got, points)
+	}
+
+	if _, err := DeserializePlacePoints("не json"); err == nil {
+		t.Errorf("DeserializePlacePoints() accepted invalid JSON")
+	}
+}
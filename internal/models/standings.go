@@ -0,0 +1,41 @@
+package models
+
+import "sort"
+
+// StandingsEntry представляет позицию гонщика в турнирной таблице после гонки
+type StandingsEntry struct {
+	SeasonID   int    `json:"season_id"` // 0 - таблица по всем сезонам
+	RaceID     int    `json:"race_id"`
+	DriverID   int    `json:"driver_id"`
+	DriverName string `json:"driver_name"`
+	Position   int    `json:"position"`
+	Points     int    `json:"points"`
+	Gap        int    `json:"gap"` // отставание от лидера в очках
+	PlaceSum   int    `json:"-"`
+	Finishes   int    `json:"-"`
+}
+
+// StandingsLess сравнивает гонщиков для турнирной таблицы: сначала по очкам,
+// затем по среднему месту в дисциплинах (гонщики без финишей ниже)
+func StandingsLess(aPoints, aPlaceSum, aFinishes, bPoints, bPlaceSum, bFinishes int) bool {
+	if aPoints != bPoints {
+		return aPoints > bPoints
+	}
+	if aFinishes == 0 || bFinishes == 0 {
+		return aFinishes > bFinishes
+	}
+	return aPlaceSum*bFinishes < bPlaceSum*aFinishes
+}
+
+// RankStandings сортирует таблицу и проставляет позиции и отставание от лидера
+func RankStandings(entries []*StandingsEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return StandingsLess(entries[i].Points, entries[i].PlaceSum, entries[i].Finishes,
+			entries[j].Points, entries[j].PlaceSum, entries[j].Finishes)
+	})
+
+	for i, entry := range entries {
+		entry.Position = i + 1
+		entry.Gap = entries[0].Points - entry.Points
+	}
+}
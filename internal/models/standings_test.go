@@ -0,0 +1,57 @@
+package models
+
+import "testing"
+
+func TestStandingsLess(t *testing.T) {
+	tests := []struct {
+		name                        string
+		aPoints, aPlaceSum, aFinish int
+		bPoints, bPlaceSum, bFinish int
+		want                        bool
+	}{
+		{name: "больше очков", aPoints: 10, aPlaceSum: 9, aFinish: 3, bPoints: 8, bPlaceSum: 3, bFinish: 3, want: true},
+		{name: "меньше очков", aPoints: 8, aPlaceSum: 3, aFinish: 3, bPoints: 10, bPlaceSum: 9, bFinish: 3, want: false},
+		{name: "лучше среднее место", aPoints: 6, aPlaceSum: 4, aFinish: 2, bPoints: 6, bPlaceSum: 9, bFinish: 3, want: true},
+		{name: "хуже среднее место", aPoints: 6, aPlaceSum: 9, aFinish: 3, bPoints: 6, bPlaceSum: 4, bFinish: 2, want: false},
+		{name: "без финишей ниже", aPoints: 0, aPlaceSum: 0, aFinish: 0, bPoints: 0, bPlaceSum: 12, bFinish: 3, want: false},
+		{name: "с финишами выше", aPoints: 0, aPlaceSum: 12, aFinish: 3, bPoints: 0, bPlaceSum: 0, bFinish: 0, want: true},
+		{name: "полное равенство", aPoints: 5, aPlaceSum: 4, aFinish: 2, bPoints: 5, bPlaceSum: 4, bFinish: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StandingsLess(tt.aPoints, tt.aPlaceSum, tt.aFinish, tt.bPoints, tt.bPlaceSum, tt.bFinish)
+			if got != tt.want {
+				t.Errorf("StandingsLess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankStandings(t *testing.T) {
+	entries := []*StandingsEntry{
+		{DriverID: 1, Points: 6, PlaceSum: 6, Finishes: 3},
+		{DriverID: 2, Points: 9, PlaceSum: 4, Finishes: 3},
+		{DriverID: 3, Points: 6, PlaceSum: 4, Finishes: 3},
+		{DriverID: 4, Points: 0},
+	}
+
+	RankStandings(entries)
+
+	want := []struct {
+		driverID, position, gap int
+	}{
+		{driverID: 2, position: 1, gap: 0},
+		{driverID: 3, position: 2, gap: 3},
+		{driverID: 1, position: 3, gap: 3},
+		{driverID: 4, position: 4, gap: 9},
+	}
+
+	for i, w := range want {
+		entry := entries[i]
+		if entry.DriverID != w.driverID || entry.Position != w.position || entry.Gap != w.gap {
+			t.Errorf("entries[%d] = гонщик %d, позиция %d, отставание %d, want гонщик %d, позиция %d, отставание %d",
+				i, entry.DriverID, entry.Position, entry.Gap, w.driverID, w.position, w.gap)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Статусы результата в дисциплине, когда гонщик не получил классифицированное место
+const (
+	DisciplineDNS = "DNS" // не стартовал
+	DisciplineDNF = "DNF" // сошел с дистанции
+	DisciplineDSQ = "DSQ" // дисквалифицирован
+)
+
+// DisciplineStatuses перечисляет статусы в порядке отображения на клавиатурах
+var DisciplineStatuses = []string{
+	DisciplineDNS,
+	DisciplineDNF,
+	DisciplineDSQ,
+}
+
+// IsDisciplineStatus проверяет, является ли строка статусом дисциплины
+func IsDisciplineStatus(status string) bool {
+	for _, s := range DisciplineStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDisciplineStatus разбирает статус без учета регистра
+func ParseDisciplineStatus(text string) (string, bool) {
+	status := strings.ToUpper(strings.TrimSpace(text))
+	if !IsDisciplineStatus(status) {
+		return "", false
+	}
+	return status, true
+}
+
+// DisciplineStatusName возвращает название статуса
+func DisciplineStatusName(status string) string {
+	switch status {
+	case DisciplineDNS:
+		return "не стартовал"
+	case DisciplineDNF:
+		return "сошел"
+	case DisciplineDSQ:
+		return "дисквалифицирован"
+	default:
+		return status
+	}
+}
+
+// DisciplineStatusEmoji возвращает эмодзи статуса
+func DisciplineStatusEmoji(status string) string {
+	switch status {
+	case DisciplineDNS:
+		return "🚷"
+	case DisciplineDNF:
+		return "💥"
+	case DisciplineDSQ:
+		return "🚫"
+	default:
+		return "➖"
+	}
+}
+
+// SetDisciplineResult записывает в карты результата место или статус дисциплины.
+// Статус обнуляет место, место снимает ранее выставленный статус.
+func SetDisciplineResult(results map[string]int, statuses map[string]string, discipline string, place int, status string) {
+	if status != "" {
+		results[discipline] = 0
+		statuses[discipline] = status
+		return
+	}
+
+	results[discipline] = place
+	delete(statuses, discipline)
+}
+
+// SerializeStatuses сериализует статусы дисциплин в JSON
+func SerializeStatuses(statuses map[string]string) (string, error) {
+	if statuses == nil {
+		return "{}", nil
+	}
+
+	jsonData, err := json.Marshal(statuses)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// DeserializeStatuses десериализует статусы дисциплин из JSON
+func DeserializeStatuses(data string) (map[string]string, error) {
+	statuses := make(map[string]string)
+	if data == "" {
+		return statuses, nil
+	}
+
+	err := json.Unmarshal([]byte(data), &statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if statuses == nil {
+		statuses = make(map[string]string)
+	}
+	return statuses, nil
+}
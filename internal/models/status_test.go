@@ -0,0 +1,120 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDisciplineStatus(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   string
+		wantOK bool
+	}{
+		{text: "DNS", want: DisciplineDNS, wantOK: true},
+		{text: " dnf ", want: DisciplineDNF, wantOK: true},
+		{text: "Dsq", want: DisciplineDSQ, wantOK: true},
+		{text: "1", wantOK: false},
+		{text: "", wantOK: false},
+		{text: "DNQ", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseDisciplineStatus(tt.text)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseDisciplineStatus(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDisciplinePoints(t *testing.T) {
+	rules := &ScoringRules{
+		PlacePoints:         []int{3, 2, 1},
+		ParticipationPoints: 1,
+		DNSPoints:           -2,
+		DNFPoints:           0,
+		DSQPoints:           -5,
+	}
+
+	tests := []struct {
+		name   string
+		place  int
+		status string
+		want   int
+	}{
+		{name: "место", place: 1, want: 4},
+		{name: "DNS", status: DisciplineDNS, want: -2},
+		{name: "DNF без очков за участие", status: DisciplineDNF, want: 0},
+		{name: "DSQ", status: DisciplineDSQ, want: -5},
+		{name: "статус важнее места", place: 1, status: DisciplineDSQ, want: -5},
+		{name: "неизвестный статус", status: "DNQ", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.DisciplinePoints(tt.place, tt.status); got != tt.want {
+				t.Errorf("DisciplinePoints(%d, %q) = %d, want %d", tt.place, tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateTotalScoreWithStatuses(t *testing.T) {
+	rules := DefaultScoringRules()
+	rules.DNFPoints = -1
+
+	results := map[string]int{"Драг": 1, "Офроад": 0, "Ралли": 0}
+	statuses := map[string]string{"Офроад": DisciplineDNF, "Ралли": DisciplineDNS}
+
+	if got := rules.CalculateTotalScore(results, statuses, 1); got != 1 {
+		t.Errorf("CalculateTotalScore() = %d, want 1", got)
+	}
+}
+
+func TestSetDisciplineResult(t *testing.T) {
+	results := map[string]int{"Драг": 2}
+	statuses := map[string]string{}
+
+	SetDisciplineResult(results, statuses, "Драг", 0, DisciplineDNF)
+	if results["Драг"] != 0 || statuses["Драг"] != DisciplineDNF {
+		t.Fatalf("после статуса: место %d, статус %q", results["Драг"], statuses["Драг"])
+	}
+
+	SetDisciplineResult(results, statuses, "Драг", 3, "")
+	if results["Драг"] != 3 {
+		t.Errorf("после места: место %d, want 3", results["Драг"])
+	}
+	if _, ok := statuses["Драг"]; ok {
+		t.Errorf("место не сняло статус: %v", statuses)
+	}
+}
+
+func TestStatusesRoundTrip(t *testing.T) {
+	statuses := map[string]string{"Драг": DisciplineDNS, "Ралли": DisciplineDSQ}
+
+	data, err := SerializeStatuses(statuses)
+	if err != nil {
+		t.Fatalf("SerializeStatuses() error = %v", err)
+	}
+
+	got, err := DeserializeStatuses(data)
+	if err != nil {
+		t.Fatalf("DeserializeStatuses(%q) error = %v", data, err)
+	}
+	if !reflect.DeepEqual(got, statuses) {
+		t.Errorf("round trip = %v, want %v", got, statuses)
+	}
+
+	for _, data := range []string{"", "null"} {
+		got, err := DeserializeStatuses(data)
+		if err != nil || got == nil || len(got) != 0 {
+			t.Errorf("DeserializeStatuses(%q) = %v, %v, want empty map", data, got, err)
+		}
+	}
+
+	if data, err := SerializeStatuses(nil); err != nil || data != "{}" {
+		t.Errorf("SerializeStatuses(nil) = %q, %v, want {}", data, err)
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Ограничения состава команды
+const (
+	TeamMinMembers   = 2
+	TeamMaxMembers   = 3
+	DefaultTeamBadge = "🛡"
+)
+
+// Team представляет команду гонщиков в сезоне
+type Team struct {
+	ID        int       `json:"id"`
+	SeasonID  int       `json:"season_id"`
+	Name      string    `json:"name"`
+	Badge     string    `json:"badge"` // эмодзи команды рядом с именем гонщика
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Label возвращает значок и название команды
+func (t *Team) Label() string {
+	return t.Badge + " " + t.Name
+}
+
+// TeamMember представляет участника команды
+type TeamMember struct {
+	TeamID     int    `json:"team_id"`
+	DriverID   int    `json:"driver_id"`
+	DriverName string `json:"driver_name"`
+	Points     int    `json:"points"` // очки гонщика в сезоне команды
+}
+
+// TeamStanding представляет позицию команды в командном зачете
+type TeamStanding struct {
+	Team     *Team         `json:"team"`
+	Position int           `json:"position"`
+	Points   int           `json:"points"`
+	Members  []*TeamMember `json:"members"`
+}
+
+// IsComplete проверяет, что в команде достаточно гонщиков для зачета
+func (s *TeamStanding) IsComplete() bool {
+	return len(s.Members) >= TeamMinMembers
+}
+
+// ValidateTeamName проверяет название команды
+func ValidateTeamName(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < 2 || length > 30 {
+		return fmt.Errorf("название команды должно содержать от 2 до 30 символов")
+	}
+	return nil
+}
+
+// ValidateTeamBadge проверяет значок команды: один эмодзи, с модификаторами - до 4 кодовых точек
+func ValidateTeamBadge(badge string) error {
+	length := utf8.RuneCountInString(badge)
+	if length < 1 || length > 4 {
+		return fmt.Errorf("значок команды должен быть одним эмодзи")
+	}
+	return nil
+}
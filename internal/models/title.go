@@ -0,0 +1,89 @@
+package models
+
+import "sort"
+
+// RemainingRace представляет незавершенную гонку сезона для расчета борьбы за титул
+type RemainingRace struct {
+	RaceID     int    `json:"race_id"`
+	Name       string `json:"name"`
+	MaxPoints  int    `json:"max_points"`  // максимум очков за гонку: победа во всех дисциплинах
+	DivisionID int    `json:"division_id"` // 0 - гонка открыта для всех
+}
+
+// OpenTo проверяет, может ли гонщик дивизиона выступить в гонке
+func (r *RemainingRace) OpenTo(divisionID int) bool {
+	return r.DivisionID == 0 || r.DivisionID == divisionID
+}
+
+// TitleContender представляет гонщика в борьбе за титул
+type TitleContender struct {
+	DriverID       int    `json:"driver_id"`
+	DriverName     string `json:"driver_name"`
+	Points         int    `json:"points"`
+	PendingPoints  int    `json:"pending_points"`  // максимум очков за неподтвержденные результаты завершенных гонок
+	MaxPoints      int    `json:"max_points"`      // очки, если выиграть все доступные гонки
+	RemainingRaces int    `json:"remaining_races"` // сколько оставшихся гонок доступно гонщику
+	Eliminated     bool   `json:"eliminated"`
+}
+
+// TitleRace представляет математику борьбы за титул сезона
+type TitleRace struct {
+	Contenders     []*TitleContender `json:"contenders"`
+	RemainingRaces int               `json:"remaining_races"`
+	RemainingMax   int               `json:"remaining_max"` // максимум очков во всех оставшихся гонках
+	Champion       *TitleContender   `json:"champion"`      // лидер, обеспечивший титул, иначе nil
+}
+
+// CalculateTitleRace считает максимум достижимых очков каждого гонщика и определяет выбывших.
+// Гонщик выбывает, если даже победы во всех доступных ему гонках не хватит, чтобы догнать лидера
+// (при равенстве очков титул делится, поэтому равенство оставляет шанс). Неподтвержденные результаты
+// еще могут принести гонщику до PendingPoints очков. Лидер обеспечивает титул, когда ни один соперник
+// уже не может его догнать. divisions: ID гонщика -> ID дивизиона.
+func CalculateTitleRace(contenders []*TitleContender, remaining []*RemainingRace, divisions map[int]int) *TitleRace {
+	title := &TitleRace{Contenders: contenders, RemainingRaces: len(remaining)}
+
+	for _, race := range remaining {
+		title.RemainingMax += race.MaxPoints
+	}
+
+	for _, contender := range contenders {
+		contender.MaxPoints = contender.Points + contender.PendingPoints
+		contender.RemainingRaces = 0
+		for _, race := range remaining {
+			if race.OpenTo(divisions[contender.DriverID]) {
+				contender.MaxPoints += race.MaxPoints
+				contender.RemainingRaces++
+			}
+		}
+	}
+
+	sort.SliceStable(contenders, func(i, j int) bool {
+		if contenders[i].Points != contenders[j].Points {
+			return contenders[i].Points > contenders[j].Points
+		}
+		if contenders[i].MaxPoints != contenders[j].MaxPoints {
+			return contenders[i].MaxPoints > contenders[j].MaxPoints
+		}
+		return contenders[i].DriverName < contenders[j].DriverName
+	})
+
+	if len(contenders) == 0 {
+		return title
+	}
+
+	leader := contenders[0]
+	clinched := leader.Points > 0
+
+	for _, contender := range contenders[1:] {
+		contender.Eliminated = contender.MaxPoints < leader.Points
+		if !contender.Eliminated {
+			clinched = false
+		}
+	}
+
+	if clinched {
+		title.Champion = leader
+	}
+
+	return title
+}
@@ -0,0 +1,120 @@
+package models
+
+import "testing"
+
+func TestCalculateTitleRace(t *testing.T) {
+	tests := []struct {
+		name           string
+		contenders     []*TitleContender
+		remaining      []*RemainingRace
+		divisions      map[int]int
+		wantChampion   int // 0 - титул не решен
+		wantEliminated []int
+		wantMax        map[int]int
+	}{
+		{
+			name: "догнать лидера уже невозможно",
+			contenders: []*TitleContender{
+				{DriverID: 2, DriverName: "Борис", Points: 3},
+				{DriverID: 1, DriverName: "Анна", Points: 10},
+			},
+			remaining:      []*RemainingRace{{RaceID: 1, MaxPoints: 6}},
+			wantChampion:   1,
+			wantEliminated: []int{2},
+			wantMax:        map[int]int{1: 16, 2: 9},
+		},
+		{
+			name: "равенство оставляет шанс",
+			contenders: []*TitleContender{
+				{DriverID: 1, DriverName: "Анна", Points: 10},
+				{DriverID: 2, DriverName: "Борис", Points: 4},
+			},
+			remaining: []*RemainingRace{{RaceID: 1, MaxPoints: 6}},
+			wantMax:   map[int]int{1: 16, 2: 10},
+		},
+		{
+			name: "гонка чужого дивизиона не дает шансов",
+			contenders: []*TitleContender{
+				{DriverID: 1, DriverName: "Анна", Points: 10},
+				{DriverID: 2, DriverName: "Борис", Points: 8},
+			},
+			remaining:      []*RemainingRace{{RaceID: 1, MaxPoints: 6, DivisionID: 20}},
+			divisions:      map[int]int{1: 10, 2: 10},
+			wantChampion:   1,
+			wantEliminated: []int{2},
+			wantMax:        map[int]int{1: 10, 2: 8},
+		},
+		{
+			name: "неподтвержденный результат держит борьбу открытой",
+			contenders: []*TitleContender{
+				{DriverID: 1, DriverName: "Анна", Points: 10},
+				{DriverID: 2, DriverName: "Борис", Points: 3, PendingPoints: 7},
+			},
+			wantMax: map[int]int{1: 10, 2: 10},
+		},
+		{
+			name: "без очков чемпиона нет",
+			contenders: []*TitleContender{
+				{DriverID: 1, DriverName: "Анна"},
+				{DriverID: 2, DriverName: "Борис"},
+			},
+			wantMax: map[int]int{1: 0, 2: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title := CalculateTitleRace(tt.contenders, tt.remaining, tt.divisions)
+
+			champion := 0
+			if title.Champion != nil {
+				champion = title.Champion.DriverID
+			}
+			if champion != tt.wantChampion {
+				t.Errorf("champion = %d, want %d", champion, tt.wantChampion)
+			}
+
+			eliminated := make(map[int]bool)
+			for _, id := range tt.wantEliminated {
+				eliminated[id] = true
+			}
+
+			for _, contender := range title.Contenders {
+				if contender.Eliminated != eliminated[contender.DriverID] {
+					t.Errorf("driver %d eliminated = %v, want %v", contender.DriverID,
+						contender.Eliminated, eliminated[contender.DriverID])
+				}
+				if contender.MaxPoints != tt.wantMax[contender.DriverID] {
+					t.Errorf("driver %d max = %d, want %d", contender.DriverID,
+						contender.MaxPoints, tt.wantMax[contender.DriverID])
+				}
+			}
+
+			if title.Contenders[0].Points < title.Contenders[len(title.Contenders)-1].Points {
+				t.Errorf("contenders are not sorted by points")
+			}
+		})
+	}
+}
+
+func TestRemainingRaceOpenTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		race       RemainingRace
+		divisionID int
+		want       bool
+	}{
+		{name: "гонка для всех", race: RemainingRace{}, divisionID: 5, want: true},
+		{name: "свой дивизион", race: RemainingRace{DivisionID: 5}, divisionID: 5, want: true},
+		{name: "чужой дивизион", race: RemainingRace{DivisionID: 5}, divisionID: 6, want: false},
+		{name: "гонщик без дивизиона", race: RemainingRace{DivisionID: 5}, divisionID: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.race.OpenTo(tt.divisionID); got != tt.want {
+				t.Errorf("OpenTo(%d) = %v, want %v", tt.divisionID, got, tt.want)
+			}
+		})
+	}
+}
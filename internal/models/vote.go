@@ -0,0 +1,156 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// JudgedDisciplines перечисляет дисциплины, места в которых определяются голосованием участников
+var JudgedDisciplines = []string{
+	"Визуал",
+}
+
+// Ballot представляет бюллетень гонщика в судейской дисциплине гонки
+type Ballot struct {
+	ID         int       `json:"id"`
+	RaceID     int       `json:"race_id"`
+	Discipline string    `json:"discipline"`
+	VoterID    int       `json:"voter_id"`
+	Ranking    []int     `json:"ranking"` // ID гонщиков от лучшего к худшему
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// VoteTally представляет итог голосования по одному гонщику
+type VoteTally struct {
+	DriverID    int `json:"driver_id"`
+	Points      int `json:"points"`       // сумма очков Борда по всем бюллетеням
+	Ballots     int `json:"ballots"`      // число бюллетеней, в которых оценен гонщик
+	FirstPlaces int `json:"first_places"` // сколько раз гонщик поставлен первым
+	Place       int `json:"place"`
+}
+
+// IsJudgedDiscipline проверяет, определяются ли места дисциплины голосованием
+func IsJudgedDiscipline(discipline string) bool {
+	for _, d := range JudgedDisciplines {
+		if d == discipline {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBallot проверяет, что бюллетень ранжирует каждого участника, кроме голосующего, ровно один раз
+func ValidateBallot(voterID int, ranking []int, participants []int) error {
+	expected := make(map[int]bool, len(participants))
+	for _, id := range participants {
+		if id != voterID {
+			expected[id] = true
+		}
+	}
+
+	seen := make(map[int]bool, len(ranking))
+	for _, id := range ranking {
+		if id == voterID {
+			return fmt.Errorf("нельзя голосовать за себя")
+		}
+		if !expected[id] {
+			return fmt.Errorf("гонщик %d не участвует в гонке", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("гонщик %d указан в бюллетене дважды", id)
+		}
+		seen[id] = true
+	}
+
+	if len(seen) != len(expected) {
+		return fmt.Errorf("в бюллетене должны быть оценены все %d участников", len(expected))
+	}
+
+	return nil
+}
+
+// TallyBallots подсчитывает бюллетени методом Борда и распределяет места между участниками.
+// Место в бюллетене из n гонщиков приносит от n-1 очков за первое до 0 за последнее.
+// Так как гонщик не оценивает себя, сравнивается среднее число очков на бюллетень;
+// при равенстве выше тот, кого чаще ставили первым, затем гонщик с меньшим ID.
+func TallyBallots(ballots []*Ballot, participants []int) []VoteTally {
+	tallies := make(map[int]*VoteTally, len(participants))
+	for _, id := range participants {
+		tallies[id] = &VoteTally{DriverID: id}
+	}
+
+	for _, ballot := range ballots {
+		n := len(ballot.Ranking)
+		for i, id := range ballot.Ranking {
+			tally, ok := tallies[id]
+			if !ok {
+				continue
+			}
+
+			tally.Points += n - 1 - i
+			tally.Ballots++
+			if i == 0 {
+				tally.FirstPlaces++
+			}
+		}
+	}
+
+	result := make([]VoteTally, 0, len(tallies))
+	for _, tally := range tallies {
+		result = append(result, *tally)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.AverageScore() != b.AverageScore() {
+			return a.AverageScore() > b.AverageScore()
+		}
+		if a.FirstPlaces != b.FirstPlaces {
+			return a.FirstPlaces > b.FirstPlaces
+		}
+		return a.DriverID < b.DriverID
+	})
+
+	for i := range result {
+		result[i].Place = i + 1
+	}
+
+	return result
+}
+
+// AverageScore возвращает среднее число очков Борда на бюллетень
+func (t VoteTally) AverageScore() float64 {
+	if t.Ballots == 0 {
+		return 0
+	}
+	return float64(t.Points) / float64(t.Ballots)
+}
+
+// SerializeRanking сериализует порядок гонщиков бюллетеня в JSON
+func SerializeRanking(ranking []int) (string, error) {
+	if ranking == nil {
+		return "[]", nil
+	}
+
+	jsonData, err := json.Marshal(ranking)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// DeserializeRanking десериализует порядок гонщиков бюллетеня из JSON
+func DeserializeRanking(data string) ([]int, error) {
+	var ranking []int
+	if data == "" {
+		return ranking, nil
+	}
+
+	err := json.Unmarshal([]byte(data), &ranking)
+	if err != nil {
+		return nil, err
+	}
+	return ranking, nil
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsJudgedDiscipline(t *testing.T) {
+	tests := []struct {
+		discipline string
+		want       bool
+	}{
+		{discipline: "Визуал", want: true},
+		{discipline: "Драг", want: false},
+		{discipline: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsJudgedDiscipline(tt.discipline); got != tt.want {
+			t.Errorf("IsJudgedDiscipline(%q) = %v, want %v", tt.discipline, got, tt.want)
+		}
+	}
+}
+
+func TestValidateBallot(t *testing.T) {
+	participants := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		name    string
+		voterID int
+		ranking []int
+		wantErr bool
+	}{
+		{name: "все соперники по разу", voterID: 1, ranking: []int{3, 2, 4}},
+		{name: "голос за себя", voterID: 1, ranking: []int{1, 2, 3}, wantErr: true},
+		{name: "гонщик не из гонки", voterID: 1, ranking: []int{2, 3, 5}, wantErr: true},
+		{name: "гонщик дважды", voterID: 1, ranking: []int{2, 2, 3}, wantErr: true},
+		{name: "оценены не все", voterID: 1, ranking: []int{2, 3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBallot(tt.voterID, tt.ranking, participants)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBallot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTallyBallots(t *testing.T) {
+	tests := []struct {
+		name         string
+		participants []int
+		ballots      []*Ballot
+		wantOrder    []int
+	}{
+		{
+			name:         "места по среднему числу очков",
+			participants: []int{1, 2, 3},
+			ballots: []*Ballot{
+				{VoterID: 1, Ranking: []int{2, 3}},
+				{VoterID: 2, Ranking: []int{1, 3}},
+				{VoterID: 3, Ranking: []int{1, 2}},
+			},
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:         "при равенстве выше тот, кого чаще ставили первым",
+			participants: []int{1, 2, 3, 4},
+			ballots: []*Ballot{
+				{VoterID: 3, Ranking: []int{1, 2, 4}},
+				{VoterID: 4, Ranking: []int{3, 2, 1}},
+			},
+			wantOrder: []int{3, 1, 2, 4},
+		},
+		{
+			name:         "полное равенство решается по ID",
+			participants: []int{1, 2, 3},
+			ballots: []*Ballot{
+				{VoterID: 1, Ranking: []int{2, 3}},
+				{VoterID: 2, Ranking: []int{3, 1}},
+				{VoterID: 3, Ranking: []int{1, 2}},
+			},
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:         "без бюллетеней",
+			participants: []int{2, 1},
+			wantOrder:    []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tallies := TallyBallots(tt.ballots, tt.participants)
+
+			order := make([]int, 0, len(tallies))
+			for i, tally := range tallies {
+				if tally.Place != i+1 {
+					t.Errorf("tally[%d].Place = %d, want %d", i, tally.Place, i+1)
+				}
+				order = append(order, tally.DriverID)
+			}
+
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("TallyBallots() order = %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestRankingRoundTrip(t *testing.T) {
+	for _, ranking := range [][]int{{3, 1, 2}, {}} {
+		data, err := SerializeRanking(ranking)
+		if err != nil {
+			t.Fatalf("SerializeRanking(%v) error = %v", ranking, err)
+		}
+
+		got, err := DeserializeRanking(data)
+		if err != nil {
+			t.Fatalf("DeserializeRanking(%q) error = %v", data, err)
+		}
+
+		if len(got) != len(ranking) || (len(ranking) > 0 && !reflect.DeepEqual(got, ranking)) {
+			t.Errorf("round trip = %v, want %v", got, ranking)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package parser
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/config"
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"golang.org/x/net/html"
+)
+
+// minCarCells - минимальное число ячеек в строке таблицы с машиной
+const minCarCells = 11
+
+var (
+	yearPattern  = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+	digitPattern = regexp.MustCompile(`\D`)
+)
+
+// LoadCars загружает таблицу машин из источника: URL страницы вики или путь к сохраненному HTML-файлу
+func LoadCars(source string) ([]*models.Car, error) {
+	if source == "" {
+		source = config.DefaultCarsSource
+	}
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: 30 * time.Second}
+
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка запроса страницы с машинами: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("страница с машинами вернула статус %s", resp.Status)
+		}
+
+		return ParseCars(resp.Body)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла с машинами: %v", err)
+	}
+	defer file.Close()
+
+	return ParseCars(file)
+}
+
+// ParseCars разбирает HTML таблицы машин вики. Строки, которые не удалось разобрать, пропускаются.
+func ParseCars(r io.Reader) ([]*models.Car, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора HTML: %v", err)
+	}
+
+	table := findNode(doc, func(n *html.Node) bool {
+		return n.Data == "table" && hasClass(n, "sortable")
+	})
+	if table == nil {
+		return nil, fmt.Errorf("не удалось найти таблицу с машинами")
+	}
+
+	var cars []*models.Car
+	for _, row := range findAll(table, func(n *html.Node) bool { return n.Data == "tr" }) {
+		cells := findAll(row, func(n *html.Node) bool { return n.Data == "td" })
+		if len(cells) < minCarCells {
+			continue
+		}
+
+		car, ok := parseCarRow(cells)
+		if ok {
+			cars = append(cars, car)
+		}
+	}
+
+	if len(cars) == 0 {
+		return nil, fmt.Errorf("в таблице не найдено ни одной машины")
+	}
+
+	return cars, nil
+}
+
+// parseCarRow разбирает ячейки строки таблицы в машину
+func parseCarRow(cells []*html.Node) (*models.Car, bool) {
+	car := &models.Car{Rarity: "Unknown", Source: "Unknown"}
+
+	// Картинка
+	if link := findNode(cells[0], func(n *html.Node) bool {
+		return n.Data == "a" && hasClass(n, "mw-file-description") && hasClass(n, "image")
+	}); link != nil {
+		car.ImageURL = attr(link, "href")
+	}
+
+	// Название и год
+	nameCell := cells[1]
+	fullName := textContent(nameCell)
+	if div := findByStyle(nameCell, "div", "line-height: 18px"); div != nil {
+		fullName = textContent(div)
+	}
+
+	car.Name = fullName
+	if year := yearPattern.FindString(fullName); year != "" {
+		value, _ := strconv.Atoi(year)
+		car.YearRaw = sql.NullInt64{Int64: int64(value), Valid: true}
+		car.Year = year
+		car.Name = strings.Join(strings.Fields(strings.Replace(fullName, year, "", 1)), " ")
+	}
+
+	if car.Name == "" {
+		return nil, false
+	}
+
+	// Способ получения (Autoshow и т.п.)
+	if div := findByStyle(nameCell, "div", "font-size: smaller"); div != nil {
+		car.Source = textContent(div)
+	}
+
+	// Цена и редкость
+	priceCell := cells[5]
+	if div := findByStyle(priceCell, "div", "line-height: 18px"); div != nil {
+		car.Price = parseDigits(textContent(div))
+	}
+	if span := findByStyle(priceCell, "span", "background-color"); span != nil {
+		car.Rarity = textContent(span)
+	}
+
+	// Характеристики
+	stats := []*float64{&car.Speed, &car.Handling, &car.Acceleration, &car.Launch, &car.Braking}
+	for i, stat := range stats {
+		text := textContent(cells[6+i])
+		if text == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			return nil, false
+		}
+		*stat = value
+	}
+
+	// Класс и индекс производительности
+	if len(cells) > minCarCells {
+		classCell := cells[minCarCells]
+		if span := findByStyle(classCell, "span", "background-color"); span != nil {
+			car.ClassLetter = textContent(span)
+		}
+		if span := findByStyle(classCell, "span", "border:"); span != nil {
+			car.ClassNumber = parseDigits(textContent(span))
+		}
+	}
+
+	return car, true
+}
+
+// parseDigits собирает все цифры строки в число, 0 если цифр нет
+func parseDigits(text string) int {
+	value, err := strconv.Atoi(digitPattern.ReplaceAllString(text, ""))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// findNode ищет первый элемент поддерева, подходящий под условие
+func findNode(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findNode(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findAll ищет все элементы поддерева, подходящие под условие
+func findAll(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	var nodes []*html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && match(child) {
+			nodes = append(nodes, child)
+		}
+		nodes = append(nodes, findAll(child, match)...)
+	}
+	return nodes
+}
+
+// findByStyle ищет элемент с тегом, чей атрибут style содержит подстроку
+func findByStyle(n *html.Node, tag, style string) *html.Node {
+	return findNode(n, func(node *html.Node) bool {
+		return node.Data == tag && strings.Contains(attr(node, "style"), style)
+	})
+}
+
+// attr возвращает значение атрибута элемента
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasClass проверяет, что у элемента есть CSS-класс
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent возвращает текст элемента без лишних пробелов
+func textContent(n *html.Node) string {
+	var b strings.Builder
+
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			b.WriteString(" ")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
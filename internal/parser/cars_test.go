@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// carsPage - фрагмент страницы вики с таблицей машин: заголовок, две машины,
+// строка с нечисловой характеристикой и короткая служебная строка
+const carsPage = `<html><body>
+<table class="wikitable sortable">
+<tr><th>Image</th><th>Car</th><th></th><th></th><th></th><th>Value</th>
+<th>Speed</th><th>Handling</th><th>Acceleration</th><th>Launch</th><th>Braking</th><th>Class</th></tr>
+<tr>
+<td><a href="https://img.example/supra.png" class="mw-file-description image"><img src="x"></a></td>
+<td><div style="line-height: 18px">1998 Toyota  Supra RZ</div><div style="font-size: smaller">Autoshow</div></td>
+<td></td><td></td><td></td>
+<td><div style="line-height: 18px">CR 75,000</div><span style="background-color: #2a7">Rare</span></td>
+<td>6,9</td><td>5.4</td><td>6.8</td><td>5.9</td><td>4.9</td>
+<td><span style="background-color: #f60">A</span><span style="border: 1px solid">800</span></td>
+</tr>
+<tr>
+<td></td>
+<td>Warthog</td>
+<td></td><td></td><td></td>
+<td></td>
+<td></td><td>4.1</td><td></td><td></td><td></td>
+</tr>
+<tr>
+<td></td>
+<td>2020 Broken Stats</td>
+<td></td><td></td><td></td>
+<td></td>
+<td>n/a</td><td>5</td><td>5</td><td>5</td><td>5</td>
+</tr>
+<tr><td colspan="11">Итого</td></tr>
+</table>
+</body></html>`
+
+func TestParseCars(t *testing.T) {
+	cars, err := ParseCars(strings.NewReader(carsPage))
+	if err != nil {
+		t.Fatalf("ParseCars() error = %v", err)
+	}
+
+	want := []*models.Car{
+		{
+			Name:         "Toyota Supra RZ",
+			YearRaw:      sql.NullInt64{Int64: 1998, Valid: true},
+			Year:         "1998",
+			ImageURL:     "https://img.example/supra.png",
+			Price:        75000,
+			Rarity:       "Rare",
+			Speed:        6.9,
+			Handling:     5.4,
+			Acceleration: 6.8,
+			Launch:       5.9,
+			Braking:      4.9,
+			ClassLetter:  "A",
+			ClassNumber:  800,
+			Source:       "Autoshow",
+		},
+		{
+			Name:     "Warthog",
+			Rarity:   "Unknown",
+			Handling: 4.1,
+			Source:   "Unknown",
+		},
+	}
+
+	if len(cars) != len(want) {
+		t.Fatalf("ParseCars() вернул %d машин, want %d", len(cars), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(cars[i], want[i]) {
+			t.Errorf("машина %d = %+v, want %+v", i, cars[i], want[i])
+		}
+	}
+}
+
+func TestParseCarsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+	}{
+		{name: "нет таблицы", page: `<html><body><p>пусто</p></body></html>`},
+		{name: "таблица без машин", page: `<table class="sortable"><tr><th>Car</th></tr></table>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCars(strings.NewReader(tt.page)); err == nil {
+				t.Errorf("ParseCars() принял страницу без машин")
+			}
+		})
+	}
+}
+
+func TestLoadCarsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cars.html")
+	if err := os.WriteFile(path, []byte(carsPage), 0o644); err != nil {
+		t.Fatalf("ошибка записи файла: %v", err)
+	}
+
+	cars, err := LoadCars(path)
+	if err != nil {
+		t.Fatalf("LoadCars(%q) error = %v", path, err)
+	}
+	if len(cars) != 2 {
+		t.Errorf("LoadCars() вернул %d машин, want 2", len(cars))
+	}
+
+	if _, err := LoadCars(filepath.Join(t.TempDir(), "missing.html")); err == nil {
+		t.Errorf("LoadCars() не вернул ошибку для отсутствующего файла")
+	}
+}
@@ -0,0 +1,312 @@
+package parser
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// Форматы файла каталога машин
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// RowError представляет ошибку проверки строки файла каталога
+type RowError struct {
+	Row     int // номер строки CSV (с заголовком) или элемента JSON (с 1)
+	Message string
+}
+
+// Error возвращает текст ошибки строки
+func (e *RowError) Error() string {
+	return fmt.Sprintf("строка %d: %s", e.Row, e.Message)
+}
+
+// CatalogColumns возвращает колонки каталога - JSON-теги полей models.Car
+func CatalogColumns() []string {
+	var columns []string
+	carType := reflect.TypeOf(models.Car{})
+	for i := 0; i < carType.NumField(); i++ {
+		if tag := jsonName(carType.Field(i)); tag != "" {
+			columns = append(columns, tag)
+		}
+	}
+	return columns
+}
+
+// FormatFromName определяет формат каталога по расширению файла
+func FormatFromName(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат файла %s: поддерживаются .csv и .json", name)
+	}
+}
+
+// WriteCatalog выгружает машины в CSV или JSON
+func WriteCatalog(w io.Writer, format string, cars []*models.Car) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(cars); err != nil {
+			return fmt.Errorf("ошибка записи JSON: %v", err)
+		}
+		return nil
+	case FormatCSV:
+		columns := CatalogColumns()
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return fmt.Errorf("ошибка записи CSV: %v", err)
+		}
+
+		for _, car := range cars {
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				record[i] = carField(car, column)
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("ошибка записи CSV: %v", err)
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("ошибка записи CSV: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("неизвестный формат каталога: %s", format)
+	}
+}
+
+// ReadCatalog читает машины из CSV или JSON. Ошибки отдельных строк собираются в список,
+// ошибка возвращается, только если файл нельзя разобрать целиком.
+func ReadCatalog(r io.Reader, format string) ([]*models.Car, []*RowError, error) {
+	switch format {
+	case FormatJSON:
+		return readJSONCatalog(r)
+	case FormatCSV:
+		return readCSVCatalog(r)
+	default:
+		return nil, nil, fmt.Errorf("неизвестный формат каталога: %s", format)
+	}
+}
+
+// readJSONCatalog читает массив машин в JSON
+func readJSONCatalog(r io.Reader) ([]*models.Car, []*RowError, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, nil, fmt.Errorf("ошибка разбора JSON: ожидается массив машин: %v", err)
+	}
+
+	var cars []*models.Car
+	var rowErrors []*RowError
+
+	for i, item := range items {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(item, &fields); err != nil {
+			rowErrors = append(rowErrors, &RowError{Row: i + 1, Message: "ожидается объект машины"})
+			continue
+		}
+
+		// Год в выгрузке - строка, но в таблицах его часто пишут числом
+		if year, ok := fields["year"]; ok && len(year) > 0 && year[0] != '"' && string(year) != "null" {
+			fields["year"] = json.RawMessage(strconv.Quote(string(year)))
+			item, _ = json.Marshal(fields)
+		}
+
+		var car models.Car
+		decoder := json.NewDecoder(strings.NewReader(string(item)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&car); err != nil {
+			rowErrors = append(rowErrors, &RowError{Row: i + 1, Message: err.Error()})
+			continue
+		}
+
+		if err := normalizeCar(&car); err != nil {
+			rowErrors = append(rowErrors, &RowError{Row: i + 1, Message: err.Error()})
+			continue
+		}
+		cars = append(cars, &car)
+	}
+
+	return cars, rowErrors, nil
+}
+
+// readCSVCatalog читает машины из CSV с заголовком из колонок каталога
+func readCSVCatalog(r io.Reader) ([]*models.Car, []*RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка чтения заголовка CSV: %v", err)
+	}
+
+	known := make(map[string]bool)
+	for _, column := range CatalogColumns() {
+		known[column] = true
+	}
+
+	hasName := false
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !known[column] {
+			return nil, nil, fmt.Errorf("неизвестная колонка %q, допустимые: %s", column, strings.Join(CatalogColumns(), ", "))
+		}
+		if column == "name" {
+			hasName = true
+		}
+		header[i] = column
+	}
+
+	if !hasName {
+		return nil, nil, fmt.Errorf("в CSV нет обязательной колонки name")
+	}
+
+	var cars []*models.Car
+	var rowErrors []*RowError
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &RowError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, &RowError{Row: row,
+				Message: fmt.Sprintf("ожидается колонок: %d, получено: %d", len(header), len(record))})
+			continue
+		}
+
+		var car models.Car
+		var fieldErr error
+		for i, column := range header {
+			if fieldErr = setCarField(&car, column, strings.TrimSpace(record[i])); fieldErr != nil {
+				break
+			}
+		}
+
+		if fieldErr == nil {
+			fieldErr = normalizeCar(&car)
+		}
+
+		if fieldErr != nil {
+			rowErrors = append(rowErrors, &RowError{Row: row, Message: fieldErr.Error()})
+			continue
+		}
+		cars = append(cars, &car)
+	}
+
+	return cars, rowErrors, nil
+}
+
+// normalizeCar заполняет год для базы данных и проверяет машину
+func normalizeCar(car *models.Car) error {
+	car.Name = strings.Join(strings.Fields(car.Name), " ")
+	car.Year = strings.TrimSpace(car.Year)
+
+	car.YearRaw = sql.NullInt64{}
+	if car.Year != "" && car.Year != models.CarYearUnknown {
+		year, err := strconv.Atoi(car.Year)
+		if err != nil {
+			return fmt.Errorf("year: ожидается число, получено %q", car.Year)
+		}
+		car.YearRaw = sql.NullInt64{Int64: int64(year), Valid: true}
+	}
+
+	return models.ValidateCar(car)
+}
+
+// jsonName возвращает имя поля в JSON или пустую строку для скрытых полей
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// carFieldValue возвращает поле машины по имени колонки каталога
+func carFieldValue(car *models.Car, column string) (reflect.Value, bool) {
+	value := reflect.ValueOf(car).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if jsonName(value.Type().Field(i)) == column {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// carField форматирует поле машины для CSV
+func carField(car *models.Car, column string) string {
+	field, ok := carFieldValue(car, column)
+	if !ok {
+		return ""
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String()
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// setCarField заполняет поле машины значением из CSV
+func setCarField(car *models.Car, column, text string) error {
+	field, ok := carFieldValue(car, column)
+	if !ok {
+		return fmt.Errorf("неизвестная колонка %s", column)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int64:
+		if text == "" {
+			return nil
+		}
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: ожидается целое число, получено %q", column, text)
+		}
+		field.SetInt(value)
+	case reflect.Float64:
+		if text == "" {
+			return nil
+		}
+		value, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			return fmt.Errorf("%s: ожидается число, получено %q", column, text)
+		}
+		field.SetFloat(value)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"bytes"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+func TestFormatFromName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "cars.csv", want: FormatCSV},
+		{name: "Cars.JSON", want: FormatJSON},
+		{name: "cars.xlsx", wantErr: true},
+		{name: "cars", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatFromName(tt.name)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("FormatFromName(%q) = %q, %v, want %q, ошибка %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadCSVCatalogRowErrors(t *testing.T) {
+	data := "\ufeffName, year, class_letter, class_number, price, speed\n" +
+		"Toyota  Supra RZ,1998,A,800,75000,\"6,9\"\n" +
+		",2001,B,650,0,5\n" +
+		"Ford GT,twenty,S1,900,0,7\n" +
+		"Mini,1965,D,100,-5,3\n" +
+		"Jeep,,C,500,0,10.5\n" +
+		"Short,2000\n" +
+		"Warthog,нет информации,D,400,0,4\n"
+
+	cars, rowErrors, err := ReadCatalog(strings.NewReader(data), FormatCSV)
+	if err != nil {
+		t.Fatalf("ReadCatalog() error = %v", err)
+	}
+
+	wantCars := []*models.Car{
+		{
+			Name:        "Toyota Supra RZ",
+			Year:        "1998",
+			YearRaw:     sql.NullInt64{Int64: 1998, Valid: true},
+			ClassLetter: "A",
+			ClassNumber: 800,
+			Price:       75000,
+			Speed:       6.9,
+		},
+		{Name: "Warthog", Year: models.CarYearUnknown, ClassLetter: "D", ClassNumber: 400, Speed: 4},
+	}
+	if !reflect.DeepEqual(cars, wantCars) {
+		t.Errorf("машины = %+v, want %+v", cars, wantCars)
+	}
+
+	wantRows := []int{3, 4, 5, 6, 7}
+	if len(rowErrors) != len(wantRows) {
+		t.Fatalf("ошибки строк = %v, want строки %v", rowErrors, wantRows)
+	}
+	for i, row := range wantRows {
+		if rowErrors[i].Row != row {
+			t.Errorf("ошибка %d относится к строке %d, want %d: %v", i, rowErrors[i].Row, row, rowErrors[i])
+		}
+	}
+}
+
+func TestReadCSVCatalogHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "пустой файл", data: ""},
+		{name: "неизвестная колонка", data: "name,color\nSupra,red\n"},
+		{name: "нет колонки name", data: "year,class_letter\n1998,A\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadCatalog(strings.NewReader(tt.data), FormatCSV); err == nil {
+				t.Errorf("ReadCatalog() принял некорректный заголовок")
+			}
+		})
+	}
+}
+
+func TestReadJSONCatalogRowErrors(t *testing.T) {
+	data := `[
+		{"name": "Toyota Supra RZ", "year": 1998, "class_letter": "A", "class_number": 800},
+		"не объект",
+		{"name": "Ford GT", "class_letter": "S1", "color": "red"},
+		{"name": "Mini", "year": "1800", "class_letter": "D"},
+		{"name": "Jeep", "year": null, "class_letter": "C"}
+	]`
+
+	cars, rowErrors, err := ReadCatalog(strings.NewReader(data), FormatJSON)
+	if err != nil {
+		t.Fatalf("ReadCatalog() error = %v", err)
+	}
+
+	if len(cars) != 2 || cars[0].YearRaw.Int64 != 1998 || cars[0].Year != "1998" || cars[1].Name != "Jeep" || cars[1].YearRaw.Valid {
+		t.Errorf("машины = %+v", cars)
+	}
+
+	wantRows := []int{2, 3, 4}
+	if len(rowErrors) != len(wantRows) {
+		t.Fatalf("ошибки строк = %v, want элементы %v", rowErrors, wantRows)
+	}
+	for i, row := range wantRows {
+		if rowErrors[i].Row != row {
+			t.Errorf("ошибка %d относится к элементу %d, want %d: %v", i, rowErrors[i].Row, row, rowErrors[i])
+		}
+	}
+
+	if _, _, err := ReadCatalog(strings.NewReader(`{"name": "Supra"}`), FormatJSON); err == nil {
+		t.Errorf("ReadCatalog() принял JSON без массива")
+	}
+}
+
+func TestCatalogRoundTrip(t *testing.T) {
+	cars := []*models.Car{
+		{
+			Name:         "Toyota Supra RZ",
+			Year:         "1998",
+			YearRaw:      sql.NullInt64{Int64: 1998, Valid: true},
+			ImageURL:     "https://img.example/supra.png",
+			Price:        75000,
+			Rarity:       "Rare",
+			Speed:        6.9,
+			Handling:     5.4,
+			Acceleration: 6.8,
+			Launch:       5.9,
+			Braking:      4.9,
+			ClassLetter:  "A",
+			ClassNumber:  800,
+			Source:       "Autoshow",
+		},
+		{Name: "Warthog", Year: models.CarYearUnknown, ClassLetter: "D", ClassNumber: 400},
+	}
+
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteCatalog(&buf, format, cars); err != nil {
+				t.Fatalf("WriteCatalog() error = %v", err)
+			}
+
+			got, rowErrors, err := ReadCatalog(&buf, format)
+			if err != nil || len(rowErrors) > 0 {
+				t.Fatalf("ReadCatalog() = %v, %v", rowErrors, err)
+			}
+			if !reflect.DeepEqual(got, cars) {
+				t.Errorf("round trip = %+v, want %+v", got, cars)
+			}
+		})
+	}
+}
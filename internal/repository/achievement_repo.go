@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// AchievementRepository представляет репозиторий для работы с достижениями гонщиков
+type AchievementRepository struct {
+	db *sql.DB
+}
+
+// NewAchievementRepository создает новый репозиторий достижений
+func NewAchievementRepository(db *sql.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+// award сохраняет достижение и возвращает true, если гонщик получил его впервые
+func award(tx *sql.Tx, driverID int, code string, seasonID int, raceID sql.NullInt64, earnedAt time.Time) (bool, error) {
+	res, err := tx.Exec(`
+		INSERT INTO driver_achievements (driver_id, code, season_id, race_id, earned_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (driver_id, code, season_id) DO NOTHING
+	`, driverID, code, seasonID, raceID, earnedAt)
+	if err != nil {
+		return false, fmt.Errorf("ошибка сохранения достижения: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения количества сохраненных достижений: %v", err)
+	}
+
+	return affected > 0, nil
+}
+
+// EvaluateRace проверяет правила достижений по подтвержденным результатам завершенной гонки
+// и выдает новые достижения с датой гонки. Возвращает коды новых достижений по ID гонщиков.
+func (r *AchievementRepository) EvaluateRace(raceID int) (map[int][]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var raceDate time.Time
+	var completed bool
+	err = tx.QueryRow("SELECT date, completed FROM races WHERE id = $1", raceID).Scan(&raceDate, &completed)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения гонки: %v", err)
+	}
+
+	if !completed {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT `+resultColumns+`
+		FROM race_results rr
+		WHERE rr.race_id = $1 AND rr.confirmation_status = $2
+		ORDER BY rr.id
+	`, raceID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения результатов гонки: %v", err)
+	}
+
+	var results []*models.RaceResult
+
+	for rows.Next() {
+		var result models.RaceResult
+		if err := scanResult(rows, &result); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+	rows.Close()
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	penalties, err := queryPenalties(tx, "p.result_id IN (SELECT id FROM race_results WHERE race_id = $1)", raceID)
+	if err != nil {
+		return nil, err
+	}
+	// Правила проверяются по местам и статусам с учетом штрафов стюардов
+	adjusted := models.ApplyRacePenalties(results, groupPenaltiesByResult(penalties))
+	for _, result := range results {
+		result.Results, result.Statuses = adjusted[result.ID].Results, adjusted[result.ID].Statuses
+	}
+
+	awarded := make(map[int][]string)
+
+	for _, result := range results {
+		// Гонки считаются по дате гонки, чтобы пересчет задним числом давал тот же результат
+		var races int
+		err := tx.QueryRow(`
+			SELECT COUNT(DISTINCT r.id)
+			FROM race_results rr
+			JOIN races r ON r.id = rr.race_id
+			WHERE rr.driver_id = $1 AND rr.confirmation_status = $2 AND r.completed = true
+				AND (r.date < $3 OR (r.date = $3 AND r.id <= $4))
+		`, result.DriverID, models.ResultStatusConfirmed, raceDate, raceID).Scan(&races)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка подсчета гонок гонщика: %v", err)
+		}
+
+		ctx := &models.AchievementContext{
+			Result:      result,
+			RaceResults: results,
+			Races:       races,
+		}
+
+		for _, code := range models.EvaluateAchievements(ctx) {
+			isNew, err := award(tx, result.DriverID, code, 0, sql.NullInt64{Int64: int64(raceID), Valid: true}, raceDate)
+			if err != nil {
+				return nil, err
+			}
+			if isNew {
+				awarded[result.DriverID] = append(awarded[result.DriverID], code)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return awarded, nil
+}
+
+// EvaluateSeason выдает достижение чемпиона завершенного сезона гонщикам с наибольшей суммой очков
+// (при равенстве чемпионами становятся все лидеры). Возвращает ID гонщиков, получивших достижение впервые.
+// Для активного сезона ничего не выдается.
+func (r *AchievementRepository) EvaluateSeason(seasonID int) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var active bool
+	var endDate sql.NullTime
+	err = tx.QueryRow("SELECT active, end_date FROM seasons WHERE id = $1", seasonID).Scan(&active, &endDate)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сезона: %v", err)
+	}
+
+	if active || !endDate.Valid {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(`
+		WITH totals AS (
+			SELECT rr.driver_id, SUM(rr.total_score) AS score
+			FROM race_results rr
+			JOIN races r ON r.id = rr.race_id
+			WHERE r.season_id = $1 AND r.completed = true AND rr.confirmation_status = $2
+			GROUP BY rr.driver_id
+		)
+		SELECT driver_id
+		FROM totals
+		WHERE score > 0 AND score = (SELECT MAX(score) FROM totals)
+		ORDER BY driver_id
+	`, seasonID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лидеров сезона: %v", err)
+	}
+
+	var leaders []int
+
+	for rows.Next() {
+		var driverID int
+		if err := rows.Scan(&driverID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка сканирования лидера сезона: %v", err)
+		}
+		leaders = append(leaders, driverID)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("ошибка итерации по лидерам сезона: %v", err)
+	}
+	rows.Close()
+
+	var champions []int
+
+	for _, driverID := range leaders {
+		isNew, err := award(tx, driverID, models.AchievementSeasonChampion, seasonID, sql.NullInt64{}, endDate.Time)
+		if err != nil {
+			return nil, err
+		}
+		if isNew {
+			champions = append(champions, driverID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return champions, nil
+}
+
+// EvaluateAll проверяет достижения по всем завершенным гонкам и сезонам в хронологическом порядке.
+// Используется для выдачи достижений за гонки, завершенные до появления правил.
+// Возвращает количество новых достижений.
+func (r *AchievementRepository) EvaluateAll() (int, error) {
+	raceIDs, err := r.queryIDs("SELECT id FROM races WHERE completed = true ORDER BY date, id")
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения завершенных гонок: %v", err)
+	}
+
+	total := 0
+
+	for _, raceID := range raceIDs {
+		awarded, err := r.EvaluateRace(raceID)
+		if err != nil {
+			return total, err
+		}
+		for _, codes := range awarded {
+			total += len(codes)
+		}
+	}
+
+	seasonIDs, err := r.queryIDs("SELECT id FROM seasons WHERE active = false AND end_date IS NOT NULL ORDER BY end_date, id")
+	if err != nil {
+		return total, fmt.Errorf("ошибка получения завершенных сезонов: %v", err)
+	}
+
+	for _, seasonID := range seasonIDs {
+		champions, err := r.EvaluateSeason(seasonID)
+		if err != nil {
+			return total, err
+		}
+		total += len(champions)
+	}
+
+	return total, nil
+}
+
+// queryIDs выполняет запрос, возвращающий столбец идентификаторов
+func (r *AchievementRepository) queryIDs(query string, args ...interface{}) ([]int, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/athebyme/forza-top-gear-bot/internal/models"
@@ -50,6 +51,7 @@ func (r *CarRepository) GetByID(id int) (*models.Car, error) {
 		&car.Source,
 	)
 
+	car.YearRaw = yearRaw
 	if yearRaw.Valid {
 		car.Year = fmt.Sprintf("%d", yearRaw.Int64)
 	} else {
@@ -72,7 +74,7 @@ func (r *CarRepository) GetByClass(classLetter string) ([]*models.Car, error) {
 		SELECT id, name, year, image_url, price, rarity, speed, handling, 
 		       acceleration, launch, braking, class_letter, class_number, source
 		FROM cars 
-		WHERE class_letter = $1
+		WHERE class_letter = $1 AND retired_version_id IS NULL
 		ORDER BY name, year
 	`
 
@@ -108,6 +110,7 @@ func (r *CarRepository) GetByClass(classLetter string) ([]*models.Car, error) {
 		if err != nil {
 			return nil, fmt.Errorf("ошибка сканирования данных машины: %v", err)
 		}
+		car.YearRaw = yearRaw
 		if yearRaw.Valid {
 			car.Year = fmt.Sprintf("%d", yearRaw.Int64)
 		} else {
@@ -130,6 +133,7 @@ func (r *CarRepository) GetAll() ([]*models.Car, error) {
 		SELECT id, name, year, image_url, price, rarity, speed, handling, 
 		       acceleration, launch, braking, class_letter, class_number, source
 		FROM cars 
+		WHERE retired_version_id IS NULL
 		ORDER BY name, year
 	`
 
@@ -165,6 +169,7 @@ func (r *CarRepository) GetAll() ([]*models.Car, error) {
 			return nil, fmt.Errorf("ошибка сканирования данных машины: %v", err)
 		}
 
+		car.YearRaw = yearRaw
 		if yearRaw.Valid {
 			car.Year = fmt.Sprintf("%d", yearRaw.Int64)
 		} else {
@@ -183,7 +188,7 @@ func (r *CarRepository) GetAll() ([]*models.Car, error) {
 
 // CountByClass подсчитывает количество машин определенного класса
 func (r *CarRepository) CountByClass(classLetter string) (int, error) {
-	query := `SELECT COUNT(*) FROM cars WHERE class_letter = $1`
+	query := `SELECT COUNT(*) FROM cars WHERE class_letter = $1 AND retired_version_id IS NULL`
 
 	var count int
 	err := r.db.QueryRow(query, classLetter).Scan(&count)
@@ -196,7 +201,13 @@ func (r *CarRepository) CountByClass(classLetter string) (int, error) {
 
 // GetClassCounts возвращает количество машин по каждому классу
 func (r *CarRepository) GetClassCounts() (map[string]int, error) {
-	query := `SELECT class_letter, COUNT(*) FROM cars GROUP BY class_letter ORDER BY class_letter`
+	query := `
+		SELECT class_letter, COUNT(*)
+		FROM cars
+		WHERE retired_version_id IS NULL
+		GROUP BY class_letter
+		ORDER BY class_letter
+	`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -225,23 +236,24 @@ func (r *CarRepository) GetClassCounts() (map[string]int, error) {
 	return counts, nil
 }
 
-// AssignRandomCars назначает случайные машины для гонки
+// AssignRandomCars назначает случайные машины из пула гонки
 func (r *CarRepository) AssignRandomCars(tx *sql.Tx, raceID int, driverIDs []int, carClass string) ([]*models.CarAssignmentResult, error) {
-	// Получаем все машины указанного класса
-	cars, err := r.GetByClass(carClass)
+	// Получаем машины из пула гонки
+	cars, err := r.GetPoolCars(raceID, carClass)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(cars) == 0 {
-		return nil, fmt.Errorf("нет машин класса %s", carClass)
+		return nil, fmt.Errorf("в пуле гонки нет машин класса %s", carClass)
 	}
 
 	// Получаем количество машин в классе
 	carCount := len(cars)
 
-	// Определяем максимальный номер машины (количество машин * 1.7)
-	maxCarNumber := int(float64(carCount) * 1.7)
+	// Определяем максимальный номер машины (количество машин * 1.7). В маленьком пуле
+	// номеров должно хватить на всех гонщиков, иначе уникальный номер не найдется.
+	maxCarNumber := max(int(float64(carCount)*1.7), len(driverIDs))
 
 	// Устанавливаем сид для генератора случайных чисел
 	rand.Seed(time.Now().UnixNano())
@@ -335,6 +347,63 @@ func (r *CarRepository) AssignRandomCars(tx *sql.Tx, raceID int, driverIDs []int
 	return results, nil
 }
 
+// GetRacePool получает фильтры пула машин гонки. Если пул не настроен, возвращается
+// пустой пул - все машины класса гонки.
+func (r *CarRepository) GetRacePool(raceID int) (*models.CarPool, error) {
+	var filters []byte
+	err := r.db.QueryRow("SELECT filters FROM race_car_pools WHERE race_id = $1", raceID).Scan(&filters)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &models.CarPool{}, nil
+		}
+		return nil, fmt.Errorf("ошибка получения пула машин: %v", err)
+	}
+
+	return models.DeserializeCarPool(filters)
+}
+
+// SaveRacePool сохраняет фильтры пула машин гонки. Пустой пул удаляется.
+func (r *CarRepository) SaveRacePool(raceID int, pool *models.CarPool) error {
+	if pool.IsEmpty() {
+		_, err := r.db.Exec("DELETE FROM race_car_pools WHERE race_id = $1", raceID)
+		if err != nil {
+			return fmt.Errorf("ошибка сброса пула машин: %v", err)
+		}
+		return nil
+	}
+
+	filters, err := models.SerializeCarPool(pool)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO race_car_pools (race_id, filters, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (race_id) DO UPDATE SET filters = EXCLUDED.filters, updated_at = EXCLUDED.updated_at
+	`, raceID, filters)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения пула машин: %v", err)
+	}
+
+	return nil
+}
+
+// GetPoolCars получает машины класса гонки, которые проходят фильтры ее пула
+func (r *CarRepository) GetPoolCars(raceID int, carClass string) ([]*models.Car, error) {
+	cars, err := r.GetByClass(carClass)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := r.GetRacePool(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.Filter(cars), nil
+}
+
 // GetRaceCarAssignments получает назначения машин для гонки
 func (r *CarRepository) GetRaceCarAssignments(raceID int) ([]*models.RaceCarAssignment, error) {
 	query := `
@@ -389,6 +458,7 @@ func (r *CarRepository) GetRaceCarAssignments(raceID int) ([]*models.RaceCarAssi
 			return nil, fmt.Errorf("ошибка сканирования данных назначения: %v", err)
 		}
 
+		car.YearRaw = yearRaw
 		if yearRaw.Valid {
 			car.Year = fmt.Sprintf("%d", yearRaw.Int64)
 		} else {
@@ -466,7 +536,7 @@ func (r *CarRepository) AssignCarsToRegisteredDrivers(tx *sql.Tx, raceID int, ca
 	return r.AssignRandomCars(tx, raceID, driverIDs, carClass)
 }
 
-// RerollCarForDriver assigns a new random car to a driver
+// RerollCarForDriver assigns a new random car from the race pool to a driver
 func (r *CarRepository) RerollCarForDriver(tx *sql.Tx, raceID int, driverID int, carClass string) (*models.CarAssignmentResult, error) {
 	// Get the current car assignment
 	var currentAssignment models.RaceCarAssignment
@@ -481,35 +551,31 @@ func (r *CarRepository) RerollCarForDriver(tx *sql.Tx, raceID int, driverID int,
 		return nil, fmt.Errorf("ошибка получения текущего назначения: %v", err)
 	}
 
-	// Get all cars of the specified class
-	cars, err := r.GetByClass(carClass)
+	// Get the cars of the race pool
+	cars, err := r.GetPoolCars(raceID, carClass)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(cars) == 0 {
-		return nil, fmt.Errorf("нет машин класса %s", carClass)
+	// A reroll must give a different car, so the current one is not a candidate
+	var candidates []*models.Car
+	for _, car := range cars {
+		if car.ID != currentCarID {
+			candidates = append(candidates, car)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("в пуле гонки нет другой машины класса %s для реролла", carClass)
 	}
 
 	// Get car count and set up random generation
-	carCount := len(cars)
-	maxCarNumber := int(float64(carCount) * 1.7)
+	carCount := len(candidates)
+	maxCarNumber := max(int(float64(carCount)*1.7), 1)
 	rand.Seed(time.Now().UnixNano())
 
-	// Generate a random car that's different from the current one
-	var carIndex, assignmentNumber int
-	var selectedCar *models.Car
-
-	// Try to find a different car (up to 5 attempts)
-	for attempt := 0; attempt < 5; attempt++ {
-		assignmentNumber = rand.Intn(maxCarNumber) + 1
-		carIndex = (assignmentNumber - 1) % carCount
-		selectedCar = cars[carIndex]
-
-		if selectedCar.ID != currentCarID {
-			break
-		}
-	}
+	assignmentNumber := rand.Intn(maxCarNumber) + 1
+	selectedCar := candidates[(assignmentNumber-1)%carCount]
 
 	// Get driver name
 	var driverName string
@@ -549,6 +615,64 @@ func (r *CarRepository) RerollCarForDriver(tx *sql.Tx, raceID int, driverID int,
 	}, nil
 }
 
+// OverrideCarForDriver назначает гонщику выбранную администратором машину вместо выданной.
+// Прежняя машина сохраняется в previous_car_id, реролл гонщика не расходуется.
+// Если машина еще не выдавалась, создается назначение с номером 0.
+func (r *CarRepository) OverrideCarForDriver(raceID int, driverID int, carID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var assignmentID, currentCarID int
+	err = tx.QueryRow(`
+		SELECT id, car_id FROM race_car_assignments
+		WHERE race_id = $1 AND driver_id = $2
+		FOR UPDATE
+	`, raceID, driverID).Scan(&assignmentID, &currentCarID)
+
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(`
+			INSERT INTO race_car_assignments (race_id, driver_id, car_id, assignment_number)
+			VALUES ($1, $2, $3, 0)
+		`, raceID, driverID, carID)
+		if err != nil {
+			return fmt.Errorf("ошибка создания назначения машины: %v", err)
+		}
+	case err != nil:
+		return fmt.Errorf("ошибка получения текущего назначения: %v", err)
+	case currentCarID == carID:
+		return nil
+	default:
+		_, err = tx.Exec(`
+			UPDATE race_car_assignments
+			SET car_id = $1, previous_car_id = $2
+			WHERE id = $3
+		`, carID, currentCarID, assignmentID)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления назначения машины: %v", err)
+		}
+	}
+
+	// Гонщик должен заново подтвердить машину
+	_, err = tx.Exec(`
+		UPDATE race_registrations
+		SET car_confirmed = false
+		WHERE race_id = $1 AND driver_id = $2
+	`, raceID, driverID)
+	if err != nil {
+		return fmt.Errorf("ошибка сброса подтверждения машины: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return nil
+}
+
 // GetDriverCarAssignment gets the car assigned to a specific driver for a race
 func (r *CarRepository) GetDriverCarAssignment(raceID int, driverID int) (*models.RaceCarAssignment, error) {
 	query := `
@@ -633,3 +757,349 @@ func (r *CarRepository) IsDriverAssignedCar(raceID int, driverID int) (bool, err
 
 	return exists, nil
 }
+
+// Import синхронизирует каталог машин с источником и сохраняет результат как новую версию каталога.
+// Машина сопоставляется по ID, если он указан и есть в каталоге, иначе по названию и году.
+// Машины, которые уже выдавались на гонки, не меняются: измененная машина получает новую запись,
+// а прежняя выводится из каталога, поэтому назначения прошлых гонок указывают на неизменные данные.
+// С prune из каталога выводятся машины, которых нет в источнике.
+func (r *CarRepository) Import(cars []*models.Car, prune bool, source string, createdBy int64) (*models.CarImportReport, error) {
+	report := &models.CarImportReport{Total: len(cars)}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Импорты выполняются по очереди, чтобы версии не перемешались
+	if _, err := tx.Exec("LOCK TABLE car_catalog_versions IN EXCLUSIVE MODE"); err != nil {
+		return nil, fmt.Errorf("ошибка блокировки каталога машин: %v", err)
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO car_catalog_versions (source, created_by)
+		VALUES ($1, NULLIF($2, 0))
+		RETURNING id
+	`, source, createdBy).Scan(&report.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания версии каталога: %v", err)
+	}
+
+	stored, byID, order, err := r.getActiveCarsTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := r.getUsedCarIDsTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	matched := make(map[int]bool)
+
+	for _, car := range cars {
+		key := models.CarKey(car.Name, car.YearRaw)
+
+		current, ok := byID[car.ID]
+		if !ok {
+			current, ok = stored[key]
+		}
+
+		if seen[key] || (ok && matched[current.ID]) {
+			report.Skipped++
+			continue
+		}
+		seen[key] = true
+
+		if !ok {
+			if err := r.insertCarTx(tx, car, report.VersionID); err != nil {
+				return nil, err
+			}
+
+			report.Added++
+			report.Changes = append(report.Changes, &models.CarChange{
+				CarID:   car.ID,
+				Type:    models.CarChangeAdded,
+				CarName: models.CarTitle(car.Name, car.YearRaw),
+				Details: fmt.Sprintf("класс %s %d", car.ClassLetter, car.ClassNumber),
+			})
+			continue
+		}
+
+		matched[current.ID] = true
+
+		diff := models.CarDiff(current, car)
+		if len(diff) == 0 {
+			report.Unchanged++
+			continue
+		}
+
+		change := &models.CarChange{
+			Type:    models.CarChangeUpdated,
+			CarName: models.CarTitle(current.Name, current.YearRaw),
+			Details: strings.Join(diff, "; "),
+		}
+
+		if used[current.ID] {
+			// Машина уже выдавалась: сохраняем прежнюю запись для истории гонок
+			if err := r.insertCarTx(tx, car, report.VersionID); err != nil {
+				return nil, err
+			}
+			if err := r.retireCarTx(tx, current.ID, report.VersionID); err != nil {
+				return nil, err
+			}
+			change.PreviousCarID = current.ID
+		} else {
+			car.ID = current.ID
+			_, err := tx.Exec(`
+				UPDATE cars
+				SET name = $1, year = $2, image_url = $3, price = $4, rarity = $5, speed = $6, handling = $7,
+					acceleration = $8, launch = $9, braking = $10, class_letter = $11, class_number = $12, source = $13
+				WHERE id = $14
+			`, car.Name, car.YearRaw, car.ImageURL, car.Price, car.Rarity, car.Speed, car.Handling, car.Acceleration,
+				car.Launch, car.Braking, car.ClassLetter, car.ClassNumber, car.Source, car.ID)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка обновления машины %s: %v", car.Name, err)
+			}
+		}
+
+		change.CarID = car.ID
+		report.Updated++
+		report.Changes = append(report.Changes, change)
+	}
+
+	// Выводим из каталога машины, которых больше нет в источнике
+	if prune {
+		for _, car := range order {
+			if matched[car.ID] {
+				continue
+			}
+
+			if err := r.retireCarTx(tx, car.ID, report.VersionID); err != nil {
+				return nil, err
+			}
+
+			report.Removed++
+			report.Changes = append(report.Changes, &models.CarChange{
+				CarID:   car.ID,
+				Type:    models.CarChangeRemoved,
+				CarName: models.CarTitle(car.Name, car.YearRaw),
+			})
+		}
+	}
+
+	for _, change := range report.Changes {
+		change.VersionID = report.VersionID
+		err := tx.QueryRow(`
+			INSERT INTO car_catalog_changes (version_id, car_id, previous_car_id, change_type, car_name, details)
+			VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6)
+			RETURNING id
+		`, change.VersionID, change.CarID, change.PreviousCarID, change.Type, change.CarName, change.Details,
+		).Scan(&change.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сохранения изменения каталога: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE car_catalog_versions
+		SET total = $1, added = $2, updated = $3, removed = $4, unchanged = $5
+		WHERE id = $6
+	`, report.Total, report.Added, report.Updated, report.Removed, report.Unchanged, report.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения итогов версии каталога: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return report, nil
+}
+
+// getActiveCarsTx возвращает машины текущего каталога: по ключу названия и года, по ID и в порядке ID
+func (r *CarRepository) getActiveCarsTx(tx *sql.Tx) (map[string]*models.Car, map[int]*models.Car, []*models.Car, error) {
+	rows, err := tx.Query(`
+		SELECT id, name, year, COALESCE(image_url, ''), COALESCE(price, 0), COALESCE(rarity, ''),
+			COALESCE(speed, 0), COALESCE(handling, 0), COALESCE(acceleration, 0), COALESCE(launch, 0),
+			COALESCE(braking, 0), COALESCE(class_letter, ''), COALESCE(class_number, 0), COALESCE(source, '')
+		FROM cars
+		WHERE retired_version_id IS NULL
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ошибка получения машин: %v", err)
+	}
+	defer rows.Close()
+
+	stored := make(map[string]*models.Car)
+	byID := make(map[int]*models.Car)
+	var order []*models.Car
+
+	for rows.Next() {
+		var car models.Car
+		err := rows.Scan(&car.ID, &car.Name, &car.YearRaw, &car.ImageURL, &car.Price, &car.Rarity,
+			&car.Speed, &car.Handling, &car.Acceleration, &car.Launch, &car.Braking,
+			&car.ClassLetter, &car.ClassNumber, &car.Source)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("ошибка сканирования данных машины: %v", err)
+		}
+
+		order = append(order, &car)
+		byID[car.ID] = &car
+		key := models.CarKey(car.Name, car.YearRaw)
+		if _, ok := stored[key]; !ok {
+			stored[key] = &car
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, fmt.Errorf("ошибка итерации по машинам: %v", err)
+	}
+
+	return stored, byID, order, nil
+}
+
+// getUsedCarIDsTx возвращает ID машин, которые выдавались на гонки (в том числе до реролла)
+func (r *CarRepository) getUsedCarIDsTx(tx *sql.Tx) (map[int]bool, error) {
+	rows, err := tx.Query(`
+		SELECT car_id FROM race_car_assignments WHERE car_id IS NOT NULL
+		UNION
+		SELECT previous_car_id FROM race_car_assignments WHERE previous_car_id IS NOT NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения выданных машин: %v", err)
+	}
+	defer rows.Close()
+
+	used := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования ID машины: %v", err)
+		}
+		used[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по выданным машинам: %v", err)
+	}
+
+	return used, nil
+}
+
+// insertCarTx добавляет машину в каталог в указанной версии
+func (r *CarRepository) insertCarTx(tx *sql.Tx, car *models.Car, versionID int) error {
+	err := tx.QueryRow(`
+		INSERT INTO cars (name, year, image_url, price, rarity, speed, handling, acceleration,
+			launch, braking, class_letter, class_number, source, added_version_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`, car.Name, car.YearRaw, car.ImageURL, car.Price, car.Rarity, car.Speed, car.Handling,
+		car.Acceleration, car.Launch, car.Braking, car.ClassLetter, car.ClassNumber, car.Source, versionID,
+	).Scan(&car.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления машины %s: %v", car.Name, err)
+	}
+	return nil
+}
+
+// retireCarTx выводит машину из каталога в указанной версии, сохраняя запись для истории
+func (r *CarRepository) retireCarTx(tx *sql.Tx, carID, versionID int) error {
+	_, err := tx.Exec("UPDATE cars SET retired_version_id = $1 WHERE id = $2", versionID, carID)
+	if err != nil {
+		return fmt.Errorf("ошибка вывода машины из каталога: %v", err)
+	}
+	return nil
+}
+
+// GetCatalogVersions возвращает последние версии каталога машин, новые первыми
+func (r *CarRepository) GetCatalogVersions(limit int) ([]*models.CatalogVersion, error) {
+	rows, err := r.db.Query(`
+		SELECT id, source, COALESCE(created_by, 0), created_at, total, added, updated, removed, unchanged
+		FROM car_catalog_versions
+		ORDER BY id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения версий каталога: %v", err)
+	}
+	defer rows.Close()
+
+	var versions []*models.CatalogVersion
+	for rows.Next() {
+		version, err := scanCatalogVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по версиям каталога: %v", err)
+	}
+
+	return versions, nil
+}
+
+// GetCatalogVersion возвращает версию каталога по ID
+func (r *CarRepository) GetCatalogVersion(id int) (*models.CatalogVersion, error) {
+	row := r.db.QueryRow(`
+		SELECT id, source, COALESCE(created_by, 0), created_at, total, added, updated, removed, unchanged
+		FROM car_catalog_versions
+		WHERE id = $1
+	`, id)
+
+	version, err := scanCatalogVersion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return version, err
+}
+
+// scanCatalogVersion сканирует строку версии каталога
+func scanCatalogVersion(row rowScanner) (*models.CatalogVersion, error) {
+	var version models.CatalogVersion
+	err := row.Scan(&version.ID, &version.Source, &version.CreatedBy, &version.CreatedAt,
+		&version.Total, &version.Added, &version.Updated, &version.Removed, &version.Unchanged)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сканирования версии каталога: %v", err)
+	}
+	return &version, nil
+}
+
+// GetCatalogChanges возвращает изменения машин в версии каталога
+func (r *CarRepository) GetCatalogChanges(versionID int) ([]*models.CarChange, error) {
+	rows, err := r.db.Query(`
+		SELECT id, version_id, COALESCE(car_id, 0), COALESCE(previous_car_id, 0), change_type, car_name, details
+		FROM car_catalog_changes
+		WHERE version_id = $1
+		ORDER BY CASE change_type WHEN 'added' THEN 0 WHEN 'updated' THEN 1 ELSE 2 END, car_name
+	`, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменений каталога: %v", err)
+	}
+	defer rows.Close()
+
+	var changes []*models.CarChange
+	for rows.Next() {
+		var change models.CarChange
+		err := rows.Scan(&change.ID, &change.VersionID, &change.CarID, &change.PreviousCarID,
+			&change.Type, &change.CarName, &change.Details)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования изменения каталога: %v", err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по изменениям каталога: %v", err)
+	}
+
+	return changes, nil
+}
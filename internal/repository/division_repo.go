@@ -0,0 +1,481 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// DivisionRepository представляет репозиторий для работы с дивизионами
+type DivisionRepository struct {
+	db *sql.DB
+}
+
+// NewDivisionRepository создает новый репозиторий дивизионов
+func NewDivisionRepository(db *sql.DB) *DivisionRepository {
+	return &DivisionRepository{db: db}
+}
+
+const divisionColumns = "d.id, d.season_id, d.name, d.tier, d.swap_count, d.created_at"
+
+// scanDivision сканирует строку дивизиона
+func scanDivision(row rowScanner) (*models.Division, error) {
+	var division models.Division
+	err := row.Scan(&division.ID, &division.SeasonID, &division.Name, &division.Tier,
+		&division.SwapCount, &division.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &division, nil
+}
+
+// queryDivisions выполняет запрос списка дивизионов
+func queryDivisions(q queryer, query string, args ...interface{}) ([]*models.Division, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения дивизионов: %v", err)
+	}
+	defer rows.Close()
+
+	var divisions []*models.Division
+	for rows.Next() {
+		division, err := scanDivision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования дивизиона: %v", err)
+		}
+		divisions = append(divisions, division)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по дивизионам: %v", err)
+	}
+
+	return divisions, nil
+}
+
+// Create создает дивизион сезона уровнем ниже всех существующих
+func (r *DivisionRepository) Create(seasonID int, name string) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO divisions (season_id, name, tier, swap_count)
+		SELECT $1, $2, COALESCE(MAX(tier), 0) + 1, $3
+		FROM divisions
+		WHERE season_id = $1
+		RETURNING id
+	`, seasonID, name, models.DefaultDivisionSwapCount).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания дивизиона: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetByID возвращает дивизион по ID
+func (r *DivisionRepository) GetByID(id int) (*models.Division, error) {
+	division, err := scanDivision(r.db.QueryRow(`
+		SELECT `+divisionColumns+`
+		FROM divisions d
+		WHERE d.id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения дивизиона: %v", err)
+	}
+
+	return division, nil
+}
+
+// GetBySeason возвращает дивизионы сезона от высшего к низшему
+func (r *DivisionRepository) GetBySeason(seasonID int) ([]*models.Division, error) {
+	return queryDivisions(r.db, `
+		SELECT `+divisionColumns+`
+		FROM divisions d
+		WHERE d.season_id = $1
+		ORDER BY d.tier
+	`, seasonID)
+}
+
+// Delete удаляет дивизион. Гонки дивизиона становятся открытыми для всех.
+func (r *DivisionRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM divisions WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления дивизиона: %v", err)
+	}
+	return nil
+}
+
+// SetSwapCount задает число гонщиков для обмена с дивизионом ниже
+func (r *DivisionRepository) SetSwapCount(id, count int) error {
+	_, err := r.db.Exec("UPDATE divisions SET swap_count = $1 WHERE id = $2", count, id)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения числа гонщиков для обмена: %v", err)
+	}
+	return nil
+}
+
+// GetMembers возвращает гонщиков дивизиона
+func (r *DivisionRepository) GetMembers(divisionID int) ([]*models.Driver, error) {
+	rows, err := r.db.Query(`
+		SELECT dr.id, dr.telegram_id, dr.name
+		FROM division_members dm
+		JOIN drivers dr ON dr.id = dm.driver_id
+		WHERE dm.division_id = $1
+		ORDER BY dr.name
+	`, divisionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения гонщиков дивизиона: %v", err)
+	}
+	defer rows.Close()
+
+	var drivers []*models.Driver
+	for rows.Next() {
+		var driver models.Driver
+		if err := rows.Scan(&driver.ID, &driver.TelegramID, &driver.Name); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования гонщика дивизиона: %v", err)
+		}
+		drivers = append(drivers, &driver)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по гонщикам дивизиона: %v", err)
+	}
+
+	return drivers, nil
+}
+
+// AddMember добавляет гонщика в дивизион. Возвращает false, если гонщик уже состоит
+// в дивизионе этого сезона.
+func (r *DivisionRepository) AddMember(divisionID, driverID int) (bool, error) {
+	res, err := r.db.Exec(`
+		INSERT INTO division_members (division_id, season_id, driver_id)
+		SELECT id, season_id, $2 FROM divisions WHERE id = $1
+		ON CONFLICT (season_id, driver_id) DO NOTHING
+	`, divisionID, driverID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка добавления гонщика в дивизион: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения количества добавленных гонщиков: %v", err)
+	}
+
+	return affected > 0, nil
+}
+
+// RemoveMember исключает гонщика из дивизиона
+func (r *DivisionRepository) RemoveMember(divisionID, driverID int) error {
+	_, err := r.db.Exec("DELETE FROM division_members WHERE division_id = $1 AND driver_id = $2", divisionID, driverID)
+	if err != nil {
+		return fmt.Errorf("ошибка исключения гонщика из дивизиона: %v", err)
+	}
+	return nil
+}
+
+// GetDriverDivisions возвращает дивизионы гонщиков сезона: ID гонщика -> дивизион
+func (r *DivisionRepository) GetDriverDivisions(seasonID int) (map[int]*models.Division, error) {
+	rows, err := r.db.Query(`
+		SELECT dm.driver_id, `+divisionColumns+`
+		FROM division_members dm
+		JOIN divisions d ON d.id = dm.division_id
+		WHERE dm.season_id = $1
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения дивизионов гонщиков: %v", err)
+	}
+	defer rows.Close()
+
+	divisions := make(map[int]*models.Division)
+	for rows.Next() {
+		var driverID int
+		var division models.Division
+		err := rows.Scan(&driverID, &division.ID, &division.SeasonID, &division.Name, &division.Tier,
+			&division.SwapCount, &division.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования дивизиона гонщика: %v", err)
+		}
+		divisions[driverID] = &division
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по дивизионам гонщиков: %v", err)
+	}
+
+	return divisions, nil
+}
+
+// GetRaceDivision возвращает дивизион гонки (nil - гонка открыта для всех)
+func (r *DivisionRepository) GetRaceDivision(raceID int) (*models.Division, error) {
+	division, err := scanDivision(r.db.QueryRow(`
+		SELECT `+divisionColumns+`
+		FROM race_divisions rd
+		JOIN divisions d ON d.id = rd.division_id
+		WHERE rd.race_id = $1
+	`, raceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения дивизиона гонки: %v", err)
+	}
+
+	return division, nil
+}
+
+// SetRaceDivision привязывает гонку к дивизиону (divisionID = 0 - гонка открыта для всех)
+func (r *DivisionRepository) SetRaceDivision(raceID, divisionID int) error {
+	var err error
+	if divisionID == 0 {
+		_, err = r.db.Exec("DELETE FROM race_divisions WHERE race_id = $1", raceID)
+	} else {
+		_, err = r.db.Exec(`
+			INSERT INTO race_divisions (race_id, division_id)
+			VALUES ($1, $2)
+			ON CONFLICT (race_id) DO UPDATE SET division_id = EXCLUDED.division_id
+		`, raceID, divisionID)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка изменения дивизиона гонки: %v", err)
+	}
+	return nil
+}
+
+// GetStandings возвращает таблицу дивизиона: очки гонщиков дивизиона в его завершенных гонках.
+// Гонщики дивизиона без результатов попадают в таблицу с нулем очков.
+func (r *DivisionRepository) GetStandings(divisionID int) ([]*models.StandingsEntry, error) {
+	members, err := r.GetMembers(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[int]*models.StandingsEntry, len(members))
+	entries := make([]*models.StandingsEntry, 0, len(members))
+	for _, member := range members {
+		entry := &models.StandingsEntry{DriverID: member.ID, DriverName: member.Name}
+		totals[member.ID] = entry
+		entries = append(entries, entry)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT rr.driver_id, rr.total_score, rr.results
+		FROM race_results rr
+		JOIN race_divisions rd ON rd.race_id = rr.race_id
+		JOIN races r ON r.id = rr.race_id
+		WHERE rd.division_id = $1 AND r.completed = true AND rr.confirmation_status = $2
+	`, divisionID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения результатов дивизиона: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var driverID, points int
+		var resultsJSON []byte
+		if err := rows.Scan(&driverID, &points, &resultsJSON); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования результата дивизиона: %v", err)
+		}
+
+		entry, ok := totals[driverID]
+		if !ok {
+			continue // гонщик уже не состоит в дивизионе
+		}
+
+		places, err := models.DeserializeResults(string(resultsJSON))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
+		}
+
+		entry.Points += points
+		for _, place := range places {
+			if place > 0 {
+				entry.PlaceSum += place
+				entry.Finishes++
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам дивизиона: %v", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DriverName < entries[j].DriverName })
+	models.RankStandings(entries)
+
+	return entries, nil
+}
+
+// SaveMoves сохраняет повышения и понижения по итогам сезона, заменяя ранее сохраненные
+func (r *DivisionRepository) SaveMoves(seasonID int, moves []*models.DivisionMove) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM division_moves WHERE season_id = $1", seasonID); err != nil {
+		return fmt.Errorf("ошибка очистки переходов между дивизионами: %v", err)
+	}
+
+	for _, move := range moves {
+		_, err := tx.Exec(`
+			INSERT INTO division_moves (season_id, driver_id, from_division_id, to_division_id)
+			VALUES ($1, $2, $3, $4)
+		`, seasonID, move.DriverID, move.FromDivisionID, move.ToDivisionID)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения перехода между дивизионами: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return nil
+}
+
+// GetMoves возвращает переходы между дивизионами по итогам сезона
+func (r *DivisionRepository) GetMoves(seasonID int) ([]*models.DivisionMove, error) {
+	rows, err := r.db.Query(`
+		SELECT m.season_id, m.driver_id, dr.name, m.from_division_id, m.to_division_id, t.tier < f.tier
+		FROM division_moves m
+		JOIN drivers dr ON dr.id = m.driver_id
+		JOIN divisions f ON f.id = m.from_division_id
+		JOIN divisions t ON t.id = m.to_division_id
+		WHERE m.season_id = $1
+		ORDER BY f.tier, t.tier, dr.name
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения переходов между дивизионами: %v", err)
+	}
+	defer rows.Close()
+
+	var moves []*models.DivisionMove
+	for rows.Next() {
+		var move models.DivisionMove
+		err := rows.Scan(&move.SeasonID, &move.DriverID, &move.DriverName, &move.FromDivisionID,
+			&move.ToDivisionID, &move.Promoted)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования перехода между дивизионами: %v", err)
+		}
+		moves = append(moves, &move)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по переходам между дивизионами: %v", err)
+	}
+
+	return moves, nil
+}
+
+// GetLatestSourceSeason возвращает последний завершенный сезон с дивизионами, кроме указанного
+// (0, если такого нет)
+func (r *DivisionRepository) GetLatestSourceSeason(excludeSeasonID int) (int, error) {
+	var seasonID int
+	err := r.db.QueryRow(`
+		SELECT s.id
+		FROM seasons s
+		WHERE s.id <> $1 AND s.active = false AND s.end_date IS NOT NULL
+			AND EXISTS (SELECT 1 FROM divisions d WHERE d.season_id = s.id)
+		ORDER BY s.end_date DESC, s.id DESC
+		LIMIT 1
+	`, excludeSeasonID).Scan(&seasonID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка поиска сезона с дивизионами: %v", err)
+	}
+
+	return seasonID, nil
+}
+
+// CarryOver переносит дивизионы и их составы из завершенного сезона в новый с учетом
+// повышений и понижений. Возвращает число перенесенных гонщиков.
+func (r *DivisionRepository) CarryOver(fromSeasonID, toSeasonID int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var existing int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM divisions WHERE season_id = $1", toSeasonID).Scan(&existing); err != nil {
+		return 0, fmt.Errorf("ошибка проверки дивизионов сезона: %v", err)
+	}
+	if existing > 0 {
+		return 0, fmt.Errorf("в сезоне %d уже есть дивизионы", toSeasonID)
+	}
+
+	// Старый дивизион -> новый дивизион того же уровня
+	rows, err := tx.Query(`
+		INSERT INTO divisions (season_id, name, tier, swap_count)
+		SELECT $2, name, tier, swap_count FROM divisions WHERE season_id = $1
+		RETURNING id, tier
+	`, fromSeasonID, toSeasonID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка переноса дивизионов: %v", err)
+	}
+
+	newByTier := make(map[int]int)
+	for rows.Next() {
+		var id, tier int
+		if err := rows.Scan(&id, &tier); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка сканирования нового дивизиона: %v", err)
+		}
+		newByTier[tier] = id
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("ошибка итерации по новым дивизионам: %v", err)
+	}
+	rows.Close()
+
+	// Гонщик переходит в дивизион, куда его перевели по итогам сезона, иначе остается на своем уровне
+	rows, err = tx.Query(`
+		SELECT dm.driver_id, COALESCE(t.tier, d.tier)
+		FROM division_members dm
+		JOIN divisions d ON d.id = dm.division_id
+		LEFT JOIN division_moves m ON m.season_id = dm.season_id AND m.driver_id = dm.driver_id
+		LEFT JOIN divisions t ON t.id = m.to_division_id
+		WHERE dm.season_id = $1
+	`, fromSeasonID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения составов дивизионов: %v", err)
+	}
+
+	targets := make(map[int]int)
+	for rows.Next() {
+		var driverID, tier int
+		if err := rows.Scan(&driverID, &tier); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка сканирования состава дивизиона: %v", err)
+		}
+		targets[driverID] = newByTier[tier]
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("ошибка итерации по составам дивизионов: %v", err)
+	}
+	rows.Close()
+
+	for driverID, divisionID := range targets {
+		_, err := tx.Exec(`
+			INSERT INTO division_members (division_id, season_id, driver_id)
+			VALUES ($1, $2, $3)
+		`, divisionID, toSeasonID, driverID)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка переноса гонщика в новый дивизион: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return len(targets), nil
+}
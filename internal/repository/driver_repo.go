@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/athebyme/forza-top-gear-bot/internal/models"
 )
@@ -198,8 +199,8 @@ func (r *DriverRepository) GetStats(driverID int) (*models.DriverStats, error) {
 	err := r.db.QueryRow(`
 		SELECT COALESCE(SUM(total_score), 0) 
 		FROM race_results 
-		WHERE driver_id = $1
-	`, driverID).Scan(&totalScore)
+		WHERE driver_id = $1 AND confirmation_status = $2
+	`, driverID, models.ResultStatusConfirmed).Scan(&totalScore)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения общего счета: %v", err)
 	}
@@ -209,20 +210,47 @@ func (r *DriverRepository) GetStats(driverID int) (*models.DriverStats, error) {
 	err = r.db.QueryRow(`
 		SELECT COUNT(*) 
 		FROM race_results 
-		WHERE driver_id = $1
-	`, driverID).Scan(&totalRaces)
+		WHERE driver_id = $1 AND confirmation_status = $2
+	`, driverID, models.ResultStatusConfirmed).Scan(&totalRaces)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения количества гонок: %v", err)
 	}
 
+	// Получаем среднее место по всем дисциплинам, где гонщик финишировал
+	var averagePlace float64
+	err = r.db.QueryRow(`
+		SELECT COALESCE(AVG(d.value::int), 0)
+		FROM race_results rr, jsonb_each_text(rr.results) d
+		WHERE rr.driver_id = $1 AND rr.confirmation_status = $2 AND d.value::int > 0
+	`, driverID, models.ResultStatusConfirmed).Scan(&averagePlace)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения среднего места: %v", err)
+	}
+
+	// Считаем статусы DNS/DNF/DSQ по дисциплинам отдельно от мест
+	var dns, dnf, dsq int
+	err = r.db.QueryRow(`
+		SELECT
+			COUNT(*) FILTER (WHERE s.value = $3),
+			COUNT(*) FILTER (WHERE s.value = $4),
+			COUNT(*) FILTER (WHERE s.value = $5)
+		FROM race_results rr, jsonb_each_text(rr.statuses) s
+		WHERE rr.driver_id = $1 AND rr.confirmation_status = $2
+	`, driverID, models.ResultStatusConfirmed,
+		models.DisciplineDNS, models.DisciplineDNF, models.DisciplineDSQ,
+	).Scan(&dns, &dnf, &dsq)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения статусов дисциплин: %v", err)
+	}
+
 	// Получаем последние гонки
 	rows, err := r.db.Query(`
 		SELECT r.name, rr.total_score 
 		FROM race_results rr 
 		JOIN races r ON rr.race_id = r.id 
-		WHERE rr.driver_id = $1 
+		WHERE rr.driver_id = $1 AND rr.confirmation_status = $2
 		ORDER BY r.date DESC LIMIT 5
-	`, driverID)
+	`, driverID, models.ResultStatusConfirmed)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения последних гонок: %v", err)
 	}
@@ -248,12 +276,44 @@ func (r *DriverRepository) GetStats(driverID int) (*models.DriverStats, error) {
 		return nil, fmt.Errorf("ошибка итерации по гонкам: %v", err)
 	}
 
+	// Получаем достижения гонщика
+	achRows, err := r.db.Query(`
+		SELECT code, earned_at
+		FROM driver_achievements
+		WHERE driver_id = $1
+		ORDER BY earned_at, id
+	`, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения достижений: %v", err)
+	}
+	defer achRows.Close()
+
+	var achievements []models.Achievement
+
+	for achRows.Next() {
+		var code string
+		var earnedAt time.Time
+		if err := achRows.Scan(&code, &earnedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования достижения: %v", err)
+		}
+
+		achievements = append(achievements, models.NewAchievement(code, earnedAt.Format("02.01.2006")))
+	}
+
+	if err := achRows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по достижениям: %v", err)
+	}
+
 	// Создаем статистику
 	stats := &models.DriverStats{
-		TotalScore:  totalScore,
-		RecentRaces: recentRaces,
-		TotalRaces:  totalRaces,
-		// Достижения можно добавить позже
+		TotalScore:   totalScore,
+		RecentRaces:  recentRaces,
+		TotalRaces:   totalRaces,
+		AveragePlace: averagePlace,
+		DNS:          dns,
+		DNF:          dnf,
+		DSQ:          dsq,
+		Achievements: achievements,
 	}
 
 	return stats, nil
@@ -292,3 +352,118 @@ func (r *DriverRepository) CheckExists(telegramID int64) (bool, error) {
 
 	return exists, nil
 }
+
+// breakdownData содержит подтвержденные результаты для статистики по дисциплинам и классам
+type breakdownData struct {
+	results     []*models.RaceResult
+	penalties   map[int][]*models.Penalty
+	driverNames map[int]string
+	raceClasses map[int]string
+}
+
+// getBreakdownData загружает подтвержденные результаты и их штрафы. Штрафы на позиции сдвигают
+// места соседей, поэтому для гонщика загружаются все результаты гонок, в которых он участвовал.
+// driverID = 0 - по всем гонщикам, seasonID = 0 - по всем сезонам.
+func (r *DriverRepository) getBreakdownData(driverID, seasonID int) (*breakdownData, error) {
+	rows, err := r.db.Query(`
+		SELECT `+resultColumns+`, d.name, r.car_class
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		JOIN drivers d ON d.id = rr.driver_id
+		WHERE rr.confirmation_status = $1
+			AND ($2 = 0 OR rr.race_id IN (
+				SELECT race_id FROM race_results WHERE driver_id = $2 AND confirmation_status = $1))
+			AND ($3 = 0 OR r.season_id = $3)
+		ORDER BY rr.id
+	`, models.ResultStatusConfirmed, driverID, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения результатов для статистики: %v", err)
+	}
+	defer rows.Close()
+
+	data := &breakdownData{
+		driverNames: make(map[int]string),
+		raceClasses: make(map[int]string),
+	}
+
+	for rows.Next() {
+		var result models.RaceResult
+		var driverName, carClass string
+
+		if err := scanResult(rows, &result, &driverName, &carClass); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		data.results = append(data.results, &result)
+		data.driverNames[result.DriverID] = driverName
+		data.raceClasses[result.RaceID] = carClass
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+
+	if len(data.results) == 0 {
+		return data, nil
+	}
+
+	penalties, err := queryPenalties(r.db, `p.result_id IN (
+		SELECT rr.id FROM race_results rr JOIN races r ON r.id = rr.race_id
+		WHERE rr.confirmation_status = $1
+			AND ($2 = 0 OR rr.race_id IN (
+				SELECT race_id FROM race_results WHERE driver_id = $2 AND confirmation_status = $1))
+			AND ($3 = 0 OR r.season_id = $3))`,
+		models.ResultStatusConfirmed, driverID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.penalties = groupPenaltiesByResult(penalties)
+	return data, nil
+}
+
+// GetDisciplineBreakdown возвращает статистику по дисциплинам из подтвержденных результатов
+// с учетом штрафов стюардов. driverID = 0 - по всем гонщикам, seasonID = 0 - по всем сезонам.
+func (r *DriverRepository) GetDisciplineBreakdown(driverID, seasonID int) ([]*models.DisciplineBreakdown, error) {
+	data, err := r.getBreakdownData(driverID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := models.CalculateDisciplineBreakdown(data.results, data.penalties, data.driverNames)
+	if driverID == 0 {
+		return items, nil
+	}
+
+	var driverItems []*models.DisciplineBreakdown
+	for _, item := range items {
+		if item.DriverID == driverID {
+			driverItems = append(driverItems, item)
+		}
+	}
+
+	return driverItems, nil
+}
+
+// GetClassBreakdown возвращает статистику по классам машин из подтвержденных результатов
+// с учетом штрафов стюардов. driverID = 0 - по всем гонщикам, seasonID = 0 - по всем сезонам.
+func (r *DriverRepository) GetClassBreakdown(driverID, seasonID int) ([]*models.ClassBreakdown, error) {
+	data, err := r.getBreakdownData(driverID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := models.CalculateClassBreakdown(data.results, data.penalties, data.driverNames, data.raceClasses)
+	if driverID == 0 {
+		return items, nil
+	}
+
+	var driverItems []*models.ClassBreakdown
+	for _, item := range items {
+		if item.DriverID == driverID {
+			driverItems = append(driverItems, item)
+		}
+	}
+
+	return driverItems, nil
+}
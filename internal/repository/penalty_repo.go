@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// PenaltyRepository представляет репозиторий для работы со штрафами
+type PenaltyRepository struct {
+	db *sql.DB
+}
+
+// NewPenaltyRepository создает новый репозиторий штрафов
+func NewPenaltyRepository(db *sql.DB) *PenaltyRepository {
+	return &PenaltyRepository{db: db}
+}
+
+// queryer обобщает *sql.DB и *sql.Tx для запросов на чтение
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryPenalties выбирает штрафы по условию where (алиас таблицы - p)
+func queryPenalties(q queryer, where string, args ...interface{}) ([]*models.Penalty, error) {
+	rows, err := q.Query(`
+		SELECT p.id, p.result_id, p.type, p.discipline, p.value, p.reason, p.issued_by, p.created_at
+		FROM race_penalties p
+		WHERE `+where+`
+		ORDER BY p.created_at, p.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения штрафов: %v", err)
+	}
+	defer rows.Close()
+
+	var penalties []*models.Penalty
+	for rows.Next() {
+		var penalty models.Penalty
+		err := rows.Scan(
+			&penalty.ID,
+			&penalty.ResultID,
+			&penalty.Type,
+			&penalty.Discipline,
+			&penalty.Value,
+			&penalty.Reason,
+			&penalty.IssuedBy,
+			&penalty.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования штрафа: %v", err)
+		}
+		penalties = append(penalties, &penalty)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по штрафам: %v", err)
+	}
+
+	return penalties, nil
+}
+
+// groupPenaltiesByResult группирует штрафы по ID результата
+func groupPenaltiesByResult(penalties []*models.Penalty) map[int][]*models.Penalty {
+	grouped := make(map[int][]*models.Penalty)
+	for _, penalty := range penalties {
+		grouped[penalty.ResultID] = append(grouped[penalty.ResultID], penalty)
+	}
+	return grouped
+}
+
+// GetByID возвращает штраф по ID
+func (r *PenaltyRepository) GetByID(id int) (*models.Penalty, error) {
+	penalties, err := queryPenalties(r.db, "p.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(penalties) == 0 {
+		return nil, nil
+	}
+
+	return penalties[0], nil
+}
+
+// GetByResultID возвращает штрафы результата в порядке назначения
+func (r *PenaltyRepository) GetByResultID(resultID int) ([]*models.Penalty, error) {
+	return queryPenalties(r.db, "p.result_id = $1", resultID)
+}
+
+// GetByRaceID возвращает штрафы всех результатов гонки, сгруппированные по ID результата
+func (r *PenaltyRepository) GetByRaceID(raceID int) (map[int][]*models.Penalty, error) {
+	penalties, err := queryPenalties(r.db,
+		"p.result_id IN (SELECT id FROM race_results WHERE race_id = $1)", raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupPenaltiesByResult(penalties), nil
+}
+
+// Create назначает штраф и пересчитывает total_score результатов гонки по таблице очков rules
+func (r *PenaltyRepository) Create(penalty *models.Penalty, rules *models.ScoringRules) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO race_penalties (result_id, type, discipline, value, reason, issued_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`,
+		penalty.ResultID,
+		penalty.Type,
+		penalty.Discipline,
+		penalty.Value,
+		penalty.Reason,
+		penalty.IssuedBy,
+	).Scan(&id, &penalty.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания штрафа: %v", err)
+	}
+
+	if err := rescoreResultRace(tx, penalty.ResultID, rules); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	penalty.ID = id
+	return id, nil
+}
+
+// Delete снимает штраф и пересчитывает total_score результатов гонки по таблице очков rules
+func (r *PenaltyRepository) Delete(penaltyID int, rules *models.ScoringRules) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	var resultID int
+	err = tx.QueryRow("DELETE FROM race_penalties WHERE id = $1 RETURNING result_id", penaltyID).Scan(&resultID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("штраф %d не найден", penaltyID)
+		}
+		return fmt.Errorf("ошибка удаления штрафа: %v", err)
+	}
+
+	if err := rescoreResultRace(tx, resultID, rules); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return nil
+}
+
+// rescoreResultRace пересчитывает очки всех результатов гонки, к которой относится результат resultID:
+// штраф на позиции и дисквалификация меняют места соседей по дисциплине
+func rescoreResultRace(tx *sql.Tx, resultID int, rules *models.ScoringRules) error {
+	var raceID int
+	err := tx.QueryRow("SELECT race_id FROM race_results WHERE id = $1", resultID).Scan(&raceID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения результата %d: %v", resultID, err)
+	}
+
+	results, penaltiesByResult, err := lockRaceResults(tx, raceID)
+	if err != nil {
+		return err
+	}
+
+	return saveRaceScores(tx, results, penaltiesByResult, rules)
+}
@@ -46,7 +46,7 @@ func (r *RaceRepository) Create(race *models.Race) (int, error) {
 
 func (r *RaceRepository) GetByID(id int) (*models.Race, error) {
 	query := `
-		SELECT id, season_id, name, date, car_class, disciplines, completed
+		SELECT id, season_id, name, date, car_class, disciplines, completed, state
 		FROM races
 		WHERE id = $1
 	`
@@ -63,6 +63,7 @@ func (r *RaceRepository) GetByID(id int) (*models.Race, error) {
 		&race.CarClass,
 		&disciplinesJSON,
 		&race.Completed,
+		&race.State,
 	)
 
 	if err != nil {
@@ -285,7 +286,7 @@ func (r *RaceRepository) Update(tx *sql.Tx, race *models.Race) error {
 // Move it to ResultRepository if possible.
 func (r *RaceRepository) GetResultCountByRaceID(raceID int) (int, error) {
 	var count int
-	query := "SELECT COUNT(*) FROM results WHERE race_id = $1"
+	query := "SELECT COUNT(*) FROM race_results WHERE race_id = $1"
 	err := r.db.QueryRow(query, raceID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("ошибка получения количества результатов для гонки ID %d: %v", raceID, err)
@@ -293,6 +294,23 @@ func (r *RaceRepository) GetResultCountByRaceID(raceID int) (int, error) {
 	return count, nil
 }
 
+// GetFieldSize возвращает количество участников гонки: зарегистрированных
+// гонщиков или уже добавленных результатов, если их больше
+func (r *RaceRepository) GetFieldSize(raceID int) (int, error) {
+	var size int
+	query := `
+		SELECT GREATEST(
+			(SELECT COUNT(*) FROM race_registrations WHERE race_id = $1),
+			(SELECT COUNT(*) FROM race_results WHERE race_id = $1)
+		)
+	`
+	err := r.db.QueryRow(query, raceID).Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения количества участников гонки: %v", err)
+	}
+	return size, nil
+}
+
 func (r *RaceRepository) UpdateCompleted(id int, completed bool) error {
 	// Исправлено для использования boolean значения
 	_, err := r.db.Exec(
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// RatingRepository представляет репозиторий для работы с рейтингом Эло гонщиков
+type RatingRepository struct {
+	db *sql.DB
+}
+
+// NewRatingRepository создает новый репозиторий рейтинга
+func NewRatingRepository(db *sql.DB) *RatingRepository {
+	return &RatingRepository{db: db}
+}
+
+// ratingColumns - столбцы текущего рейтинга (алиасы: dr - driver_ratings, d - drivers)
+const ratingColumns = `dr.driver_id, d.name, dr.rating, dr.races, dr.updated_at,
+	COALESCE((
+		SELECT h.rating_after - h.rating_before
+		FROM rating_history h
+		JOIN races r ON r.id = h.race_id
+		WHERE h.driver_id = dr.driver_id
+		ORDER BY r.date DESC, r.id DESC
+		LIMIT 1
+	), 0)`
+
+// scanRating сканирует строку текущего рейтинга
+func scanRating(row rowScanner) (*models.DriverRating, error) {
+	var rating models.DriverRating
+	err := row.Scan(
+		&rating.DriverID,
+		&rating.DriverName,
+		&rating.Rating,
+		&rating.Races,
+		&rating.UpdatedAt,
+		&rating.LastDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+// GetAll возвращает рейтинги всех гонщиков по убыванию
+func (r *RatingRepository) GetAll() ([]*models.DriverRating, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + ratingColumns + `
+		FROM driver_ratings dr
+		JOIN drivers d ON d.id = dr.driver_id
+		ORDER BY dr.rating DESC, d.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рейтинга: %v", err)
+	}
+	defer rows.Close()
+
+	var ratings []*models.DriverRating
+	for rows.Next() {
+		rating, err := scanRating(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования рейтинга: %v", err)
+		}
+		ratings = append(ratings, rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по рейтингу: %v", err)
+	}
+
+	return ratings, nil
+}
+
+// GetByDriverID возвращает рейтинг гонщика или nil, если гонщик еще не участвовал в рейтинге
+func (r *RatingRepository) GetByDriverID(driverID int) (*models.DriverRating, error) {
+	rating, err := scanRating(r.db.QueryRow(`
+		SELECT `+ratingColumns+`
+		FROM driver_ratings dr
+		JOIN drivers d ON d.id = dr.driver_id
+		WHERE dr.driver_id = $1
+	`, driverID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рейтинга гонщика: %v", err)
+	}
+
+	return rating, nil
+}
+
+// GetHistory возвращает последние limit изменений рейтинга гонщика, начиная с последней гонки
+func (r *RatingRepository) GetHistory(driverID, limit int) ([]*models.RatingChange, error) {
+	rows, err := r.db.Query(`
+		SELECT h.driver_id, h.race_id, r.name, r.date, h.rating_before, h.rating_after, h.created_at
+		FROM rating_history h
+		JOIN races r ON r.id = h.race_id
+		WHERE h.driver_id = $1
+		ORDER BY r.date DESC, r.id DESC
+		LIMIT $2
+	`, driverID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории рейтинга: %v", err)
+	}
+	defer rows.Close()
+
+	var history []*models.RatingChange
+	for rows.Next() {
+		var change models.RatingChange
+		err := rows.Scan(
+			&change.DriverID,
+			&change.RaceID,
+			&change.RaceName,
+			&change.RaceDate,
+			&change.Before,
+			&change.After,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования истории рейтинга: %v", err)
+		}
+		history = append(history, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по истории рейтинга: %v", err)
+	}
+
+	return history, nil
+}
+
+// Recalculate пересчитывает рейтинг с нуля, последовательно проходя все завершенные гонки
+// в хронологическом порядке по подтвержденным результатам. Пересчет целиком делает рейтинг
+// независимым от порядка завершения гонок и правок результатов задним числом.
+// Возвращает количество учтенных гонок.
+func (r *RatingRepository) Recalculate() (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT `+resultColumns+`
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		WHERE r.completed = true AND rr.confirmation_status = $1
+		ORDER BY r.date, r.id, rr.id
+	`, models.ResultStatusConfirmed)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения результатов завершенных гонок: %v", err)
+	}
+
+	var raceOrder []int
+	resultsByRace := make(map[int][]*models.RaceResult)
+
+	for rows.Next() {
+		var result models.RaceResult
+		if err := scanResult(rows, &result); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		if _, ok := resultsByRace[result.RaceID]; !ok {
+			raceOrder = append(raceOrder, result.RaceID)
+		}
+		resultsByRace[result.RaceID] = append(resultsByRace[result.RaceID], &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+	rows.Close()
+
+	penalties, err := queryPenalties(tx,
+		"p.result_id IN (SELECT rr.id FROM race_results rr JOIN races r ON r.id = rr.race_id WHERE r.completed = true)")
+	if err != nil {
+		return 0, err
+	}
+	penaltiesByResult := groupPenaltiesByResult(penalties)
+
+	if _, err := tx.Exec("DELETE FROM rating_history"); err != nil {
+		return 0, fmt.Errorf("ошибка очистки истории рейтинга: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM driver_ratings"); err != nil {
+		return 0, fmt.Errorf("ошибка очистки рейтинга: %v", err)
+	}
+
+	ratings := make(map[int]float64)
+	races := make(map[int]int)
+	counted := 0
+
+	for _, raceID := range raceOrder {
+		deltas := models.CalculateRatingDeltas(ratings, resultsByRace[raceID], penaltiesByResult)
+		if len(deltas) == 0 {
+			continue
+		}
+		counted++
+
+		for driverID, delta := range deltas {
+			before, ok := ratings[driverID]
+			if !ok {
+				before = models.DefaultRating
+			}
+			after := before + delta
+
+			_, err := tx.Exec(`
+				INSERT INTO rating_history (driver_id, race_id, rating_before, rating_after)
+				VALUES ($1, $2, $3, $4)
+			`, driverID, raceID, before, after)
+			if err != nil {
+				return 0, fmt.Errorf("ошибка сохранения истории рейтинга: %v", err)
+			}
+
+			ratings[driverID] = after
+			races[driverID]++
+		}
+	}
+
+	for driverID, rating := range ratings {
+		_, err := tx.Exec(`
+			INSERT INTO driver_ratings (driver_id, rating, races, updated_at)
+			VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		`, driverID, rating, races[driverID])
+		if err != nil {
+			return 0, fmt.Errorf("ошибка сохранения рейтинга гонщика %d: %v", driverID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return counted, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// RecordRepository представляет репозиторий для расчета рекордов зала славы
+type RecordRepository struct {
+	db *sql.DB
+}
+
+// NewRecordRepository создает новый репозиторий рекордов
+func NewRecordRepository(db *sql.DB) *RecordRepository {
+	return &RecordRepository{db: db}
+}
+
+// GetRaceFinishes возвращает итоговые места гонщиков во всех завершенных гонках по сумме
+// подтвержденных очков, упорядоченные по дате гонки
+func (r *RecordRepository) GetRaceFinishes() ([]*models.RaceFinish, error) {
+	rows, err := r.db.Query(`
+		SELECT r.id, r.name, r.date, rr.driver_id, d.name, rr.total_score,
+			RANK() OVER (PARTITION BY r.id ORDER BY rr.total_score DESC)
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		JOIN drivers d ON d.id = rr.driver_id
+		WHERE r.completed = true AND rr.confirmation_status = $1
+		ORDER BY r.date, r.id, rr.driver_id
+	`, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения мест в гонках: %v", err)
+	}
+	defer rows.Close()
+
+	var finishes []*models.RaceFinish
+	for rows.Next() {
+		var finish models.RaceFinish
+		err := rows.Scan(&finish.RaceID, &finish.RaceName, &finish.Date, &finish.DriverID, &finish.DriverName,
+			&finish.Score, &finish.Rank)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования места в гонке: %v", err)
+		}
+		finishes = append(finishes, &finish)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по местам в гонках: %v", err)
+	}
+
+	return finishes, nil
+}
+
+// queryRecords выполняет запрос таблицы рекордов (driver_id, имя, значение, пояснение)
+func (r *RecordRepository) queryRecords(query string, args ...interface{}) ([]*models.RecordEntry, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рекордов: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.RecordEntry
+	for rows.Next() {
+		var entry models.RecordEntry
+		if err := rows.Scan(&entry.DriverID, &entry.DriverName, &entry.Value, &entry.Detail); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования рекорда: %v", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по рекордам: %v", err)
+	}
+
+	return entries, nil
+}
+
+// GetTopRaceScores возвращает лучшие результаты за одну гонку
+func (r *RecordRepository) GetTopRaceScores(limit int) ([]*models.RecordEntry, error) {
+	return r.queryRecords(`
+		SELECT rr.driver_id, d.name, rr.total_score, r.name
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		JOIN drivers d ON d.id = rr.driver_id
+		WHERE r.completed = true AND rr.confirmation_status = $1
+		ORDER BY rr.total_score DESC, r.date, d.name
+		LIMIT $2
+	`, models.ResultStatusConfirmed, limit)
+}
+
+// GetSeasonTitles возвращает гонщиков с наибольшим числом выигранных сезонов. Чемпион завершенного
+// сезона определяется так же, как для достижения: наибольшая сумма очков, при равенстве - все лидеры.
+func (r *RecordRepository) GetSeasonTitles(limit int) ([]*models.RecordEntry, error) {
+	return r.queryRecords(`
+		WITH totals AS (
+			SELECT r.season_id, rr.driver_id, SUM(rr.total_score) AS score
+			FROM race_results rr
+			JOIN races r ON r.id = rr.race_id
+			JOIN seasons s ON s.id = r.season_id
+			WHERE s.active = false AND s.end_date IS NOT NULL
+				AND r.completed = true AND rr.confirmation_status = $1
+			GROUP BY r.season_id, rr.driver_id
+		), champions AS (
+			SELECT t.season_id, t.driver_id
+			FROM totals t
+			WHERE t.score > 0 AND t.score = (SELECT MAX(m.score) FROM totals m WHERE m.season_id = t.season_id)
+		)
+		SELECT c.driver_id, d.name, COUNT(*)::int, string_agg(s.name, ', ' ORDER BY s.end_date)
+		FROM champions c
+		JOIN drivers d ON d.id = c.driver_id
+		JOIN seasons s ON s.id = c.season_id
+		GROUP BY c.driver_id, d.name
+		ORDER BY COUNT(*) DESC, d.name
+		LIMIT $2
+	`, models.ResultStatusConfirmed, limit)
+}
+
+// GetMostDrivenCars возвращает машины, которые чаще всего выдавались в завершенных гонках.
+// Версии каталога одной машины считаются вместе по ключу название + год (как models.CarKey),
+// название и класс берутся из последней выданной версии.
+func (r *RecordRepository) GetMostDrivenCars(limit int) ([]*models.CarUsage, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, c.name, COALESCE(c.year::text, ''), COALESCE(c.class_letter, ''), COALESCE(c.class_number, 0),
+			u.races, u.drivers
+		FROM (
+			SELECT lower(regexp_replace(btrim(c.name), '\s+', ' ', 'g')) AS car_key, c.year,
+				MAX(c.id) AS car_id, COUNT(*) AS races, COUNT(DISTINCT a.driver_id) AS drivers
+			FROM race_car_assignments a
+			JOIN cars c ON c.id = a.car_id
+			JOIN races r ON r.id = a.race_id
+			WHERE r.completed = true
+			GROUP BY car_key, c.year
+		) u
+		JOIN cars c ON c.id = u.car_id
+		ORDER BY u.races DESC, u.drivers DESC, c.name
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения популярных машин: %v", err)
+	}
+	defer rows.Close()
+
+	var cars []*models.CarUsage
+	for rows.Next() {
+		var car models.CarUsage
+		err := rows.Scan(&car.CarID, &car.CarName, &car.Year, &car.ClassLetter, &car.ClassNumber,
+			&car.Races, &car.Drivers)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования популярной машины: %v", err)
+		}
+		cars = append(cars, &car)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по популярным машинам: %v", err)
+	}
+
+	return cars, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"github.com/lib/pq"
 )
 
 // ResultRepository представляет репозиторий для работы с результатами гонок
@@ -17,6 +18,120 @@ func NewResultRepository(db *sql.DB) *ResultRepository {
 	return &ResultRepository{db: db}
 }
 
+// resultColumns перечисляет колонки race_results в порядке, ожидаемом scanResult
+const resultColumns = `rr.id, rr.race_id, rr.driver_id, rr.car_number, rr.car_name, rr.car_photo_url,
+	rr.results, rr.total_score, rr.reroll_penalty, rr.times, rr.confirmation_status, COALESCE(rr.confirmed_by, 0),
+	rr.statuses`
+
+// rowScanner обобщает *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanResult сканирует колонки resultColumns в результат, а затем дополнительные колонки в extra
+func scanResult(row rowScanner, result *models.RaceResult, extra ...interface{}) error {
+	var resultsJSON, timesJSON, statusesJSON string
+
+	dest := []interface{}{
+		&result.ID,
+		&result.RaceID,
+		&result.DriverID,
+		&result.CarNumber,
+		&result.CarName,
+		&result.CarPhotoURL,
+		&resultsJSON,
+		&result.TotalScore,
+		&result.RerollPenalty,
+		&timesJSON,
+		&result.ConfirmationStatus,
+		&result.ConfirmedBy,
+		&statusesJSON,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	var err error
+	result.Results, err = models.DeserializeResults(resultsJSON)
+	if err != nil {
+		return fmt.Errorf("ошибка десериализации результатов: %v", err)
+	}
+
+	result.Times, err = models.DeserializeTimes(timesJSON)
+	if err != nil {
+		return fmt.Errorf("ошибка десериализации времен: %v", err)
+	}
+
+	result.Statuses, err = models.DeserializeStatuses(statusesJSON)
+	if err != nil {
+		return fmt.Errorf("ошибка десериализации статусов: %v", err)
+	}
+
+	return nil
+}
+
+// confirmationStatus возвращает статус подтверждения для сохранения;
+// результаты без явного статуса (добавленные администратором) считаются подтвержденными
+func confirmationStatus(result *models.RaceResult) string {
+	if result.ConfirmationStatus == "" {
+		return models.ResultStatusConfirmed
+	}
+	return result.ConfirmationStatus
+}
+
+// queryResults выполняет запрос и сканирует все строки результатов
+func (r *ResultRepository) queryResults(query string, args ...interface{}) ([]*models.RaceResult, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.RaceResult
+
+	for rows.Next() {
+		var result models.RaceResult
+		if err := scanResult(rows, &result); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+
+	return results, nil
+}
+
+// queryResultsWithDriver выполняет запрос и сканирует строки результатов с именем гонщика в последней колонке
+func (r *ResultRepository) queryResultsWithDriver(query string, args ...interface{}) ([]*RaceResultWithDriver, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*RaceResultWithDriver
+
+	for rows.Next() {
+		var result RaceResultWithDriver
+		if err := scanResult(rows, &result.RaceResult, &result.DriverName); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+
+	return results, nil
+}
+
 // Create создает новый результат гонки
 func (r *ResultRepository) Create(result *models.RaceResult) (int, error) {
 	// Сериализуем результаты в JSON
@@ -25,15 +140,25 @@ func (r *ResultRepository) Create(result *models.RaceResult) (int, error) {
 		return 0, fmt.Errorf("ошибка сериализации результатов: %v", err)
 	}
 
+	timesJSON, err := models.SerializeTimes(result.Times)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации времен: %v", err)
+	}
+
+	statusesJSON, err := models.SerializeStatuses(result.Statuses)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации статусов: %v", err)
+	}
+
 	// Вставляем новый результат и используем RETURNING для PostgreSQL
 	var resultID int
 	err = r.db.QueryRow(
 		`INSERT INTO race_results 
-        (race_id, driver_id, car_number, car_name, car_photo_url, results, total_score) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        (race_id, driver_id, car_number, car_name, car_photo_url, results, total_score, times, confirmation_status, statuses) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
         RETURNING id`,
 		result.RaceID, result.DriverID, result.CarNumber, result.CarName,
-		result.CarPhotoURL, resultsJSON, result.TotalScore,
+		result.CarPhotoURL, resultsJSON, result.TotalScore, timesJSON, confirmationStatus(result), statusesJSON,
 	).Scan(&resultID)
 
 	if err != nil {
@@ -46,25 +171,14 @@ func (r *ResultRepository) Create(result *models.RaceResult) (int, error) {
 // GetByID получает результат по ID
 func (r *ResultRepository) GetByID(id int) (*models.RaceResult, error) {
 	query := `
-		SELECT id, race_id, driver_id, car_number, car_name, car_photo_url, results, total_score 
-		FROM race_results 
-		WHERE id = $1
+		SELECT ` + resultColumns + ` 
+		FROM race_results rr 
+		WHERE rr.id = $1
 	`
 
 	var result models.RaceResult
-	var resultsJSON string
-
-	err := r.db.QueryRow(query, id).Scan(
-		&result.ID,
-		&result.RaceID,
-		&result.DriverID,
-		&result.CarNumber,
-		&result.CarName,
-		&result.CarPhotoURL,
-		&resultsJSON,
-		&result.TotalScore,
-	)
 
+	err := scanResult(r.db.QueryRow(query, id), &result)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Результат не найден
@@ -72,62 +186,22 @@ func (r *ResultRepository) GetByID(id int) (*models.RaceResult, error) {
 		return nil, fmt.Errorf("ошибка получения результата: %v", err)
 	}
 
-	// Десериализуем результаты из JSON
-	result.Results, err = models.DeserializeResults(resultsJSON)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
-	}
-
 	return &result, nil
 }
 
 // GetByRaceID получает все результаты указанной гонки
 func (r *ResultRepository) GetByRaceID(raceID int) ([]*models.RaceResult, error) {
 	query := `
-		SELECT id, race_id, driver_id, car_number, car_name, car_photo_url, results, total_score 
-		FROM race_results 
-		WHERE race_id = $1 
-		ORDER BY total_score DESC
+		SELECT ` + resultColumns + ` 
+		FROM race_results rr 
+		WHERE rr.race_id = $1 
+		ORDER BY rr.total_score DESC
 	`
 
-	rows, err := r.db.Query(query, raceID)
+	results, err := r.queryResults(query, raceID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения результатов гонки: %v", err)
 	}
-	defer rows.Close()
-
-	var results []*models.RaceResult
-
-	for rows.Next() {
-		var result models.RaceResult
-		var resultsJSON string
-
-		err := rows.Scan(
-			&result.ID,
-			&result.RaceID,
-			&result.DriverID,
-			&result.CarNumber,
-			&result.CarName,
-			&result.CarPhotoURL,
-			&resultsJSON,
-			&result.TotalScore,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
-		}
-
-		// Десериализуем результаты из JSON
-		result.Results, err = models.DeserializeResults(resultsJSON)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
-		}
-
-		results = append(results, &result)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
-	}
 
 	return results, nil
 }
@@ -135,50 +209,16 @@ func (r *ResultRepository) GetByRaceID(raceID int) ([]*models.RaceResult, error)
 // GetByDriverID получает все результаты указанного гонщика
 func (r *ResultRepository) GetByDriverID(driverID int) ([]*models.RaceResult, error) {
 	query := `
-		SELECT id, race_id, driver_id, car_number, car_name, car_photo_url, results, total_score 
-		FROM race_results 
-		WHERE driver_id = $1
-		ORDER BY id DESC
+		SELECT ` + resultColumns + ` 
+		FROM race_results rr 
+		WHERE rr.driver_id = $1
+		ORDER BY rr.id DESC
 	`
 
-	rows, err := r.db.Query(query, &driverID)
+	results, err := r.queryResults(query, driverID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения результатов гонщика: %v", err)
 	}
-	defer rows.Close()
-
-	var results []*models.RaceResult
-
-	for rows.Next() {
-		var result models.RaceResult
-		var resultsJSON string
-
-		err := rows.Scan(
-			&result.ID,
-			&result.RaceID,
-			&result.DriverID,
-			&result.CarNumber,
-			&result.CarName,
-			&result.CarPhotoURL,
-			&resultsJSON,
-			&result.TotalScore,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
-		}
-
-		// Десериализуем результаты из JSON
-		result.Results, err = models.DeserializeResults(resultsJSON)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
-		}
-
-		results = append(results, &result)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
-	}
 
 	return results, nil
 }
@@ -191,14 +231,24 @@ func (r *ResultRepository) Update(result *models.RaceResult) error {
 		return fmt.Errorf("ошибка сериализации результатов: %v", err)
 	}
 
+	timesJSON, err := models.SerializeTimes(result.Times)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации времен: %v", err)
+	}
+
+	statusesJSON, err := models.SerializeStatuses(result.Statuses)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации статусов: %v", err)
+	}
+
 	// Обновляем результат
 	_, err = r.db.Exec(
 		`UPDATE race_results 
 		SET race_id = $1, driver_id = $2, car_number = $3, car_name = $4, 
-			car_photo_url = $5, results = $6, total_score = $7 
-		WHERE id = $7`,
-		&result.RaceID, &result.DriverID, &result.CarNumber, &result.CarName,
-		&result.CarPhotoURL, &resultsJSON, &result.TotalScore, &result.ID,
+			car_photo_url = $5, results = $6, total_score = $7, reroll_penalty = $8, times = $9, statuses = $10 
+		WHERE id = $11`,
+		result.RaceID, result.DriverID, result.CarNumber, result.CarName,
+		result.CarPhotoURL, resultsJSON, result.TotalScore, result.RerollPenalty, timesJSON, statusesJSON, result.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("ошибка обновления результата: %v", err)
@@ -269,15 +319,25 @@ func (r *ResultRepository) CreateWithRerollPenalty(result *models.RaceResult) (i
 		return 0, fmt.Errorf("ошибка сериализации результатов: %v", err)
 	}
 
+	timesJSON, err := models.SerializeTimes(result.Times)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации времен: %v", err)
+	}
+
+	statusesJSON, err := models.SerializeStatuses(result.Statuses)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации статусов: %v", err)
+	}
+
 	// Используем RETURNING вместо LastInsertId
 	var id int
 	err = r.db.QueryRow(
 		`INSERT INTO race_results 
-        (race_id, driver_id, car_number, car_name, car_photo_url, results, total_score, reroll_penalty) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        (race_id, driver_id, car_number, car_name, car_photo_url, results, total_score, reroll_penalty, times, confirmation_status, statuses) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
         RETURNING id`,
 		result.RaceID, result.DriverID, result.CarNumber, result.CarName,
-		result.CarPhotoURL, resultsJSON, result.TotalScore, result.RerollPenalty,
+		result.CarPhotoURL, resultsJSON, result.TotalScore, result.RerollPenalty, timesJSON, confirmationStatus(result), statusesJSON,
 	).Scan(&id)
 
 	if err != nil {
@@ -308,54 +368,17 @@ func (r *ResultRepository) GetDriverRerollStatus(raceID, driverID int) (bool, er
 // GetRaceResultsWithRerollPenalty gets race results including reroll penalties
 func (r *ResultRepository) GetRaceResultsWithRerollPenalty(raceID int) ([]*RaceResultWithDriver, error) {
 	query := `
-		SELECT rr.id, rr.race_id, rr.driver_id, rr.car_number, rr.car_name, 
-			   rr.car_photo_url, rr.results, rr.total_score, rr.reroll_penalty, d.name 
+		SELECT ` + resultColumns + `, d.name 
 		FROM race_results rr
 		JOIN drivers d ON rr.driver_id = d.id
 		WHERE rr.race_id = $1
 		ORDER BY rr.total_score DESC
 	`
 
-	rows, err := r.db.Query(query, raceID)
+	results, err := r.queryResultsWithDriver(query, raceID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения результатов гонки: %v", err)
 	}
-	defer rows.Close()
-
-	var results []*RaceResultWithDriver
-
-	for rows.Next() {
-		var result RaceResultWithDriver
-		var resultsJSON string
-
-		err := rows.Scan(
-			&result.ID,
-			&result.RaceID,
-			&result.DriverID,
-			&result.CarNumber,
-			&result.CarName,
-			&result.CarPhotoURL,
-			&resultsJSON,
-			&result.TotalScore,
-			&result.RerollPenalty,
-			&result.DriverName,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
-		}
-
-		// Deserialize results from JSON
-		result.Results, err = models.DeserializeResults(resultsJSON)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
-		}
-
-		results = append(results, &result)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
-	}
 
 	return results, nil
 }
@@ -394,50 +417,439 @@ func (r *ResultRepository) ApplyRerollPenaltyToResult(tx *sql.Tx, raceID, driver
 	return nil
 }
 
+// GetRaceResultsWithDriverNames получает результаты гонки с именами гонщиков
 func (r *ResultRepository) GetRaceResultsWithDriverNames(raceID int) ([]*RaceResultWithDriver, error) {
 	query := `
-		SELECT rr.id, rr.race_id, rr.driver_id, rr.car_number, rr.car_name, 
-			   rr.car_photo_url, rr.results, rr.total_score, rr.reroll_penalty, d.name 
+		SELECT ` + resultColumns + `, d.name 
 		FROM race_results rr
 		JOIN drivers d ON rr.driver_id = d.id
 		WHERE rr.race_id = $1
 		ORDER BY rr.total_score DESC
 	`
 
-	rows, err := r.db.Query(query, raceID)
+	results, err := r.queryResultsWithDriver(query, raceID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения результатов гонки: %v", err)
 	}
-	defer rows.Close()
 
-	var results []*RaceResultWithDriver
+	return results, nil
+}
+
+// ScoreRecalculation описывает пересчет очков одного результата
+type ScoreRecalculation struct {
+	ResultID   int
+	RaceID     int
+	SeasonID   int
+	DriverID   int
+	DriverName string
+	OldScore   int
+	NewScore   int
+	OldPenalty int
+	NewPenalty int
+	Counted    bool // результат входит в турнирную таблицу: подтвержден, а гонка завершена
+}
+
+// Changed сообщает, изменился ли результат после пересчета
+func (s *ScoreRecalculation) Changed() bool {
+	return s.OldScore != s.NewScore || s.OldPenalty != s.NewPenalty
+}
+
+// RecalculateScores пересчитывает total_score всех результатов сезона (seasonID = 0 - всех сезонов)
+// по таблицам очков rules (ключ - ID сезона, для отсутствующих сезонов действуют правила по умолчанию).
+// Все изменения выполняются в одной транзакции; при apply = false транзакция откатывается,
+// что позволяет показать пробный расчет перед применением.
+func (r *ResultRepository) RecalculateScores(seasonID int, rules map[int]*models.ScoringRules, apply bool) ([]*ScoreRecalculation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Штрафы стюардов загружаются заранее: открытый курсор не допускает других запросов в транзакции
+	penalties, err := queryPenalties(tx, `p.result_id IN (
+		SELECT rr.id FROM race_results rr JOIN races r ON rr.race_id = r.id
+		WHERE $1 = 0 OR r.season_id = $1
+	)`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	penaltiesByResult := groupPenaltiesByResult(penalties)
+
+	rows, err := tx.Query(`
+		SELECT rr.id, rr.race_id, r.season_id, rr.driver_id, d.name, rr.results, rr.statuses,
+			   rr.total_score, COALESCE(rr.reroll_penalty, 0),
+			   COALESCE(r.completed, false) AND rr.confirmation_status = $2
+		FROM race_results rr
+		JOIN races r ON rr.race_id = r.id
+		JOIN drivers d ON rr.driver_id = d.id
+		WHERE $1 = 0 OR r.season_id = $1
+		ORDER BY rr.id
+		FOR UPDATE OF rr
+	`, seasonID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения результатов для пересчета: %v", err)
+	}
+
+	var recalculations []*ScoreRecalculation
+	resultsByRace := make(map[int][]*models.RaceResult)
+	rulesByRace := make(map[int]*models.ScoringRules)
 
 	for rows.Next() {
-		var result RaceResultWithDriver
-		var resultsJSON string
+		var rc ScoreRecalculation
+		var resultsJSON, statusesJSON string
 
 		err := rows.Scan(
-			&result.ID,
-			&result.RaceID,
-			&result.DriverID,
-			&result.CarNumber,
-			&result.CarName,
-			&result.CarPhotoURL,
+			&rc.ResultID,
+			&rc.RaceID,
+			&rc.SeasonID,
+			&rc.DriverID,
+			&rc.DriverName,
 			&resultsJSON,
-			&result.TotalScore,
-			&result.RerollPenalty,
-			&result.DriverName,
+			&statusesJSON,
+			&rc.OldScore,
+			&rc.OldPenalty,
+			&rc.Counted,
 		)
 		if err != nil {
+			rows.Close()
 			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
 		}
 
-		// Десериализуем результаты из JSON
-		result.Results, err = models.DeserializeResults(resultsJSON)
+		places, err := models.DeserializeResults(resultsJSON)
 		if err != nil {
+			rows.Close()
 			return nil, fmt.Errorf("ошибка десериализации результатов: %v", err)
 		}
 
+		statuses, err := models.DeserializeStatuses(statusesJSON)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("ошибка десериализации статусов: %v", err)
+		}
+
+		seasonRules, ok := rules[rc.SeasonID]
+		if !ok {
+			seasonRules = models.DefaultScoringRules()
+		}
+
+		// Штраф пересчитывается по действующему значению сезона только для результатов, где он
+		// сейчас назначен: снятый администратором штраф за реролл не возвращается
+		if rc.OldPenalty > 0 {
+			rc.NewPenalty = seasonRules.RerollPenalty
+		}
+
+		recalculations = append(recalculations, &rc)
+		resultsByRace[rc.RaceID] = append(resultsByRace[rc.RaceID], &models.RaceResult{
+			ID:            rc.ResultID,
+			RaceID:        rc.RaceID,
+			Results:       places,
+			Statuses:      statuses,
+			RerollPenalty: rc.NewPenalty,
+		})
+		rulesByRace[rc.RaceID] = seasonRules
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+	rows.Close()
+
+	// Штрафы на позиции сдвигают места соседей, поэтому очки считаются по гонке целиком
+	scores := make(map[int]int, len(recalculations))
+	for raceID, raceResults := range resultsByRace {
+		for resultID, score := range rulesByRace[raceID].CalculateRaceScores(raceResults, penaltiesByResult) {
+			scores[resultID] = score
+		}
+	}
+	for _, rc := range recalculations {
+		rc.NewScore = scores[rc.ResultID]
+	}
+
+	if !apply {
+		return recalculations, nil
+	}
+
+	for _, rc := range recalculations {
+		if !rc.Changed() {
+			continue
+		}
+
+		_, err = tx.Exec(
+			"UPDATE race_results SET total_score = $1, reroll_penalty = $2 WHERE id = $3",
+			rc.NewScore, rc.NewPenalty, rc.ResultID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка обновления результата %d: %v", rc.ResultID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return recalculations, nil
+}
+
+// ApplyTimedPlaces пересчитывает места в дисциплинах на время по указанному времени
+// всех результатов гонки и обновляет total_score по таблице очков rules.
+// Возвращает количество измененных результатов.
+func (r *ResultRepository) ApplyTimedPlaces(raceID int, rules *models.ScoringRules) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	results, penaltiesByResult, err := lockRaceResults(tx, raceID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Собираем дисциплины, по которым указано хотя бы одно время
+	disciplines := make(map[string]bool)
+	for _, result := range results {
+		for discipline := range result.Times {
+			disciplines[discipline] = true
+		}
+	}
+
+	changed := make(map[int]bool)
+
+	for discipline := range disciplines {
+		times := make(map[int]int)
+		places := make(map[int]int)
+
+		for _, result := range results {
+			if t, ok := result.Times[discipline]; ok {
+				times[result.ID] = t
+			}
+			if place, ok := result.Results[discipline]; ok {
+				places[result.ID] = place
+			}
+		}
+
+		ranked := models.RankByTimes(times, places)
+
+		for _, result := range results {
+			place, ok := ranked[result.ID]
+			if !ok || result.Results[discipline] == place {
+				continue
+			}
+
+			result.Results[discipline] = place
+			changed[result.ID] = true
+		}
+	}
+
+	if err := saveRecalculatedPlaces(tx, results, changed, penaltiesByResult, rules); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return len(changed), nil
+}
+
+// lockRaceResults загружает результаты гонки с блокировкой строк и штрафы к ним
+func lockRaceResults(tx *sql.Tx, raceID int) ([]*models.RaceResult, map[int][]*models.Penalty, error) {
+	rows, err := tx.Query(`
+		SELECT `+resultColumns+`
+		FROM race_results rr
+		WHERE rr.race_id = $1
+		ORDER BY rr.id
+		FOR UPDATE
+	`, raceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения результатов гонки: %v", err)
+	}
+
+	var results []*models.RaceResult
+
+	for rows.Next() {
+		var result models.RaceResult
+		if err := scanResult(rows, &result); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+	rows.Close()
+
+	penalties, err := queryPenalties(tx, "p.result_id IN (SELECT id FROM race_results WHERE race_id = $1)", raceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results, groupPenaltiesByResult(penalties), nil
+}
+
+// saveRecalculatedPlaces сохраняет места измененных результатов и пересчитывает очки гонки
+// с учетом штрафов по таблице очков rules
+func saveRecalculatedPlaces(tx *sql.Tx, results []*models.RaceResult, changed map[int]bool,
+	penaltiesByResult map[int][]*models.Penalty, rules *models.ScoringRules) error {
+	for _, result := range results {
+		if !changed[result.ID] {
+			continue
+		}
+
+		resultsJSON, err := models.SerializeResults(result.Results)
+		if err != nil {
+			return fmt.Errorf("ошибка сериализации результатов: %v", err)
+		}
+
+		_, err = tx.Exec("UPDATE race_results SET results = $1 WHERE id = $2", resultsJSON, result.ID)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления результата %d: %v", result.ID, err)
+		}
+	}
+
+	return saveRaceScores(tx, results, penaltiesByResult, rules)
+}
+
+// saveRaceScores пересчитывает очки всех результатов гонки с учетом штрафов по таблице очков rules
+// и сохраняет изменившиеся. Штрафы на позиции и дисквалификации сдвигают места соседей,
+// поэтому очки пересчитываются для гонки целиком.
+func saveRaceScores(tx *sql.Tx, results []*models.RaceResult,
+	penaltiesByResult map[int][]*models.Penalty, rules *models.ScoringRules) error {
+	scores := rules.CalculateRaceScores(results, penaltiesByResult)
+
+	for _, result := range results {
+		if scores[result.ID] == result.TotalScore {
+			continue
+		}
+
+		_, err := tx.Exec("UPDATE race_results SET total_score = $1 WHERE id = $2", scores[result.ID], result.ID)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления очков результата %d: %v", result.ID, err)
+		}
+		result.TotalScore = scores[result.ID]
+	}
+
+	return nil
+}
+
+// RescoreRace пересчитывает очки всех результатов гонки с учетом штрафов стюардов
+// по таблице очков rules
+func (r *ResultRepository) RescoreRace(raceID int, rules *models.ScoringRules) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	results, penaltiesByResult, err := lockRaceResults(tx, raceID)
+	if err != nil {
+		return err
+	}
+
+	if err := saveRaceScores(tx, results, penaltiesByResult, rules); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return nil
+}
+
+// GetTimeRecords возвращает лучшее время по каждой дисциплине и классу машин среди подтвержденных результатов.
+// Если driverID > 0, возвращаются личные рекорды гонщика, иначе - абсолютные рекорды.
+func (r *ResultRepository) GetTimeRecords(driverID int) ([]*models.TimeRecord, error) {
+	query := `
+		SELECT DISTINCT ON (t.key, r.car_class)
+			t.key, r.car_class, t.value::int, rr.driver_id, d.name, rr.car_name,
+			r.id, r.name, r.season_id, r.date
+		FROM race_results rr
+		JOIN races r ON rr.race_id = r.id
+		JOIN drivers d ON rr.driver_id = d.id
+		CROSS JOIN LATERAL jsonb_each_text(rr.times) t
+		WHERE ($1 = 0 OR rr.driver_id = $1) AND rr.confirmation_status = $2
+		ORDER BY t.key, r.car_class, t.value::int ASC, r.date ASC
+	`
+
+	rows, err := r.db.Query(query, driverID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рекордов: %v", err)
+	}
+	defer rows.Close()
+
+	var records []*models.TimeRecord
+
+	for rows.Next() {
+		var record models.TimeRecord
+		var seasonID sql.NullInt64
+
+		err := rows.Scan(
+			&record.Discipline,
+			&record.CarClass,
+			&record.TimeMs,
+			&record.DriverID,
+			&record.DriverName,
+			&record.CarName,
+			&record.RaceID,
+			&record.RaceName,
+			&seasonID,
+			&record.Date,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования рекорда: %v", err)
+		}
+
+		record.SeasonID = int(seasonID.Int64)
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по рекордам: %v", err)
+	}
+
+	return records, nil
+}
+
+// PendingResult представляет результат, ожидающий подтверждения
+type PendingResult struct {
+	RaceResultWithDriver
+	RaceName    string
+	Screenshots int
+}
+
+// GetPending возвращает результаты, ожидающие подтверждения (raceID = 0 - по всем гонкам)
+func (r *ResultRepository) GetPending(raceID int) ([]*PendingResult, error) {
+	query := `
+		SELECT ` + resultColumns + `, d.name, r.name,
+			(SELECT COUNT(*) FROM result_screenshots s WHERE s.result_id = rr.id)
+		FROM race_results rr
+		JOIN drivers d ON rr.driver_id = d.id
+		JOIN races r ON rr.race_id = r.id
+		WHERE rr.confirmation_status = $1 AND ($2 = 0 OR rr.race_id = $2)
+		ORDER BY rr.created_at, rr.id
+	`
+
+	rows, err := r.db.Query(query, models.ResultStatusPending, raceID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения неподтвержденных результатов: %v", err)
+	}
+	defer rows.Close()
+
+	var results []*PendingResult
+
+	for rows.Next() {
+		var result PendingResult
+		err := scanResult(rows, &result.RaceResult, &result.DriverName, &result.RaceName, &result.Screenshots)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
 		results = append(results, &result)
 	}
 
@@ -447,3 +859,238 @@ func (r *ResultRepository) GetRaceResultsWithDriverNames(raceID int) ([]*RaceRes
 
 	return results, nil
 }
+
+// Confirm подтверждает результат. Возвращает false, если результат уже был подтвержден.
+func (r *ResultRepository) Confirm(resultID int, confirmedBy int64) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE race_results
+		SET confirmation_status = $1, confirmed_by = $2, confirmed_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND confirmation_status = $4
+	`, models.ResultStatusConfirmed, confirmedBy, resultID, models.ResultStatusPending)
+	if err != nil {
+		return false, fmt.Errorf("ошибка подтверждения результата: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка подтверждения результата: %v", err)
+	}
+
+	return affected > 0, nil
+}
+
+// MarkPending возвращает результат в очередь на подтверждение (после изменения гонщиком)
+func (r *ResultRepository) MarkPending(resultID int) error {
+	_, err := r.db.Exec(`
+		UPDATE race_results
+		SET confirmation_status = $1, confirmed_by = NULL, confirmed_at = NULL
+		WHERE id = $2
+	`, models.ResultStatusPending, resultID)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения статуса результата: %v", err)
+	}
+
+	return nil
+}
+
+// SaveScreenshot сохраняет скриншот результата в дисциплине, заменяя предыдущий
+func (r *ResultRepository) SaveScreenshot(resultID int, discipline, fileID string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO result_screenshots (result_id, discipline, file_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (result_id, discipline) DO UPDATE
+		SET file_id = EXCLUDED.file_id, uploaded_at = CURRENT_TIMESTAMP
+	`, resultID, discipline, fileID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения скриншота: %v", err)
+	}
+
+	return nil
+}
+
+// GetScreenshots возвращает скриншоты результата
+func (r *ResultRepository) GetScreenshots(resultID int) ([]*models.ResultScreenshot, error) {
+	rows, err := r.db.Query(`
+		SELECT id, result_id, discipline, file_id, uploaded_at
+		FROM result_screenshots
+		WHERE result_id = $1
+		ORDER BY id
+	`, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения скриншотов: %v", err)
+	}
+	defer rows.Close()
+
+	var screenshots []*models.ResultScreenshot
+
+	for rows.Next() {
+		var screenshot models.ResultScreenshot
+		err := rows.Scan(
+			&screenshot.ID,
+			&screenshot.ResultID,
+			&screenshot.Discipline,
+			&screenshot.FileID,
+			&screenshot.UploadedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования скриншота: %v", err)
+		}
+
+		screenshots = append(screenshots, &screenshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по скриншотам: %v", err)
+	}
+
+	return screenshots, nil
+}
+
+// GetHeadToHead возвращает подтвержденные результаты двух гонщиков во всех гонках, где участвовали оба,
+// начиная с последней гонки. Места и статусы учитывают штрафы стюардов.
+// seasonID = 0 и пустой carClass означают отсутствие фильтра.
+func (r *ResultRepository) GetHeadToHead(firstID, secondID, seasonID int, carClass string) ([]*models.HeadToHeadMeeting, error) {
+	rows, err := r.db.Query(`
+		SELECT `+resultColumns+`, r.name, r.date, r.car_class
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		WHERE rr.confirmation_status = $3
+			AND ($4 = 0 OR r.season_id = $4)
+			AND ($5 = '' OR r.car_class = $5)
+			AND (SELECT COUNT(DISTINCT o.driver_id) FROM race_results o
+				WHERE o.race_id = rr.race_id AND o.driver_id IN ($1, $2) AND o.confirmation_status = $3) = 2
+		ORDER BY r.date DESC, r.id DESC
+	`, firstID, secondID, models.ResultStatusConfirmed, seasonID, carClass)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения очных встреч: %v", err)
+	}
+	defer rows.Close()
+
+	// Штрафы на позиции сдвигают места соседей, поэтому загружаются все результаты общих гонок
+	var meetings []*models.HeadToHeadMeeting
+	byRace := make(map[int]*models.HeadToHeadMeeting)
+	var results []*models.RaceResult
+	var resultIDs []int
+
+	for rows.Next() {
+		var result models.RaceResult
+		var meeting models.HeadToHeadMeeting
+
+		if err := scanResult(rows, &result, &meeting.RaceName, &meeting.RaceDate, &meeting.CarClass); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+		resultIDs = append(resultIDs, result.ID)
+
+		if result.DriverID != firstID && result.DriverID != secondID {
+			continue
+		}
+
+		current, ok := byRace[result.RaceID]
+		if !ok {
+			meeting.RaceID = result.RaceID
+			current = &meeting
+			byRace[result.RaceID] = current
+			meetings = append(meetings, current)
+		}
+
+		if result.DriverID == firstID {
+			current.First = &result
+		} else {
+			current.Second = &result
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+
+	if len(resultIDs) == 0 {
+		return nil, nil
+	}
+
+	penalties, err := queryPenalties(r.db, "p.result_id = ANY($1)", pq.Array(resultIDs))
+	if err != nil {
+		return nil, err
+	}
+	adjusted := models.ApplyRacePenalties(results, groupPenaltiesByResult(penalties))
+
+	for _, meeting := range meetings {
+		for _, result := range []*models.RaceResult{meeting.First, meeting.Second} {
+			result.Results, result.Statuses = adjusted[result.ID].Results, adjusted[result.ID].Statuses
+		}
+	}
+
+	return meetings, nil
+}
+
+// GetDisciplineStandings возвращает зачеты по дисциплинам из подтвержденных результатов завершенных гонок сезона
+// (seasonID = 0 - по всем сезонам). Очки каждой гонки считаются по таблице очков ее сезона.
+func (r *ResultRepository) GetDisciplineStandings(seasonID int) (map[string][]*models.DisciplineStanding, error) {
+	rows, err := r.db.Query(`
+		SELECT `+resultColumns+`, COALESCE(r.season_id, 0), d.name
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		JOIN drivers d ON d.id = rr.driver_id
+		WHERE rr.confirmation_status = $1 AND r.completed = true AND ($2 = 0 OR r.season_id = $2)
+		ORDER BY rr.id
+	`, models.ResultStatusConfirmed, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения результатов для зачетов дисциплин: %v", err)
+	}
+	defer rows.Close()
+
+	var results []*models.RaceResult
+	raceSeasons := make(map[int]int)
+	driverNames := make(map[int]string)
+
+	for rows.Next() {
+		var result models.RaceResult
+		var raceSeasonID int
+		var driverName string
+
+		if err := scanResult(rows, &result, &raceSeasonID, &driverName); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		results = append(results, &result)
+		raceSeasons[result.RaceID] = raceSeasonID
+		driverNames[result.DriverID] = driverName
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	penalties, err := queryPenalties(r.db, `p.result_id IN (
+		SELECT rr.id FROM race_results rr JOIN races r ON r.id = rr.race_id
+		WHERE rr.confirmation_status = $1 AND r.completed = true AND ($2 = 0 OR r.season_id = $2))`,
+		models.ResultStatusConfirmed, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Таблица очков загружается один раз на сезон
+	scoring := NewScoringRepository(r.db)
+	rulesBySeason := make(map[int]*models.ScoringRules)
+	rulesByRace := make(map[int]*models.ScoringRules)
+
+	for raceID, raceSeasonID := range raceSeasons {
+		rules, ok := rulesBySeason[raceSeasonID]
+		if !ok {
+			rules, err = scoring.GetBySeasonID(raceSeasonID)
+			if err != nil {
+				return nil, err
+			}
+			rulesBySeason[raceSeasonID] = rules
+		}
+		rulesByRace[raceID] = rules
+	}
+
+	return models.CalculateDisciplineStandings(results, groupPenaltiesByResult(penalties), rulesByRace, driverNames), nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// ScoringRepository представляет репозиторий для работы с таблицами очков сезонов
+type ScoringRepository struct {
+	db *sql.DB
+}
+
+// NewScoringRepository создает новый репозиторий таблиц очков
+func NewScoringRepository(db *sql.DB) *ScoringRepository {
+	return &ScoringRepository{db: db}
+}
+
+// GetBySeasonID возвращает таблицу очков сезона.
+// Если для сезона таблица не задана, возвращаются правила по умолчанию.
+func (r *ScoringRepository) GetBySeasonID(seasonID int) (*models.ScoringRules, error) {
+	query := `
+		SELECT season_id, place_points, participation_points, reroll_penalty,
+			dns_points, dnf_points, dsq_points, updated_at
+		FROM season_scoring
+		WHERE season_id = $1
+	`
+
+	rules, err := scanScoringRules(r.db.QueryRow(query, seasonID))
+	if err != nil {
+		return nil, err
+	}
+
+	if rules == nil {
+		rules = models.DefaultScoringRules()
+		rules.SeasonID = seasonID
+	}
+
+	return rules, nil
+}
+
+// GetByRaceID возвращает таблицу очков сезона, к которому относится гонка
+func (r *ScoringRepository) GetByRaceID(raceID int) (*models.ScoringRules, error) {
+	var seasonID int
+	err := r.db.QueryRow("SELECT season_id FROM races WHERE id = $1", raceID).Scan(&seasonID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultScoringRules(), nil
+		}
+		return nil, fmt.Errorf("ошибка получения сезона гонки: %v", err)
+	}
+
+	return r.GetBySeasonID(seasonID)
+}
+
+// Save сохраняет таблицу очков сезона
+func (r *ScoringRepository) Save(rules *models.ScoringRules) error {
+	pointsJSON, err := models.SerializePlacePoints(rules.PlacePoints)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации очков за места: %v", err)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO season_scoring (season_id, place_points, participation_points, reroll_penalty,
+			dns_points, dnf_points, dsq_points, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (season_id) DO UPDATE
+		SET place_points = EXCLUDED.place_points,
+			participation_points = EXCLUDED.participation_points,
+			reroll_penalty = EXCLUDED.reroll_penalty,
+			dns_points = EXCLUDED.dns_points,
+			dnf_points = EXCLUDED.dnf_points,
+			dsq_points = EXCLUDED.dsq_points,
+			updated_at = CURRENT_TIMESTAMP
+	`, rules.SeasonID, pointsJSON, rules.ParticipationPoints, rules.RerollPenalty,
+		rules.DNSPoints, rules.DNFPoints, rules.DSQPoints)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения таблицы очков: %v", err)
+	}
+
+	return nil
+}
+
+// Reset удаляет таблицу очков сезона, возвращая правила по умолчанию
+func (r *ScoringRepository) Reset(seasonID int) error {
+	_, err := r.db.Exec("DELETE FROM season_scoring WHERE season_id = $1", seasonID)
+	if err != nil {
+		return fmt.Errorf("ошибка сброса таблицы очков: %v", err)
+	}
+
+	return nil
+}
+
+// scanScoringRules сканирует строку таблицы season_scoring
+func scanScoringRules(row *sql.Row) (*models.ScoringRules, error) {
+	var rules models.ScoringRules
+	var pointsJSON string
+
+	err := row.Scan(
+		&rules.SeasonID,
+		&pointsJSON,
+		&rules.ParticipationPoints,
+		&rules.RerollPenalty,
+		&rules.DNSPoints,
+		&rules.DNFPoints,
+		&rules.DSQPoints,
+		&rules.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ошибка получения таблицы очков: %v", err)
+	}
+
+	rules.PlacePoints, err = models.DeserializePlacePoints(pointsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка десериализации очков за места: %v", err)
+	}
+
+	return &rules, nil
+}
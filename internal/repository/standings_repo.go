@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// StandingsRepository представляет репозиторий для работы со снимками турнирной таблицы
+type StandingsRepository struct {
+	db *sql.DB
+}
+
+// NewStandingsRepository создает новый репозиторий снимков турнирной таблицы
+func NewStandingsRepository(db *sql.DB) *StandingsRepository {
+	return &StandingsRepository{db: db}
+}
+
+// Rebuild пересчитывает снимки турнирной таблицы после каждой завершенной гонки: таблицу сезона гонки
+// и таблицу по всем сезонам. Учитываются подтвержденные результаты, поэтому пересчет целиком
+// отражает правки результатов задним числом. Возвращает количество гонок со снимками.
+func (r *StandingsRepository) Rebuild() (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT r.id, COALESCE(r.season_id, 0), rr.driver_id, rr.total_score, rr.results
+		FROM race_results rr
+		JOIN races r ON r.id = rr.race_id
+		WHERE r.completed = true AND rr.confirmation_status = $1
+		ORDER BY r.date, r.id, rr.driver_id
+	`, models.ResultStatusConfirmed)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения результатов завершенных гонок: %v", err)
+	}
+
+	type raceResults struct {
+		raceID   int
+		seasonID int
+		results  []*models.StandingsEntry
+	}
+
+	var races []*raceResults
+
+	for rows.Next() {
+		var raceID, seasonID int
+		var entry models.StandingsEntry
+		var resultsJSON []byte
+
+		if err := rows.Scan(&raceID, &seasonID, &entry.DriverID, &entry.Points, &resultsJSON); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка сканирования данных результата: %v", err)
+		}
+
+		places, err := models.DeserializeResults(string(resultsJSON))
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("ошибка десериализации результатов: %v", err)
+		}
+
+		for _, place := range places {
+			if place > 0 {
+				entry.PlaceSum += place
+				entry.Finishes++
+			}
+		}
+
+		if len(races) == 0 || races[len(races)-1].raceID != raceID {
+			races = append(races, &raceResults{raceID: raceID, seasonID: seasonID})
+		}
+		last := races[len(races)-1]
+		last.results = append(last.results, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("ошибка итерации по результатам: %v", err)
+	}
+	rows.Close()
+
+	if _, err := tx.Exec("DELETE FROM standings_snapshots"); err != nil {
+		return 0, fmt.Errorf("ошибка очистки снимков турнирной таблицы: %v", err)
+	}
+
+	// Накопленные очки по таблицам: ID сезона (0 - все сезоны) -> ID гонщика -> итог
+	totals := make(map[int]map[int]*models.StandingsEntry)
+
+	for _, race := range races {
+		for _, scope := range []int{race.seasonID, 0} {
+			if totals[scope] == nil {
+				totals[scope] = make(map[int]*models.StandingsEntry)
+			}
+
+			for _, result := range race.results {
+				total, ok := totals[scope][result.DriverID]
+				if !ok {
+					total = &models.StandingsEntry{DriverID: result.DriverID}
+					totals[scope][result.DriverID] = total
+				}
+				total.Points += result.Points
+				total.PlaceSum += result.PlaceSum
+				total.Finishes += result.Finishes
+			}
+
+			entries := make([]*models.StandingsEntry, 0, len(totals[scope]))
+			for _, total := range totals[scope] {
+				entry := *total
+				entries = append(entries, &entry)
+			}
+			sort.Slice(entries, func(i, j int) bool { return entries[i].DriverID < entries[j].DriverID })
+			models.RankStandings(entries)
+
+			for _, entry := range entries {
+				_, err := tx.Exec(`
+					INSERT INTO standings_snapshots (season_id, race_id, driver_id, position, points, gap)
+					VALUES ($1, $2, $3, $4, $5, $6)
+				`, scope, race.raceID, entry.DriverID, entry.Position, entry.Points, entry.Gap)
+				if err != nil {
+					return 0, fmt.Errorf("ошибка сохранения снимка турнирной таблицы: %v", err)
+				}
+			}
+
+			// Для гонок без сезона таблица сезона совпадает с общей
+			if race.seasonID == 0 {
+				break
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return len(races), nil
+}
+
+// GetRaces возвращает завершенные гонки, после которых есть снимок таблицы seasonID (0 - все сезоны),
+// в хронологическом порядке
+func (r *StandingsRepository) GetRaces(seasonID int) ([]*models.Race, error) {
+	rows, err := r.db.Query(`
+		SELECT r.id, r.name, r.date
+		FROM races r
+		WHERE EXISTS (SELECT 1 FROM standings_snapshots s WHERE s.season_id = $1 AND s.race_id = r.id)
+		ORDER BY r.date, r.id
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения гонок со снимками таблицы: %v", err)
+	}
+	defer rows.Close()
+
+	var races []*models.Race
+	for rows.Next() {
+		var race models.Race
+		if err := rows.Scan(&race.ID, &race.Name, &race.Date); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования данных гонки: %v", err)
+		}
+		races = append(races, &race)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по гонкам: %v", err)
+	}
+
+	return races, nil
+}
+
+// GetSnapshot возвращает таблицу seasonID (0 - все сезоны) после гонки raceID
+func (r *StandingsRepository) GetSnapshot(seasonID, raceID int) ([]*models.StandingsEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT s.season_id, s.race_id, s.driver_id, d.name, s.position, s.points, s.gap
+		FROM standings_snapshots s
+		JOIN drivers d ON d.id = s.driver_id
+		WHERE s.season_id = $1 AND s.race_id = $2
+		ORDER BY s.position
+	`, seasonID, raceID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения снимка турнирной таблицы: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.StandingsEntry
+	for rows.Next() {
+		var entry models.StandingsEntry
+		err := rows.Scan(&entry.SeasonID, &entry.RaceID, &entry.DriverID, &entry.DriverName,
+			&entry.Position, &entry.Points, &entry.Gap)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования снимка турнирной таблицы: %v", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по снимку турнирной таблицы: %v", err)
+	}
+
+	return entries, nil
+}
+
+// GetPreviousPositions возвращает позиции гонщиков в таблице seasonID (0 - все сезоны)
+// до последней завершенной гонки. Пустая карта означает, что сравнивать не с чем.
+func (r *StandingsRepository) GetPreviousPositions(seasonID int) (map[int]int, error) {
+	rows, err := r.db.Query(`
+		SELECT s.driver_id, s.position
+		FROM standings_snapshots s
+		WHERE s.season_id = $1 AND s.race_id = (
+			SELECT r.id
+			FROM races r
+			WHERE EXISTS (SELECT 1 FROM standings_snapshots p WHERE p.season_id = $1 AND p.race_id = r.id)
+			ORDER BY r.date DESC, r.id DESC
+			OFFSET 1 LIMIT 1
+		)
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения предыдущих позиций: %v", err)
+	}
+	defer rows.Close()
+
+	positions := make(map[int]int)
+	for rows.Next() {
+		var driverID, position int
+		if err := rows.Scan(&driverID, &position); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования предыдущей позиции: %v", err)
+		}
+		positions[driverID] = position
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по предыдущим позициям: %v", err)
+	}
+
+	return positions, nil
+}
+
+// MarkTitleClinched отмечает, что гонщик досрочно обеспечил титул сезона после гонки raceID.
+// Возвращает false, если титул сезона уже был отмечен раньше.
+func (r *StandingsRepository) MarkTitleClinched(seasonID, driverID, raceID int) (bool, error) {
+	res, err := r.db.Exec(`
+		INSERT INTO title_clinches (season_id, driver_id, race_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (season_id) DO NOTHING
+	`, seasonID, driverID, raceID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка сохранения досрочного титула: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения количества сохраненных титулов: %v", err)
+	}
+
+	return affected > 0, nil
+}
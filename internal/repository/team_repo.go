@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// TeamRepository представляет репозиторий для работы с командами
+type TeamRepository struct {
+	db *sql.DB
+}
+
+// NewTeamRepository создает новый репозиторий команд
+func NewTeamRepository(db *sql.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// scanTeam сканирует строку команды
+func scanTeam(row rowScanner) (*models.Team, error) {
+	var team models.Team
+	if err := row.Scan(&team.ID, &team.SeasonID, &team.Name, &team.Badge, &team.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Create создает команду. Возвращает 0, если команда с таким названием уже есть в сезоне.
+func (r *TeamRepository) Create(team *models.Team) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO teams (season_id, name, badge)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (season_id, name) DO NOTHING
+		RETURNING id
+	`, team.SeasonID, team.Name, team.Badge).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания команды: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetByID возвращает команду по ID
+func (r *TeamRepository) GetByID(id int) (*models.Team, error) {
+	team, err := scanTeam(r.db.QueryRow(`
+		SELECT id, season_id, name, badge, created_at
+		FROM teams
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения команды: %v", err)
+	}
+
+	return team, nil
+}
+
+// GetBySeason возвращает команды сезона
+func (r *TeamRepository) GetBySeason(seasonID int) ([]*models.Team, error) {
+	rows, err := r.db.Query(`
+		SELECT id, season_id, name, badge, created_at
+		FROM teams
+		WHERE season_id = $1
+		ORDER BY name
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения команд сезона: %v", err)
+	}
+	defer rows.Close()
+
+	var teams []*models.Team
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования команды: %v", err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по командам: %v", err)
+	}
+
+	return teams, nil
+}
+
+// Delete удаляет команду вместе с составом
+func (r *TeamRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM teams WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления команды: %v", err)
+	}
+	return nil
+}
+
+// GetMembers возвращает состав команды с очками гонщиков в сезоне команды
+func (r *TeamRepository) GetMembers(teamID int) ([]*models.TeamMember, error) {
+	rows, err := r.db.Query(`
+		SELECT tm.team_id, tm.driver_id, d.name,
+			COALESCE((
+				SELECT SUM(rr.total_score)
+				FROM race_results rr
+				JOIN races r ON r.id = rr.race_id
+				WHERE rr.driver_id = tm.driver_id AND r.season_id = tm.season_id AND r.completed = true
+					AND rr.confirmation_status = $2
+			), 0)
+		FROM team_members tm
+		JOIN drivers d ON d.id = tm.driver_id
+		WHERE tm.team_id = $1
+		ORDER BY tm.joined_at, d.name
+	`, teamID, models.ResultStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения состава команды: %v", err)
+	}
+	defer rows.Close()
+
+	var members []*models.TeamMember
+	for rows.Next() {
+		var member models.TeamMember
+		if err := rows.Scan(&member.TeamID, &member.DriverID, &member.DriverName, &member.Points); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования участника команды: %v", err)
+		}
+		members = append(members, &member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по составу команды: %v", err)
+	}
+
+	return members, nil
+}
+
+// AddMember добавляет гонщика в команду, если в команде есть место и гонщик не состоит
+// в другой команде сезона. Возвращает false, если добавить нельзя.
+func (r *TeamRepository) AddMember(teamID, driverID int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем команду, чтобы параллельные добавления не превысили лимит состава
+	var seasonID int
+	err = tx.QueryRow("SELECT season_id FROM teams WHERE id = $1 FOR UPDATE", teamID).Scan(&seasonID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения команды: %v", err)
+	}
+
+	var members int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM team_members WHERE team_id = $1", teamID).Scan(&members); err != nil {
+		return false, fmt.Errorf("ошибка подсчета состава команды: %v", err)
+	}
+
+	if members >= models.TeamMaxMembers {
+		return false, nil
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO team_members (team_id, season_id, driver_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (season_id, driver_id) DO NOTHING
+	`, teamID, seasonID, driverID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка добавления гонщика в команду: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения количества добавленных гонщиков: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return affected > 0, nil
+}
+
+// RemoveMember исключает гонщика из команды
+func (r *TeamRepository) RemoveMember(teamID, driverID int) error {
+	_, err := r.db.Exec("DELETE FROM team_members WHERE team_id = $1 AND driver_id = $2", teamID, driverID)
+	if err != nil {
+		return fmt.Errorf("ошибка исключения гонщика из команды: %v", err)
+	}
+	return nil
+}
+
+// GetDriverTeams возвращает команды гонщиков сезона: ID гонщика -> команда
+func (r *TeamRepository) GetDriverTeams(seasonID int) (map[int]*models.Team, error) {
+	rows, err := r.db.Query(`
+		SELECT tm.driver_id, t.id, t.season_id, t.name, t.badge, t.created_at
+		FROM team_members tm
+		JOIN teams t ON t.id = tm.team_id
+		WHERE tm.season_id = $1
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения команд гонщиков: %v", err)
+	}
+	defer rows.Close()
+
+	teams := make(map[int]*models.Team)
+	for rows.Next() {
+		var driverID int
+		var team models.Team
+		if err := rows.Scan(&driverID, &team.ID, &team.SeasonID, &team.Name, &team.Badge, &team.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования команды гонщика: %v", err)
+		}
+		teams[driverID] = &team
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по командам гонщиков: %v", err)
+	}
+
+	return teams, nil
+}
+
+// GetStandings возвращает командный зачет сезона: очки команды - сумма подтвержденных очков
+// ее гонщиков в завершенных гонках сезона
+func (r *TeamRepository) GetStandings(seasonID int) ([]*models.TeamStanding, error) {
+	teams, err := r.GetBySeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]*models.TeamStanding, 0, len(teams))
+	for _, team := range teams {
+		members, err := r.GetMembers(team.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		standing := &models.TeamStanding{Team: team, Members: members}
+		for _, member := range members {
+			standing.Points += member.Points
+		}
+		standings = append(standings, standing)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Points > standings[j].Points
+	})
+
+	for i, standing := range standings {
+		standing.Position = i + 1
+	}
+
+	return standings, nil
+}
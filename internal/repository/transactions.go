@@ -231,15 +231,25 @@ func (r *ResultRepository) CreateWithTx(tx *sql.Tx, result *models.RaceResult) (
 		return 0, fmt.Errorf("ошибка сериализации результатов: %v", err)
 	}
 
+	timesJSON, err := models.SerializeTimes(result.Times)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации времен: %v", err)
+	}
+
+	statusesJSON, err := models.SerializeStatuses(result.Statuses)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации статусов: %v", err)
+	}
+
 	// Вставляем новый результат
 	var id int
 	err = tx.QueryRow(
 		`INSERT INTO race_results 
-		(race_id, driver_id, car_number, car_name, car_photo_url, results, total_score) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		(race_id, driver_id, car_number, car_name, car_photo_url, results, total_score, times, confirmation_status, statuses) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`,
 		result.RaceID, result.DriverID, result.CarNumber, result.CarName,
-		result.CarPhotoURL, resultsJSON, result.TotalScore,
+		result.CarPhotoURL, resultsJSON, result.TotalScore, timesJSON, confirmationStatus(result), statusesJSON,
 	).Scan(&id)
 
 	if err != nil {
@@ -257,14 +267,24 @@ func (r *ResultRepository) UpdateWithTx(tx *sql.Tx, result *models.RaceResult) e
 		return fmt.Errorf("ошибка сериализации результатов: %v", err)
 	}
 
+	timesJSON, err := models.SerializeTimes(result.Times)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации времен: %v", err)
+	}
+
+	statusesJSON, err := models.SerializeStatuses(result.Statuses)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации статусов: %v", err)
+	}
+
 	// Обновляем результат
 	_, err = tx.Exec(
 		`UPDATE race_results 
 		SET race_id = $1, driver_id = $2, car_number = $3, car_name = $4, 
-			car_photo_url = $5, results = $6, total_score = $7 
-		WHERE id = $8`,
+			car_photo_url = $5, results = $6, total_score = $7, times = $8, statuses = $9 
+		WHERE id = $10`,
 		result.RaceID, result.DriverID, result.CarNumber, result.CarName,
-		result.CarPhotoURL, resultsJSON, result.TotalScore, result.ID,
+		result.CarPhotoURL, resultsJSON, result.TotalScore, timesJSON, statusesJSON, result.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("ошибка обновления результата: %v", err)
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// VoteRepository представляет репозиторий для работы с бюллетенями судейских дисциплин
+type VoteRepository struct {
+	db *sql.DB
+}
+
+// NewVoteRepository создает новый репозиторий бюллетеней
+func NewVoteRepository(db *sql.DB) *VoteRepository {
+	return &VoteRepository{db: db}
+}
+
+// queryBallots выбирает бюллетени по условию where (алиас таблицы - v)
+func queryBallots(q queryer, where string, args ...interface{}) ([]*models.Ballot, error) {
+	rows, err := q.Query(`
+		SELECT v.id, v.race_id, v.discipline, v.voter_id, v.ranking, v.created_at
+		FROM race_votes v
+		WHERE `+where+`
+		ORDER BY v.created_at, v.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бюллетеней: %v", err)
+	}
+	defer rows.Close()
+
+	var ballots []*models.Ballot
+	for rows.Next() {
+		var ballot models.Ballot
+		var rankingJSON string
+
+		err := rows.Scan(
+			&ballot.ID,
+			&ballot.RaceID,
+			&ballot.Discipline,
+			&ballot.VoterID,
+			&rankingJSON,
+			&ballot.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования бюллетеня: %v", err)
+		}
+
+		ballot.Ranking, err = models.DeserializeRanking(rankingJSON)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка десериализации бюллетеня: %v", err)
+		}
+
+		ballots = append(ballots, &ballot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по бюллетеням: %v", err)
+	}
+
+	return ballots, nil
+}
+
+// queryParticipants возвращает ID зарегистрированных на гонку гонщиков
+func queryParticipants(q queryer, raceID int) ([]int, error) {
+	rows, err := q.Query("SELECT driver_id FROM race_registrations WHERE race_id = $1 ORDER BY driver_id", raceID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения участников гонки: %v", err)
+	}
+	defer rows.Close()
+
+	var participants []int
+	for rows.Next() {
+		var driverID int
+		if err := rows.Scan(&driverID); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования участника гонки: %v", err)
+		}
+		participants = append(participants, driverID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка итерации по участникам гонки: %v", err)
+	}
+
+	return participants, nil
+}
+
+// GetByRace возвращает бюллетени дисциплины гонки
+func (r *VoteRepository) GetByRace(raceID int, discipline string) ([]*models.Ballot, error) {
+	return queryBallots(r.db, "v.race_id = $1 AND v.discipline = $2", raceID, discipline)
+}
+
+// GetParticipants возвращает ID гонщиков, участвующих в голосовании гонки
+func (r *VoteRepository) GetParticipants(raceID int) ([]int, error) {
+	return queryParticipants(r.db, raceID)
+}
+
+// HasVoted проверяет, отправил ли гонщик бюллетень в дисциплине гонки
+func (r *VoteRepository) HasVoted(raceID int, discipline string, voterID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM race_votes WHERE race_id = $1 AND discipline = $2 AND voter_id = $3)
+	`, raceID, discipline, voterID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки бюллетеня: %v", err)
+	}
+
+	return exists, nil
+}
+
+// Create сохраняет бюллетень. Возвращает 0, если гонщик уже голосовал в этой дисциплине.
+func (r *VoteRepository) Create(ballot *models.Ballot) (int, error) {
+	rankingJSON, err := models.SerializeRanking(ballot.Ranking)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации бюллетеня: %v", err)
+	}
+
+	var id int
+	err = r.db.QueryRow(`
+		INSERT INTO race_votes (race_id, discipline, voter_id, ranking)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (race_id, discipline, voter_id) DO NOTHING
+		RETURNING id, created_at
+	`, ballot.RaceID, ballot.Discipline, ballot.VoterID, rankingJSON).Scan(&id, &ballot.CreatedAt)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения бюллетеня: %v", err)
+	}
+
+	ballot.ID = id
+	return id, nil
+}
+
+// ApplyJudgedPlaces распределяет места в судейских дисциплинах гонки по итогам голосования
+// и обновляет total_score по таблице очков rules. Дисциплины без бюллетеней и результаты
+// со статусом DNS/DNF/DSQ не изменяются. Возвращает количество измененных результатов.
+func (r *VoteRepository) ApplyJudgedPlaces(raceID int, rules *models.ScoringRules) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %v", err)
+	}
+	defer tx.Rollback()
+
+	results, penaltiesByResult, err := lockRaceResults(tx, raceID)
+	if err != nil {
+		return 0, err
+	}
+
+	ballots, err := queryBallots(tx, "v.race_id = $1", raceID)
+	if err != nil {
+		return 0, err
+	}
+
+	participants, err := queryParticipants(tx, raceID)
+	if err != nil {
+		return 0, err
+	}
+
+	byDiscipline := make(map[string][]*models.Ballot)
+	for _, ballot := range ballots {
+		byDiscipline[ballot.Discipline] = append(byDiscipline[ballot.Discipline], ballot)
+	}
+
+	changed := make(map[int]bool)
+
+	for discipline, disciplineBallots := range byDiscipline {
+		places := make(map[int]int)
+		for _, tally := range models.TallyBallots(disciplineBallots, participants) {
+			places[tally.DriverID] = tally.Place
+		}
+
+		for _, result := range results {
+			place, ok := places[result.DriverID]
+			if !ok || result.Statuses[discipline] != "" {
+				continue
+			}
+
+			if current, exists := result.Results[discipline]; exists && current == place {
+				continue
+			}
+
+			result.Results[discipline] = place
+			changed[result.ID] = true
+		}
+	}
+
+	if err := saveRecalculatedPlaces(tx, results, changed, penaltiesByResult, rules); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения транзакции: %v", err)
+	}
+
+	return len(changed), nil
+}
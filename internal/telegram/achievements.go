@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+)
+
+// backfillAchievements выдает достижения за все завершенные гонки и сезоны без уведомлений
+func (b *Bot) backfillAchievements() {
+	awarded, err := b.AchievementRepo.EvaluateAll()
+	if err != nil {
+		log.Printf("Ошибка проверки достижений: %v", err)
+		return
+	}
+	log.Printf("Проверка достижений завершена, выдано новых: %d", awarded)
+}
+
+// evaluateRaceAchievements проверяет достижения по итогам завершенной гонки и ее сезона
+// и сообщает гонщикам о новых наградах
+func (b *Bot) evaluateRaceAchievements(raceID int) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		log.Printf("Ошибка получения гонки %d для проверки достижений: %v", raceID, err)
+		return
+	}
+
+	if race.State != models.RaceStateCompleted {
+		return
+	}
+
+	awarded, err := b.AchievementRepo.EvaluateRace(raceID)
+	if err != nil {
+		log.Printf("Ошибка проверки достижений гонки %d: %v", raceID, err)
+		return
+	}
+
+	for driverID, codes := range awarded {
+		b.announceAchievements(driverID, codes, fmt.Sprintf("по итогам гонки '%s'", race.Name))
+	}
+
+	b.evaluateSeasonAchievements(race.SeasonID)
+}
+
+// evaluateSeasonAchievements выдает достижение чемпиона завершенного сезона и сообщает о нем
+func (b *Bot) evaluateSeasonAchievements(seasonID int) {
+	champions, err := b.AchievementRepo.EvaluateSeason(seasonID)
+	if err != nil {
+		log.Printf("Ошибка проверки чемпиона сезона %d: %v", seasonID, err)
+		return
+	}
+
+	if len(champions) == 0 {
+		return
+	}
+
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения сезона %d: %v", seasonID, err)
+		return
+	}
+
+	for _, driverID := range champions {
+		b.announceAchievements(driverID, []string{models.AchievementSeasonChampion}, fmt.Sprintf("по итогам сезона '%s'", season.Name))
+	}
+}
+
+// announceAchievements отправляет гонщику сообщение о новых достижениях
+func (b *Bot) announceAchievements(driverID int, codes []string, reason string) {
+	driver, err := b.DriverRepo.GetByID(driverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика %d для уведомления о достижениях: %v", driverID, err)
+		return
+	}
+
+	text := fmt.Sprintf("🏅 *Новые достижения %s!*\n\n", reason)
+	for _, code := range codes {
+		achievement := models.NewAchievement(code, "")
+		text += fmt.Sprintf("%s *%s* — %s\n", achievement.Emoji, achievement.Name, achievement.Description)
+	}
+
+	b.sendMessage(driver.TelegramID, text)
+}
+
+// formatAchievements форматирует список достижений для карточки гонщика
+func formatAchievements(stats *models.DriverStats) string {
+	if len(stats.Achievements) == 0 {
+		return ""
+	}
+
+	text := "\n🏅 *Достижения:*\n"
+	for _, achievement := range stats.Achievements {
+		text += fmt.Sprintf("%s %s (%s)\n", achievement.Emoji, achievement.Name, achievement.DateEarned)
+	}
+
+	return text
+}
@@ -16,11 +16,21 @@ type Bot struct {
 	API              *tgbotapi.BotAPI
 	Config           *config.Config
 	StateManager     *UserStateManager
+	CarSearches      *CarSearchStore
 	DriverRepo       *repository.DriverRepository
 	SeasonRepo       *repository.SeasonRepository
 	RaceRepo         *repository.RaceRepository
 	ResultRepo       *repository.ResultRepository
 	CarRepo          *repository.CarRepository
+	ScoringRepo      *repository.ScoringRepository
+	PenaltyRepo      *repository.PenaltyRepository
+	VoteRepo         *repository.VoteRepository
+	RatingRepo       *repository.RatingRepository
+	AchievementRepo  *repository.AchievementRepository
+	StandingsRepo    *repository.StandingsRepository
+	TeamRepo         *repository.TeamRepository
+	DivisionRepo     *repository.DivisionRepository
+	RecordRepo       *repository.RecordRepository
 	CommandHandlers  map[string]CommandHandler
 	CallbackHandlers map[string]CallbackHandler
 	AdminIDs         map[int64]bool
@@ -49,6 +59,15 @@ func New(cfg *config.Config, db *sql.DB) (*Bot, error) {
 	raceRepo := repository.NewRaceRepository(db)
 	resultRepo := repository.NewResultRepository(db)
 	carRepo := repository.NewCarRepository(db)
+	scoringRepo := repository.NewScoringRepository(db)
+	penaltyRepo := repository.NewPenaltyRepository(db)
+	voteRepo := repository.NewVoteRepository(db)
+	ratingRepo := repository.NewRatingRepository(db)
+	achievementRepo := repository.NewAchievementRepository(db)
+	standingsRepo := repository.NewStandingsRepository(db)
+	teamRepo := repository.NewTeamRepository(db)
+	divisionRepo := repository.NewDivisionRepository(db)
+	recordRepo := repository.NewRecordRepository(db)
 	stateManager := NewUserStateManager()
 
 	adminIDs := make(map[int64]bool)
@@ -60,11 +79,21 @@ func New(cfg *config.Config, db *sql.DB) (*Bot, error) {
 		API:              botAPI,
 		Config:           cfg,
 		StateManager:     stateManager,
+		CarSearches:      NewCarSearchStore(),
 		DriverRepo:       driverRepo,
 		SeasonRepo:       seasonRepo,
 		RaceRepo:         raceRepo,
 		ResultRepo:       resultRepo,
 		CarRepo:          carRepo,
+		ScoringRepo:      scoringRepo,
+		PenaltyRepo:      penaltyRepo,
+		VoteRepo:         voteRepo,
+		RatingRepo:       ratingRepo,
+		AchievementRepo:  achievementRepo,
+		StandingsRepo:    standingsRepo,
+		TeamRepo:         teamRepo,
+		DivisionRepo:     divisionRepo,
+		RecordRepo:       recordRepo,
 		CommandHandlers:  make(map[string]CommandHandler),
 		CallbackHandlers: make(map[string]CallbackHandler),
 		AdminIDs:         adminIDs,
@@ -74,6 +103,25 @@ func New(cfg *config.Config, db *sql.DB) (*Bot, error) {
 	bot.registerCommandHandlers()
 	bot.registerCarCommandHandlers()
 	bot.registerCallbackHandlers()
+	bot.registerScoringHandlers()
+	bot.registerTimeHandlers()
+	bot.registerConflictHandlers()
+	bot.registerConfirmationHandlers()
+	bot.registerPenaltyHandlers()
+	bot.registerVoteHandlers()
+	bot.registerRatingHandlers()
+	bot.registerStandingsHandlers()
+	bot.registerHeadToHeadHandlers()
+	bot.registerBreakdownHandlers()
+	bot.registerDisciplineStandingsHandlers()
+	bot.registerSeasonFinaleHandlers()
+	bot.registerTeamHandlers()
+	bot.registerDivisionHandlers()
+	bot.registerRecordHandlers()
+	bot.registerTitleHandlers()
+	bot.registerCarCatalogHandlers()
+	bot.registerCarSearchHandlers()
+	bot.registerCarPoolHandlers()
 
 	if _, exists := bot.CallbackHandlers["register_race"]; !exists {
 		log.Printf("ВНИМАНИЕ: Обработчик register_race не зарегистрирован!")
@@ -92,6 +140,18 @@ func New(cfg *config.Config, db *sql.DB) (*Bot, error) {
 func (b *Bot) Start() {
 	log.Printf("Bot %s successfully started", b.API.Self.UserName)
 
+	// Rebuild Elo ratings so races completed before the rating existed are counted
+	go b.recalculateRatings()
+
+	// Rebuild standings snapshots from the completed races
+	go b.rebuildStandings()
+
+	// Award achievements for races completed before the rules existed
+	go b.backfillAchievements()
+
+	// Fill an empty car catalog from the wiki
+	go b.importCarsIfEmpty()
+
 	// Configure update receiver
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
@@ -206,6 +266,12 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 		return
 	}
 
+	// Администратор может прислать файл каталога машин
+	if message.Document != nil && b.IsAdmin(userID) {
+		b.handleCarCatalogDocument(message)
+		return
+	}
+
 	// Если у пользователя нет состояния и это не команда, игнорируем сообщение
 	// или можно ответить подсказкой
 	b.sendMessage(chatID, "Используйте /help для получения списка доступных команд.")
@@ -304,6 +370,19 @@ func (b *Bot) sendPhotoWithKeyboard(chatID int64, photoURL, caption string, keyb
 	return message
 }
 
+// sendDocument отправляет файл с подписью
+func (b *Bot) sendDocument(chatID int64, fileName string, data []byte, caption string) tgbotapi.Message {
+	document := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: fileName, Bytes: data})
+	document.Caption = caption
+
+	message, err := b.API.Send(document)
+	if err != nil {
+		log.Printf("Ошибка отправки файла: %v", err)
+	}
+
+	return message
+}
+
 // editMessageKeyboard редактирует только клавиатуру сообщения
 func (b *Bot) editMessageKeyboard(chatID int64, messageID int, keyboard tgbotapi.InlineKeyboardMarkup) {
 	edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, keyboard)
@@ -0,0 +1,222 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Страницы статистики по дисциплинам и классам
+const (
+	breakdownDisciplines = "disc"
+	breakdownClasses     = "class"
+)
+
+// registerBreakdownHandlers регистрирует обработчики статистики по дисциплинам и классам
+func (b *Bot) registerBreakdownHandlers() {
+	b.CallbackHandlers["driver_breakdown"] = b.callbackDriverBreakdown
+	b.CallbackHandlers["stats_breakdown"] = b.callbackStatsBreakdown
+}
+
+// driverBreakdownRow создает кнопки страниц статистики гонщика для карточки
+func driverBreakdownRow(driverID int) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🎯 По дисциплинам",
+			fmt.Sprintf("driver_breakdown:%d:%s", driverID, breakdownDisciplines)),
+		tgbotapi.NewInlineKeyboardButtonData("🚗 По классам",
+			fmt.Sprintf("driver_breakdown:%d:%s", driverID, breakdownClasses)),
+	)
+}
+
+// formatDisciplineBreakdownLine форматирует статистику в одной дисциплине
+func formatDisciplineBreakdownLine(item *models.DisciplineBreakdown) string {
+	text := fmt.Sprintf("ср. место %s, 🥇 %d, 🏆 %d, стартов %d",
+		formatAveragePlaceValue(item.AveragePlace), item.Wins, item.Podiums, item.Entries)
+	if item.Statuses > 0 {
+		text += fmt.Sprintf(", DNS/DNF/DSQ %d", item.Statuses)
+	}
+	return text
+}
+
+// formatClassBreakdownLine форматирует статистику в одном классе машин
+func formatClassBreakdownLine(item *models.ClassBreakdown) string {
+	return fmt.Sprintf("гонок %d, очков %d (%.1f за гонку), ср. место %s, 🥇 %d, 🏆 %d",
+		item.Races, item.Points, item.AveragePoints(), formatAveragePlaceValue(item.AveragePlace), item.Wins, item.Podiums)
+}
+
+// formatAveragePlaceValue форматирует среднее место, 0 означает отсутствие мест
+func formatAveragePlaceValue(average float64) string {
+	if average <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", average)
+}
+
+// buildDriverBreakdownView формирует страницу статистики гонщика по дисциплинам или классам
+func (b *Bot) buildDriverBreakdownView(driver *models.Driver, page string) (string, error) {
+	var text string
+
+	switch page {
+	case breakdownClasses:
+		items, err := b.DriverRepo.GetClassBreakdown(driver.ID, 0)
+		if err != nil {
+			return "", err
+		}
+
+		text = fmt.Sprintf("🚗 *%s: статистика по классам*\n\n", driver.Name)
+		if len(items) == 0 {
+			text += "Пока нет подтвержденных результатов."
+		}
+		for _, item := range items {
+			text += fmt.Sprintf("*%s*: %s\n", item.CarClass, formatClassBreakdownLine(item))
+		}
+
+	default:
+		items, err := b.DriverRepo.GetDisciplineBreakdown(driver.ID, 0)
+		if err != nil {
+			return "", err
+		}
+
+		text = fmt.Sprintf("🎯 *%s: статистика по дисциплинам*\n\n", driver.Name)
+		if len(items) == 0 {
+			text += "Пока нет подтвержденных результатов."
+		}
+		for _, item := range items {
+			text += fmt.Sprintf("*%s*: %s\n", item.Discipline, formatDisciplineBreakdownLine(item))
+		}
+	}
+
+	return text + "\n🏆 - места с 1 по 3", nil
+}
+
+// callbackDriverBreakdown показывает страницу статистики гонщика (driver_breakdown:driverID:page)
+func (b *Bot) callbackDriverBreakdown(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	driverID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	page := breakdownDisciplines
+	if len(parts) > 2 {
+		page = parts[2]
+	}
+
+	driver, err := b.DriverRepo.GetByID(driverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика %d: %v", driverID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Гонщик не найден", true)
+		return
+	}
+
+	text, err := b.buildDriverBreakdownView(driver, page)
+	if err != nil {
+		log.Printf("Ошибка получения статистики гонщика %d: %v", driverID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении статистики", true)
+		return
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		driverBreakdownRow(driverID),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+		),
+	)
+
+	// Карточка гонщика может быть фото с подписью, поэтому страницы открываются отдельным сообщением
+	if query.Message.Photo != nil {
+		b.sendMessageWithKeyboard(chatID, text, keyboard)
+		return
+	}
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackStatsBreakdown показывает статистику всех гонщиков сезона по дисциплинам или классам
+// (stats_breakdown:seasonID:page)
+func (b *Bot) callbackStatsBreakdown(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	page := breakdownDisciplines
+	if len(parts) > 2 {
+		page = parts[2]
+	}
+
+	seasonName := b.standingsScopeName(seasonID)
+	var text string
+
+	switch page {
+	case breakdownClasses:
+		items, err := b.DriverRepo.GetClassBreakdown(0, seasonID)
+		if err != nil {
+			log.Printf("Ошибка получения статистики по классам: %v", err)
+			b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении статистики", true)
+			return
+		}
+
+		text = fmt.Sprintf("🚗 *Статистика по классам: %s*\n", seasonName)
+		if len(items) == 0 {
+			text += "\nПока нет подтвержденных результатов."
+		}
+
+		class := ""
+		for _, item := range items {
+			if item.CarClass != class {
+				class = item.CarClass
+				text += fmt.Sprintf("\n*Класс %s*\n", class)
+			}
+			text += fmt.Sprintf("• %s: %s\n", item.DriverName, formatClassBreakdownLine(item))
+		}
+
+	default:
+		items, err := b.DriverRepo.GetDisciplineBreakdown(0, seasonID)
+		if err != nil {
+			log.Printf("Ошибка получения статистики по дисциплинам: %v", err)
+			b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении статистики", true)
+			return
+		}
+
+		text = fmt.Sprintf("🎯 *Статистика по дисциплинам: %s*\n", seasonName)
+		if len(items) == 0 {
+			text += "\nПока нет подтвержденных результатов."
+		}
+
+		discipline := ""
+		for _, item := range items {
+			if item.Discipline != discipline {
+				discipline = item.Discipline
+				text += fmt.Sprintf("\n*%s*\n", discipline)
+			}
+			text += fmt.Sprintf("• %s: %s\n", item.DriverName, formatDisciplineBreakdownLine(item))
+		}
+	}
+
+	text += "\n🏆 - места с 1 по 3"
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🎯 По дисциплинам",
+				fmt.Sprintf("stats_breakdown:%d:%s", seasonID, breakdownDisciplines)),
+			tgbotapi.NewInlineKeyboardButtonData("🚗 По классам",
+				fmt.Sprintf("stats_breakdown:%d:%s", seasonID, breakdownClasses)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К статистике", fmt.Sprintf("stats_season:%d", seasonID)),
+		),
+	)
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
@@ -469,12 +469,21 @@ func (b *Bot) callbackDriverCard(query *tgbotapi.CallbackQuery) {
 	// Формируем карточку гонщика
 	text := fmt.Sprintf("👨‍🏎️ *Карточка гонщика*\n\n*%s*\n", driver.Name)
 
+	text += b.formatDriverTeam(driver.ID)
+
 	if driver.Description != "" {
 		text += fmt.Sprintf("📋 *Описание:* %s\n\n", driver.Description)
 	}
 
 	text += fmt.Sprintf("🏆 *Всего очков:* %d\n", stats.TotalScore)
-	text += fmt.Sprintf("🏁 *Гонок:* %d\n\n", stats.TotalRaces)
+	text += fmt.Sprintf("🏁 *Гонок:* %d\n", stats.TotalRaces)
+	if stats.AveragePlace > 0 {
+		text += fmt.Sprintf("📈 *Среднее место:* %.2f\n", stats.AveragePlace)
+	}
+	text += formatStatusCounts(stats)
+	text += b.formatDriverRating(driver.ID)
+	text += formatAchievements(stats)
+	text += "\n"
 
 	if len(stats.RecentRaces) > 0 {
 		text += "*Последние гонки:*\n"
@@ -487,20 +496,17 @@ func (b *Bot) callbackDriverCard(query *tgbotapi.CallbackQuery) {
 
 	// Отправляем карточку гонщика
 	// Если пользователь смотрит свою карточку, добавляем кнопки редактирования
+	// Кнопки страниц статистики по дисциплинам и классам есть у любой карточки
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(driverBreakdownRow(driver.ID))
 	if driver.TelegramID == query.From.ID {
-		keyboard := DriverProfileKeyboard()
+		keyboard = DriverProfileKeyboard()
+		keyboard.InlineKeyboard = append([][]tgbotapi.InlineKeyboardButton{driverBreakdownRow(driver.ID)}, keyboard.InlineKeyboard...)
+	}
 
-		if driver.PhotoURL != "" {
-			b.sendPhotoWithKeyboard(chatID, driver.PhotoURL, text, keyboard)
-		} else {
-			b.sendMessageWithKeyboard(chatID, text, keyboard)
-		}
+	if driver.PhotoURL != "" {
+		b.sendPhotoWithKeyboard(chatID, driver.PhotoURL, text, keyboard)
 	} else {
-		if driver.PhotoURL != "" {
-			b.sendPhoto(chatID, driver.PhotoURL, text)
-		} else {
-			b.sendMessage(chatID, text)
-		}
+		b.sendMessageWithKeyboard(chatID, text, keyboard)
 	}
 
 	// Удаляем сообщение с кнопкой
@@ -647,10 +653,18 @@ func (b *Bot) callbackAddResult(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// Места в судейских дисциплинах определяются голосованием, гонщик их не вводит
+	disciplines := selfReportedDisciplines(race.Disciplines)
+	if len(disciplines) == 0 {
+		b.submitJudgedOnlyResult(chatID, userID, race, assignment.AssignmentNumber,
+			assignment.Car.Name+" ("+assignment.Car.Year+")", assignment.Car.ImageURL)
+		return
+	}
+
 	// Создаем клавиатуру с дисциплинами гонки
 	var keyboard [][]tgbotapi.InlineKeyboardButton
 
-	for _, discipline := range race.Disciplines {
+	for _, discipline := range disciplines {
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				discipline,
@@ -670,7 +684,7 @@ func (b *Bot) callbackAddResult(query *tgbotapi.CallbackQuery) {
 	// Отправляем сообщение с выбором дисциплины
 	b.sendMessageWithKeyboard(
 		chatID,
-		fmt.Sprintf("🏁 *Добавление результата для гонки '%s'*\n\nВыберите дисциплину:", race.Name),
+		fmt.Sprintf("🏁 *Добавление результата для гонки '%s'*\n\n%sВыберите дисциплину:", race.Name, judgedDisciplinesNote(race)),
 		tgbotapi.NewInlineKeyboardMarkup(keyboard...),
 	)
 
@@ -790,7 +804,7 @@ func (b *Bot) callbackDisciplinesDone(query *tgbotapi.CallbackQuery) {
 	}
 
 	// Сохраняем гонку в БД
-	_, err = b.RaceRepo.Create(race)
+	race.ID, err = b.RaceRepo.Create(race)
 	if err != nil {
 		log.Printf("Ошибка создания гонки: %v", err)
 		b.sendMessage(chatID, "⚠️ Произошла ошибка при создании гонки.")
@@ -810,6 +824,9 @@ func (b *Bot) callbackDisciplinesDone(query *tgbotapi.CallbackQuery) {
 
 	b.sendMessage(chatID, "✅ Новая гонка успешно создана!")
 
+	// В сезоне с дивизионами гонка проводится для одного из них
+	b.sendRaceDivisionPicker(chatID, race)
+
 	// Показываем гонки сезона
 	b.callbackSeasonRaces(&tgbotapi.CallbackQuery{
 		Data: fmt.Sprintf("season_races:%d", race.SeasonID),
@@ -820,7 +837,7 @@ func (b *Bot) callbackDisciplinesDone(query *tgbotapi.CallbackQuery) {
 	})
 }
 
-// callbackCompleteRace обрабатывает завершение гонки
+// callbackCompleteRace проверяет конфликты мест и запрашивает подтверждение завершения гонки
 func (b *Bot) callbackCompleteRace(query *tgbotapi.CallbackQuery) {
 	userID := query.From.ID
 	chatID := query.Message.Chat.ID
@@ -857,18 +874,45 @@ func (b *Bot) callbackCompleteRace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
-	// Обновляем статус гонки
-	err = b.RaceRepo.UpdateCompleted(raceID, true)
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.sendMessage(chatID, "⚠️ Гонка не найдена.")
+		return
+	}
+
+	// Проверяем конфликты мест: при их наличии показываем экран разрешения
+	conflicts, err := b.findRaceConflicts(race)
 	if err != nil {
-		log.Printf("Ошибка завершения гонки: %v", err)
-		b.sendMessage(chatID, "⚠️ Произошла ошибка при завершении гонки.")
+		log.Printf("Ошибка проверки конфликтов гонки: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при проверке результатов гонки.")
 		return
 	}
 
-	b.sendMessage(chatID, "✅ Гонка успешно завершена!")
+	if len(conflicts) > 0 {
+		b.showRaceConflicts(chatID, race)
+		b.deleteMessage(chatID, query.Message.MessageID)
+		return
+	}
 
-	// Показываем обновленные результаты гонки
-	b.showRaceResults(chatID, raceID)
+	// Запрашиваем подтверждение завершения
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"✅ Да, завершить",
+				fmt.Sprintf("complete_race_confirm:%d", raceID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				"❌ Отмена",
+				fmt.Sprintf("admin_race_panel:%d", raceID),
+			),
+		),
+	)
+
+	b.sendMessageWithKeyboard(
+		chatID,
+		fmt.Sprintf("🏁 Завершить гонку '%s'?\n\nКонфликтов в результатах не найдено. Участникам будут отправлены уведомления с результатами.", race.Name),
+		keyboard,
+	)
 
 	// Удаляем сообщение с кнопкой
 	b.deleteMessage(chatID, query.Message.MessageID)
@@ -1114,6 +1158,14 @@ func (b *Bot) callbackSeasonResults(query *tgbotapi.CallbackQuery) {
 		text += "В этом сезоне пока нет завершенных гонок."
 	} else {
 		text += fmt.Sprintf("*Завершено гонок:* %d из %d\n\n", len(completedRaces), len(races))
+
+		// Чемпионы дисциплин объявляются по итогам завершенного сезона
+		if !season.Active && !season.EndDate.IsZero() {
+			if champions := b.formatDisciplineChampions(seasonID); champions != "" {
+				text += champions + "\n"
+			}
+		}
+
 		text += "Выберите гонку для просмотра детальных результатов:"
 	}
 
@@ -1271,13 +1323,6 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
-	// disciplineName := parts[1] // We actually get the discipline from state
-	place, err := strconv.Atoi(parts[2])
-	if err != nil || place < 0 || place > 3 {
-		b.sendMessage(chatID, "⚠️ Неверное значение места (place).")
-		return
-	}
-
 	// Получаем текущее состояние
 	state, exists := b.StateManager.GetState(userID)
 	if !exists || state.State != "add_result_discipline" {
@@ -1287,14 +1332,25 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// disciplineName := parts[1] // We actually get the discipline from state
+	fieldSize := b.getRaceFieldSize(state.ContextData["race_id"].(int))
+	place, status, ok := parsePlaceValue(parts[2], fieldSize)
+	if !ok {
+		b.sendMessage(chatID, "⚠️ Неверное значение места (place).")
+		return
+	}
+
 	// --- Logic copied and adapted from handleResultDiscipline ---
 	disciplines := state.ContextData["disciplines"].([]string)
 	currentIdx := state.ContextData["current_idx"].(int)
 	results := state.ContextData["results"].(map[string]int)
+	times := stateTimes(state)
+	statuses := stateStatuses(state)
 
-	// Сохраняем результат текущей дисциплины
+	// Сохраняем результат текущей дисциплины (место или статус выбраны кнопкой, время не указано)
 	currentDiscipline := disciplines[currentIdx]
-	results[currentDiscipline] = place
+	models.SetDisciplineResult(results, statuses, currentDiscipline, place, status)
+	delete(times, currentDiscipline)
 
 	// Переходим к следующей дисциплине или завершаем
 	currentIdx++
@@ -1309,15 +1365,17 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 			"disciplines": disciplines,
 			"current_idx": currentIdx,
 			"results":     results,
+			"times":       times,
+			"statuses":    statuses,
 		})
 
 		// Запрашиваем результат следующей дисциплины by editing the message
 		nextDisciplineName := disciplines[currentIdx]
-		keyboard := PlacesKeyboard(nextDisciplineName)
+		keyboard := PlacesKeyboard(nextDisciplineName, fieldSize)
 		b.editMessageWithKeyboard( // EDIT instead of send
 			chatID,
 			messageID, // Edit the existing message
-			fmt.Sprintf("Выберите ваше место в дисциплине '%s':", nextDisciplineName),
+			fmt.Sprintf("Выберите ваше место в дисциплине '%s':%s", nextDisciplineName, disciplineTimeHint(nextDisciplineName)),
 			keyboard,
 		)
 	} else {
@@ -1335,21 +1393,11 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 			return
 		}
 
-		// Calculate total score
-		totalScore := 0
-		for _, place := range results {
-			switch place {
-			case 1:
-				totalScore += 3
-			case 2:
-				totalScore += 2
-			case 3:
-				totalScore += 1
-			}
-		}
+		raceID := state.ContextData["race_id"].(int)
+		rules := b.getScoringRules(raceID)
 
 		// Check if driver used reroll for this race
-		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(state.ContextData["race_id"].(int), driver.ID)
+		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(raceID, driver.ID)
 		if err != nil {
 			log.Printf("Ошибка проверки статуса реролла: %v", err)
 			rerollUsed = false // Assume not used if error
@@ -1358,27 +1406,33 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 		// Apply reroll penalty if used
 		rerollPenalty := 0
 		if rerollUsed {
-			rerollPenalty = 1
-			totalScore -= rerollPenalty
+			rerollPenalty = rules.RerollPenalty
 		}
 
+		// Calculate total score by season rules
+		totalScore := rules.CalculateTotalScore(results, statuses, rerollPenalty)
+
 		// Create race result
 		result := &models.RaceResult{
-			RaceID:        state.ContextData["race_id"].(int),
-			DriverID:      driver.ID,
-			CarNumber:     state.ContextData["car_number"].(int),
-			CarName:       state.ContextData["car_name"].(string),
-			CarPhotoURL:   state.ContextData["car_photo"].(string),
-			Results:       results,
-			TotalScore:    totalScore,
-			RerollPenalty: rerollPenalty,
+			RaceID:             state.ContextData["race_id"].(int),
+			DriverID:           driver.ID,
+			CarNumber:          state.ContextData["car_number"].(int),
+			CarName:            state.ContextData["car_name"].(string),
+			CarPhotoURL:        state.ContextData["car_photo"].(string),
+			Results:            results,
+			TotalScore:         totalScore,
+			RerollPenalty:      rerollPenalty,
+			Times:              times,
+			Statuses:           statuses,
+			ConfirmationStatus: models.ResultStatusPending,
 		}
 
 		// Save result to DB
+		var resultID int
 		if rerollPenalty > 0 {
-			_, err = b.ResultRepo.CreateWithRerollPenalty(result)
+			resultID, err = b.ResultRepo.CreateWithRerollPenalty(result)
 		} else {
-			_, err = b.ResultRepo.Create(result)
+			resultID, err = b.ResultRepo.Create(result)
 		}
 
 		if err != nil {
@@ -1387,13 +1441,16 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 			return
 		}
 
+		// Places in timed and judged disciplines are derived from the times and votes of all drivers
+		totalScore = b.finalizeResultPlaces(resultID, result)
+
 		// Clear state
 		b.StateManager.ClearState(userID)
 
 		// Format success message with penalties
 		successMsg := fmt.Sprintf("✅ Результаты успешно сохранены!")
 		if rerollPenalty > 0 {
-			successMsg += fmt.Sprintf("\n\n⚠️ Учтен штраф -%d балл за реролл машины.", rerollPenalty)
+			successMsg += fmt.Sprintf("\n\n⚠️ Учтен штраф -%d %s за реролл машины.", rerollPenalty, pointsWord(rerollPenalty))
 		}
 		successMsg += fmt.Sprintf("\n\nВы набрали %d очков в этой гонке.", totalScore)
 
@@ -1402,6 +1459,9 @@ func (b *Bot) callbackPlace(query *tgbotapi.CallbackQuery) {
 
 		// Show race results in a new message
 		b.showRaceResults(chatID, result.RaceID)
+
+		// Offer screenshots and queue the result for confirmation
+		b.onResultSubmitted(chatID, resultID)
 	}
 }
 
@@ -1432,6 +1492,7 @@ func (b *Bot) showRaceResults(chatID int64, raceID int) {
 	text := fmt.Sprintf("🏁 *%s*\n\n", race.Name)
 	text += fmt.Sprintf("📅 %s\n", b.formatDate(race.Date))
 	text += fmt.Sprintf("🚗 Класс: %s\n", race.CarClass)
+	text += b.formatRaceDivision(race.ID)
 	text += fmt.Sprintf("🏎️ Дисциплины: %s\n\n", strings.Join(race.Disciplines, ", "))
 
 	// Add race state
@@ -1444,20 +1505,28 @@ func (b *Bot) showRaceResults(chatID int64, raceID int) {
 		text += "✅ *Статус: Завершена*\n\n"
 	}
 
+	penalties, err := b.PenaltyRepo.GetByRaceID(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов гонки: %v", err)
+	}
+
+	teams := b.seasonDriverTeams(race.SeasonID)
+	pendingCount := 0
+
 	if len(results) == 0 {
 		text += "Пока нет результатов для этой гонки."
 	} else {
 		// Format results table
 		for i, result := range results {
-			text += fmt.Sprintf("*%d. %s* (%s)\n", i+1, result.DriverName, result.CarName)
+			text += fmt.Sprintf("*%d. %s*%s (%s)\n", i+1, result.DriverName, teamBadge(teams, result.DriverID), result.CarName)
 			text += fmt.Sprintf("🔢 Номер: %d\n", result.CarNumber)
 
 			// Add discipline results
 			var placesText []string
 			for _, discipline := range race.Disciplines {
 				place := result.Results[discipline]
-				emoji := getPlaceEmoji(place)
-				placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, getPlaceText(place)))
+				emoji := getResultEmoji(place, result.Statuses[discipline])
+				placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, formatDisciplineResult(&result.RaceResult, discipline)))
 			}
 
 			text += fmt.Sprintf("📊 %s\n", strings.Join(placesText, " | "))
@@ -1467,13 +1536,33 @@ func (b *Bot) showRaceResults(chatID int64, raceID int) {
 				text += fmt.Sprintf("⚠️ Штраф за реролл: -%d\n", result.RerollPenalty)
 			}
 
-			text += fmt.Sprintf("🏆 Всего очков: %d\n\n", result.TotalScore)
+			// Add steward penalties with reasons
+			text += formatPenalties(penalties[result.ID], "")
+
+			text += fmt.Sprintf("🏆 Всего очков: %d\n", result.TotalScore)
+
+			if !result.IsConfirmed() {
+				pendingCount++
+				text += "⏳ Ожидает подтверждения\n"
+			}
+
+			text += "\n"
 		}
 	}
 
 	// Create keyboard for race based on state
 	var keyboard [][]tgbotapi.InlineKeyboardButton
 
+	// Pending results can be confirmed by admins and other participants
+	if pendingCount > 0 {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("⏳ Подтвердить результаты (%d)", pendingCount),
+				fmt.Sprintf("race_confirmations:%d", raceID),
+			),
+		))
+	}
+
 	// Add buttons based on race state
 	switch race.State {
 	case models.RaceStateNotStarted:
@@ -1508,6 +1597,26 @@ func (b *Bot) showRaceResults(chatID int64, raceID int) {
 				fmt.Sprintf("view_race_cars:%d", raceID),
 			),
 		))
+
+		// Add ballot buttons for judged disciplines
+		for _, discipline := range judgedDisciplines(race) {
+			keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					fmt.Sprintf("🗳 Голосовать: %s", discipline),
+					fmt.Sprintf("vote:%d:%s", raceID, discipline),
+				),
+			))
+		}
+	case models.RaceStateCompleted:
+		// Add vote tally button for judged disciplines
+		if len(judgedDisciplines(race)) > 0 {
+			keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"🗳 Итоги голосования",
+					fmt.Sprintf("vote_status:%d", raceID),
+				),
+			))
+		}
 	}
 
 	// Add buttons common for all states
@@ -1655,9 +1764,9 @@ func (b *Bot) showRaceProgress(chatID int64, raceID int) {
 				// Add discipline results
 				var placesText []string
 				for _, discipline := range race.Disciplines {
-					place := result.Results[discipline]
-					emoji := getPlaceEmoji(place)
-					placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, getPlaceText(place)))
+					place, status := result.Results[discipline], result.Statuses[discipline]
+					emoji := getResultEmoji(place, status)
+					placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, getResultText(place, status)))
 				}
 
 				text += fmt.Sprintf("📊 %s\n", strings.Join(placesText, " | "))
@@ -1770,15 +1879,24 @@ func (b *Bot) callbackAdminEditResult(query *tgbotapi.CallbackQuery) {
 
 	text += "*Текущие результаты:*\n"
 	for _, discipline := range race.Disciplines {
-		place := result.Results[discipline]
-		emoji := getPlaceEmoji(place)
-		text += fmt.Sprintf("• %s %s: %s\n", emoji, discipline, getPlaceText(place))
+		place, status := result.Results[discipline], result.Statuses[discipline]
+		emoji := getResultEmoji(place, status)
+		text += fmt.Sprintf("• %s %s: %s\n", emoji, discipline, getResultText(place, status))
 	}
 
 	if result.RerollPenalty > 0 {
 		text += fmt.Sprintf("\n⚠️ Штраф за реролл: -%d\n", result.RerollPenalty)
 	}
 
+	penalties, err := b.PenaltyRepo.GetByResultID(resultID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов: %v", err)
+	}
+
+	if len(penalties) > 0 {
+		text += "\n" + formatPenalties(penalties, "")
+	}
+
 	text += fmt.Sprintf("\n🏆 Всего очков: %d\n\n", result.TotalScore)
 	text += "Выберите дисциплину для редактирования:"
 
@@ -1788,7 +1906,7 @@ func (b *Bot) callbackAdminEditResult(query *tgbotapi.CallbackQuery) {
 	for _, discipline := range race.Disciplines {
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				fmt.Sprintf("%s %s", getPlaceEmoji(result.Results[discipline]), discipline),
+				fmt.Sprintf("%s %s", getResultEmoji(result.Results[discipline], result.Statuses[discipline]), discipline),
 				fmt.Sprintf("admin_edit_discipline:%d:%s", resultID, discipline),
 			),
 		))
@@ -1807,6 +1925,14 @@ func (b *Bot) callbackAdminEditResult(query *tgbotapi.CallbackQuery) {
 		),
 	))
 
+	// Add steward penalties button
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("⚖️ Штрафы (%d)", len(penalties)),
+			fmt.Sprintf("penalties:%d", resultID),
+		),
+	))
+
 	// Add save/back buttons
 	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
@@ -1861,31 +1987,10 @@ func (b *Bot) callbackAdminEditDiscipline(query *tgbotapi.CallbackQuery) {
 	// Show place selection keyboard for this discipline
 	text := fmt.Sprintf("Выберите место для дисциплины '%s':", disciplineName)
 
-	// Create keyboard with place options
-	var keyboard [][]tgbotapi.InlineKeyboardButton
-
-	// Place options row
-	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(
-			"🥇 1 место",
-			fmt.Sprintf("admin_set_place:%d:%s:1", resultID, disciplineName),
-		),
-		tgbotapi.NewInlineKeyboardButtonData(
-			"🥈 2 место",
-			fmt.Sprintf("admin_set_place:%d:%s:2", resultID, disciplineName),
-		),
-		tgbotapi.NewInlineKeyboardButtonData(
-			"🥉 3 место",
-			fmt.Sprintf("admin_set_place:%d:%s:3", resultID, disciplineName),
-		),
-	))
-
-	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(
-			"❌ Не участвовал",
-			fmt.Sprintf("admin_set_place:%d:%s:0", resultID, disciplineName),
-		),
-	))
+	// Create keyboard with place options for the whole field
+	keyboard := PlaceButtons(b.getRaceFieldSize(result.RaceID), func(value string) string {
+		return fmt.Sprintf("admin_set_place:%d:%s:%s", resultID, disciplineName, value)
+	})
 
 	// Back button
 	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
@@ -1924,12 +2029,6 @@ func (b *Bot) callbackAdminSetPlace(query *tgbotapi.CallbackQuery) {
 
 	disciplineName := parts[2]
 
-	place, err := strconv.Atoi(parts[3])
-	if err != nil || place < 0 || place > 3 {
-		b.answerCallbackQuery(query.ID, "⚠️ Неверное значение места", true)
-		return
-	}
-
 	// Get the result
 	result, err := b.ResultRepo.GetByID(resultID)
 	if err != nil {
@@ -1943,28 +2042,23 @@ func (b *Bot) callbackAdminSetPlace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
-	// Update the place for this discipline
-	result.Results[disciplineName] = place
-
-	// Recalculate total score
-	totalScore := 0
-	for _, p := range result.Results {
-		switch p {
-		case 1:
-			totalScore += 3
-		case 2:
-			totalScore += 2
-		case 3:
-			totalScore += 1
-		}
+	place, status, ok := parsePlaceValue(parts[3], b.getRaceFieldSize(result.RaceID))
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверное значение места", true)
+		return
 	}
 
-	// Apply reroll penalty if it exists
-	if result.RerollPenalty > 0 {
-		totalScore -= result.RerollPenalty
-	}
+	// Update the place or status for this discipline
+	models.SetDisciplineResult(result.Results, result.Statuses, disciplineName, place, status)
 
-	result.TotalScore = totalScore
+	// Recalculate total score by season rules, keeping the stored reroll and steward penalties
+	rules := b.getScoringRules(result.RaceID)
+	result.TotalScore, err = b.scoreWithPenalties(rules, result)
+	if err != nil {
+		log.Printf("Ошибка расчета очков результата %d: %v", result.ID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Не удалось пересчитать очки, результат не сохранен", true)
+		return
+	}
 
 	// Save the updated result
 	err = b.ResultRepo.Update(result)
@@ -1974,6 +2068,16 @@ func (b *Bot) callbackAdminSetPlace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// Steward position penalties shift neighbours, so the other results of the race are rescored too
+	if err := b.ResultRepo.RescoreRace(result.RaceID, rules); err != nil {
+		log.Printf("Ошибка пересчета очков гонки %d: %v", result.RaceID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Результат сохранен, но очки остальных гонщиков не пересчитаны", true)
+		return
+	}
+
+	// Places of a completed race feed the Elo rating and standings snapshots
+	b.recalculateRatingsForRace(result.RaceID)
+
 	b.answerCallbackQuery(query.ID, "✅ Результат обновлен!", false)
 
 	// Show the edit result screen again
@@ -2020,13 +2124,23 @@ func (b *Bot) callbackAdminToggleReroll(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
-	// Toggle reroll penalty (1 <-> 0)
+	// Toggle reroll penalty (season penalty <-> 0)
+	rules := b.getScoringRules(result.RaceID)
+	if result.RerollPenalty == 0 && rules.RerollPenalty == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ В этом сезоне штраф за реролл не начисляется", true)
+		return
+	}
+
 	if result.RerollPenalty > 0 {
 		result.RerollPenalty = 0
-		result.TotalScore += 1 // Remove penalty
 	} else {
-		result.RerollPenalty = 1
-		result.TotalScore -= 1 // Apply penalty
+		result.RerollPenalty = rules.RerollPenalty
+	}
+	result.TotalScore, err = b.scoreWithPenalties(rules, result)
+	if err != nil {
+		log.Printf("Ошибка расчета очков результата %d: %v", result.ID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Не удалось пересчитать очки, результат не сохранен", true)
+		return
 	}
 
 	// Save the updated result
@@ -2037,6 +2151,9 @@ func (b *Bot) callbackAdminToggleReroll(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// The total score of a completed race feeds the standings snapshots
+	b.recalculateRatingsForRace(result.RaceID)
+
 	// Get the appropriate message
 	message := "✅ Штраф за реролл добавлен!"
 	if result.RerollPenalty == 0 {
@@ -2123,6 +2240,21 @@ func (b *Bot) callbackRegisterRace(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// На гонку дивизиона могут зарегистрироваться только гонщики этого дивизиона
+	refusal, err := b.checkRaceDivision(race, driver.ID)
+	if err != nil {
+		log.Printf("Ошибка проверки дивизиона гонки: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при проверке дивизиона", true)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при проверке дивизиона гонки. Пожалуйста, попробуйте снова.")
+		return
+	}
+
+	if refusal != "" {
+		b.answerCallbackQuery(query.ID, "⛔ Гонка проводится для другого дивизиона", true)
+		b.sendMessage(chatID, refusal)
+		return
+	}
+
 	// Регистрируем гонщика на гонку
 	err = b.RaceRepo.RegisterDriver(raceID, driver.ID)
 	if err != nil {
@@ -2477,40 +2609,29 @@ func (b *Bot) callbackSelectDiscipline(query *tgbotapi.CallbackQuery) {
 
 	disciplineName := parts[2]
 
+	if models.IsJudgedDiscipline(disciplineName) {
+		b.answerCallbackQuery(query.ID, fmt.Sprintf("🗳 Места в дисциплине '%s' определяются голосованием участников (/vote)", disciplineName), true)
+		return
+	}
+
 	driver, err := b.DriverRepo.GetByTelegramID(userID)
 	if err != nil || driver == nil {
 		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения данных гонщика", true)
 		return
 	}
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥇 1 место",
-				fmt.Sprintf("set_place:%d:%s:1", raceID, disciplineName),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥈 2 место",
-				fmt.Sprintf("set_place:%d:%s:2", raceID, disciplineName),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥉 3 место",
-				fmt.Sprintf("set_place:%d:%s:3", raceID, disciplineName),
-			),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"❌ Не участвовал",
-				fmt.Sprintf("set_place:%d:%s:0", raceID, disciplineName),
-			),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🔙 Назад к выбору дисциплины",
-				fmt.Sprintf("add_result:%d", raceID),
-			),
+	rows := PlaceButtons(b.getRaceFieldSize(raceID), func(value string) string {
+		return fmt.Sprintf("set_place:%d:%s:%s", raceID, disciplineName, value)
+	})
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🔙 Назад к выбору дисциплины",
+			fmt.Sprintf("add_result:%d", raceID),
 		),
-	)
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
 	// Отправляем сообщение с клавиатурой
 	b.editMessageWithKeyboard(
@@ -2540,8 +2661,26 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 
 	disciplineName := parts[2]
 
-	place, err := strconv.Atoi(parts[3])
-	if err != nil || place < 0 || place > 3 {
+	if models.IsJudgedDiscipline(disciplineName) {
+		b.answerCallbackQuery(query.ID, fmt.Sprintf("🗳 Места в дисциплине '%s' определяются голосованием участников (/vote)", disciplineName), true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения данных гонки", true)
+		return
+	}
+
+	// Гонщик вводит места только во время гонки: подтвержденный результат завершенной гонки
+	// уже учтен в таблице, и его исправляют администраторы через штрафы или /editresult
+	if race.State != models.RaceStateInProgress {
+		b.answerCallbackQuery(query.ID, "⚠️ Вносить и менять места можно только во время гонки. Исправления после завершения вносят администраторы", true)
+		return
+	}
+
+	place, status, ok := parsePlaceValue(parts[3], b.getRaceFieldSize(raceID))
+	if !ok {
 		b.answerCallbackQuery(query.ID, "⚠️ Неверное место", true)
 		return
 	}
@@ -2555,20 +2694,27 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 
 	// Проверяем, есть ли уже результаты по этой гонке
 	var resultID int
-	var resultsJSON string
+	var resultsJSON, statusesJSON string
 	var totalScore int
 	var rerollPenalty int
 
 	err = b.db.QueryRow(`
-        SELECT id, results, total_score, reroll_penalty 
+        SELECT id, results, statuses, total_score, reroll_penalty 
         FROM race_results 
         WHERE race_id = $1 AND driver_id = $2
-    `, raceID, driver.ID).Scan(&resultID, &resultsJSON, &totalScore, &rerollPenalty)
+    `, raceID, driver.ID).Scan(&resultID, &resultsJSON, &statusesJSON, &totalScore, &rerollPenalty)
 
 	var results map[string]int
+	statuses := make(map[string]string)
+	var assignment *models.RaceCarAssignment
+	rules := b.getScoringRules(raceID)
+	resultExists := err == nil
 
-	if err == nil {
+	if resultExists {
 		err = json.Unmarshal([]byte(resultsJSON), &results)
+		if err == nil {
+			statuses, err = models.DeserializeStatuses(statusesJSON)
+		}
 		if err != nil {
 			b.answerCallbackQuery(query.ID, "⚠️ Ошибка разбора результатов", true)
 			return
@@ -2578,7 +2724,7 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 		results = make(map[string]int)
 
 		// Получаем информацию о машине
-		assignment, err := b.CarRepo.GetDriverCarAssignment(raceID, driver.ID)
+		assignment, err = b.CarRepo.GetDriverCarAssignment(raceID, driver.ID)
 		if err != nil || assignment == nil {
 			b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения данных о машине", true)
 			return
@@ -2587,36 +2733,79 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 		// Проверяем статус реролла
 		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(raceID, driver.ID)
 		if err == nil && rerollUsed {
-			rerollPenalty = 1
+			rerollPenalty = rules.RerollPenalty
 		}
 	}
 
-	results[disciplineName] = place
-
-	totalScore = 0
-	for _, p := range results {
-		switch p {
-		case 1:
-			totalScore += 3
-		case 2:
-			totalScore += 2
-		case 3:
-			totalScore += 1
-		}
+	models.SetDisciplineResult(results, statuses, disciplineName, place, status)
+	totalScore, err = b.scoreWithPenalties(rules, &models.RaceResult{
+		ID:            resultID,
+		RaceID:        raceID,
+		Results:       results,
+		Statuses:      statuses,
+		RerollPenalty: rerollPenalty,
+	})
+	if err != nil {
+		log.Printf("Ошибка расчета очков результата: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка расчета очков, результат не сохранен", true)
+		return
 	}
 
-	if rerollPenalty > 0 {
-		totalScore -= rerollPenalty
+	// Сохраняем результат
+	if resultExists {
+		resultsData, err := models.SerializeResults(results)
+		var statusesData string
+		if err == nil {
+			statusesData, err = models.SerializeStatuses(statuses)
+		}
+		if err == nil {
+			_, err = b.db.Exec(`
+				UPDATE race_results
+				SET results = $1, statuses = $2, total_score = $3
+				WHERE id = $4
+			`, resultsData, statusesData, totalScore, resultID)
+		}
+		if err == nil {
+			// Измененный результат требует повторного подтверждения
+			err = b.ResultRepo.MarkPending(resultID)
+		}
+		if err != nil {
+			log.Printf("Ошибка обновления результата: %v", err)
+			b.answerCallbackQuery(query.ID, "⚠️ Ошибка сохранения результата", true)
+			return
+		}
+	} else {
+		resultID, err = b.ResultRepo.CreateWithRerollPenalty(&models.RaceResult{
+			RaceID:             raceID,
+			DriverID:           driver.ID,
+			CarNumber:          assignment.AssignmentNumber,
+			CarName:            assignment.Car.Name + " (" + assignment.Car.Year + ")",
+			CarPhotoURL:        assignment.Car.ImageURL,
+			Results:            results,
+			Statuses:           statuses,
+			TotalScore:         totalScore,
+			RerollPenalty:      rerollPenalty,
+			ConfirmationStatus: models.ResultStatusPending,
+		})
+		if err != nil {
+			log.Printf("Ошибка создания результата: %v", err)
+			b.answerCallbackQuery(query.ID, "⚠️ Ошибка сохранения результата", true)
+			return
+		}
 	}
 
-	race, err := b.RaceRepo.GetByID(raceID)
-	if err != nil || race == nil {
-		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения данных гонки", true)
+	// Штрафы на позиции сдвигают соседей, поэтому очки остальных результатов гонки тоже пересчитываются
+	if err := b.ResultRepo.RescoreRace(raceID, rules); err != nil {
+		log.Printf("Ошибка пересчета очков гонки %d: %v", raceID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Результат сохранен, но очки остальных гонщиков не пересчитаны", true)
 		return
 	}
 
+	// Судейские дисциплины заполнит голосование, поэтому полнота считается по остальным
+	disciplines := selfReportedDisciplines(race.Disciplines)
+
 	allDisciplinesFilled := true
-	for _, d := range race.Disciplines {
+	for _, d := range disciplines {
 		if _, exists := results[d]; !exists {
 			allDisciplinesFilled = false
 			break
@@ -2628,9 +2817,8 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 
 		// Показываем места по дисциплинам
 		text += "*Ваши места:*\n"
-		for _, discipline := range race.Disciplines {
-			place := results[discipline]
-			emoji := getPlaceEmoji(place)
+		for _, discipline := range disciplines {
+			emoji := getResultEmoji(results[discipline], statuses[discipline])
 			text += fmt.Sprintf("• %s: %s\n", discipline, emoji)
 		}
 
@@ -2657,9 +2845,10 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 		)
 
 		b.editMessageWithKeyboard(chatID, messageID, text, keyboard)
+		b.onResultSubmitted(chatID, resultID)
 	} else {
 		var remainingDisciplines []string
-		for _, d := range race.Disciplines {
+		for _, d := range disciplines {
 			if _, exists := results[d]; !exists {
 				remainingDisciplines = append(remainingDisciplines, d)
 			}
@@ -2669,7 +2858,7 @@ func (b *Bot) callbackSetPlace(query *tgbotapi.CallbackQuery) {
 		text += "*Заполненные дисциплины:*\n"
 
 		for d, p := range results {
-			emoji := getPlaceEmoji(p)
+			emoji := getResultEmoji(p, statuses[d])
 			text += fmt.Sprintf("• %s: %s\n", d, emoji)
 		}
 
@@ -2771,6 +2960,8 @@ func (b *Bot) showUniversalRaceCard(chatID int64, raceID int, userID int64) {
 	// Добавляем основную информацию
 	text += fmt.Sprintf("📅 Дата: %s\n", b.formatDate(race.Date))
 	text += fmt.Sprintf("🚗 Класс: %s\n", race.CarClass)
+	text += b.formatRacePool(race)
+	text += b.formatRaceDivision(race.ID)
 	text += fmt.Sprintf("🏎️ Дисциплины: %s\n\n", strings.Join(race.Disciplines, ", "))
 
 	// Информация о статусе регистрации пользователя
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"github.com/athebyme/forza-top-gear-bot/internal/parser"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -90,7 +91,7 @@ func (b *Bot) callbackCarClassAll(query *tgbotapi.CallbackQuery) {
 	// Формируем полный список, но с ограничением на длину сообщения
 	var carLines []string
 	for i, car := range cars {
-		line := fmt.Sprintf("%d. *%s (%d)* - %d CR\n", i+1, car.Name, car.Year, car.Price)
+		line := fmt.Sprintf("%d. *%s (%s)* - %d CR\n", i+1, car.Name, car.Year, car.Price)
 		carLines = append(carLines, line)
 	}
 
@@ -165,17 +166,7 @@ func (b *Bot) callbackRandomCar(query *tgbotapi.CallbackQuery) {
 	car := cars[rand.Intn(len(cars))]
 
 	// Формируем сообщение с информацией о машине
-	text := fmt.Sprintf("🚗 *%s (%d)*\n\n", car.Name, car.Year)
-	text += fmt.Sprintf("💰 Цена: %d CR\n", car.Price)
-	text += fmt.Sprintf("⭐ Редкость: %s\n\n", car.Rarity)
-	text += "*Характеристики:*\n"
-	text += fmt.Sprintf("🏁 Скорость: %.1f/10\n", car.Speed)
-	text += fmt.Sprintf("🔄 Управление: %.1f/10\n", car.Handling)
-	text += fmt.Sprintf("⚡ Ускорение: %.1f/10\n", car.Acceleration)
-	text += fmt.Sprintf("🚦 Старт: %.1f/10\n", car.Launch)
-	text += fmt.Sprintf("🛑 Торможение: %.1f/10\n\n", car.Braking)
-	text += fmt.Sprintf("🏆 Класс: %s %d\n", car.ClassLetter, car.ClassNumber)
-	text += fmt.Sprintf("📍 Источник: %s", car.Source)
+	text := formatCarCard(car)
 
 	// Создаем клавиатуру для дополнительных действий
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
@@ -194,11 +185,7 @@ func (b *Bot) callbackRandomCar(query *tgbotapi.CallbackQuery) {
 	)
 
 	// Отправляем сообщение с клавиатурой и изображением, если оно есть
-	if car.ImageURL != "" {
-		b.sendPhotoWithKeyboard(chatID, car.ImageURL, text, keyboard)
-	} else {
-		b.sendMessageWithKeyboard(chatID, text, keyboard)
-	}
+	b.sendCarCard(chatID, car, text, keyboard)
 
 	// Удаляем сообщение с кнопкой
 	b.deleteMessage(chatID, query.Message.MessageID)
@@ -218,12 +205,24 @@ func (b *Bot) callbackUpdateCarsDB(query *tgbotapi.CallbackQuery) {
 	// Отправляем уведомление о начале обновления
 	b.sendMessage(chatID, "🔄 Запуск обновления базы машин. Это может занять некоторое время...")
 
-	// В реальном приложении здесь был бы запуск парсера или обращение к API
-	// Для демонстрации просто делаем задержку
-	time.Sleep(3 * time.Second)
+	cars, err := parser.LoadCars(b.Config.Cars.Source)
+	if err != nil {
+		log.Printf("Ошибка загрузки каталога машин: %v", err)
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Не удалось загрузить каталог машин: %v", err))
+		return
+	}
+
+	report, err := b.CarRepo.Import(cars, true, b.Config.Cars.Source, userID)
+	if err != nil {
+		log.Printf("Ошибка импорта каталога машин: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении каталога машин.")
+		return
+	}
 
-	// Отправляем уведомление об успешном обновлении
-	b.sendMessage(chatID, "✅ База машин успешно обновлена!")
+	log.Printf("Каталог машин обновлен пользователем %d: версия %d", userID, report.VersionID)
+
+	// Отправляем итоги обновления с журналом изменений
+	b.sendCarImportReport(chatID, report)
 
 	// Показываем обновленную статистику
 	message := tgbotapi.Message{
@@ -237,6 +236,35 @@ func (b *Bot) callbackUpdateCarsDB(query *tgbotapi.CallbackQuery) {
 	b.deleteMessage(chatID, query.Message.MessageID)
 }
 
+// importCarsIfEmpty загружает каталог машин при запуске, если база машин пуста
+func (b *Bot) importCarsIfEmpty() {
+	counts, err := b.CarRepo.GetClassCounts()
+	if err != nil {
+		log.Printf("Ошибка проверки базы машин: %v", err)
+		return
+	}
+
+	if len(counts) > 0 {
+		return
+	}
+
+	log.Printf("База машин пуста, загружаем каталог из %s", b.Config.Cars.Source)
+
+	cars, err := parser.LoadCars(b.Config.Cars.Source)
+	if err != nil {
+		log.Printf("Ошибка загрузки каталога машин: %v", err)
+		return
+	}
+
+	report, err := b.CarRepo.Import(cars, true, b.Config.Cars.Source, 0)
+	if err != nil {
+		log.Printf("Ошибка импорта каталога машин: %v", err)
+		return
+	}
+
+	log.Printf("Каталог машин загружен: добавлено %d машин", report.Added)
+}
+
 // callbackRaceAssignCars обрабатывает запрос на назначение машин для гонки
 func (b *Bot) callbackRaceAssignCars(query *tgbotapi.CallbackQuery) {
 	chatID := query.Message.Chat.ID
@@ -1191,6 +1219,21 @@ func (b *Bot) callbackCompleteRaceConfirm(query *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// Block completion while places conflict
+	conflicts, err := b.findRaceConflicts(race)
+	if err != nil {
+		log.Printf("Ошибка проверки конфликтов гонки: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при проверке результатов", true)
+		return
+	}
+
+	if len(conflicts) > 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ Найдены конфликты мест, гонку нельзя завершить", true)
+		b.showRaceConflicts(chatID, race)
+		b.deleteMessage(chatID, query.Message.MessageID)
+		return
+	}
+
 	// Start a database transaction
 	tx, err := b.db.Begin()
 	if err != nil {
@@ -1218,6 +1261,18 @@ func (b *Bot) callbackCompleteRaceConfirm(query *tgbotapi.CallbackQuery) {
 
 	b.answerCallbackQuery(query.ID, "✅ Гонка успешно завершена!", false)
 
+	// Update Elo ratings with the completed race
+	b.recalculateRatings()
+
+	// Save the standings after the completed race
+	b.rebuildStandings()
+
+	// Award achievements for the completed race
+	go b.evaluateRaceAchievements(raceID)
+
+	// Check whether the leader has clinched the season title
+	go b.checkTitleClinch(raceID)
+
 	// Send success message
 	b.sendMessage(chatID, fmt.Sprintf("✅ Гонка '%s' успешно завершена! Участникам отправлены уведомления с результатами.", race.Name))
 
@@ -1295,6 +1350,7 @@ func (b *Bot) showRaceDetails(chatID int64, raceID int, userID int64) {
 	// Добавляем основную информацию
 	text += fmt.Sprintf("📅 Дата: %s\n", b.formatDate(race.Date))
 	text += fmt.Sprintf("🚗 Класс: %s\n", race.CarClass)
+	text += b.formatRacePool(race)
 	text += fmt.Sprintf("🏎️ Дисциплины: %s\n\n", strings.Join(race.Disciplines, ", "))
 
 	// Информация о статусе регистрации пользователя
@@ -1586,6 +1642,7 @@ func (b *Bot) showAdminRacePanel(chatID int64, raceID int) {
 	text := fmt.Sprintf("⚙️ *Админ-панель гонки: %s*\n\n", race.Name)
 	text += fmt.Sprintf("📅 Дата: %s\n", b.formatDate(race.Date))
 	text += fmt.Sprintf("🚗 Класс: %s\n", race.CarClass)
+	text += b.formatRacePool(race)
 	text += fmt.Sprintf("🏎️ Дисциплины: %s\n", strings.Join(race.Disciplines, ", "))
 	text += fmt.Sprintf("🏆 Статус: %s\n\n", getStatusText(race.State))
 
@@ -1776,7 +1833,8 @@ func (b *Bot) callbackRerollCar(query *tgbotapi.CallbackQuery) {
 	log.Printf("📌 callbackRerollCar: Флаг реролла установлен для гонщика %d в гонке %d", driver.ID, raceID)
 
 	// Применяем штраф реролла к результатам (если результаты уже существуют)
-	err = b.ResultRepo.ApplyRerollPenaltyToResult(tx, raceID, driver.ID, 1)
+	rerollPenalty := b.getScoringRules(raceID).RerollPenalty
+	err = b.ResultRepo.ApplyRerollPenaltyToResult(tx, raceID, driver.ID, rerollPenalty)
 	if err != nil {
 		log.Printf("⚠️ callbackRerollCar: Предупреждение при применении штрафа: %v (игнорируется, если результаты еще не добавлены)", err)
 		// Не делаем rollback, это нормальная ситуация если результатов еще нет
@@ -1821,7 +1879,8 @@ func (b *Bot) callbackRerollCar(query *tgbotapi.CallbackQuery) {
 	notificationText := fmt.Sprintf("🎲 *Реролл выполнен успешно!*\n\n")
 	notificationText += fmt.Sprintf("Ваша предыдущая машина: %s\n", oldCarName)
 	notificationText += fmt.Sprintf("Ваша новая машина: %s\n\n", carAssignment.Car.Name)
-	notificationText += "⚠️ За реролл будет применен штраф -1 балл к вашему итоговому результату.\n\n"
+	notificationText += fmt.Sprintf("⚠️ За реролл будет применен штраф -%d %s к вашему итоговому результату.\n\n",
+		rerollPenalty, pointsWord(rerollPenalty))
 	notificationText += "Сейчас будет отправлена подробная информация о новой машине..."
 
 	b.sendMessage(chatID, notificationText)
@@ -1840,7 +1899,8 @@ func (b *Bot) callbackRerollCar(query *tgbotapi.CallbackQuery) {
 	text += fmt.Sprintf("🚦 Старт: %.1f/10\n", car.Launch)
 	text += fmt.Sprintf("🛑 Торможение: %.1f/10\n\n", car.Braking)
 	text += fmt.Sprintf("🏆 Класс: %s %d\n\n", car.ClassLetter, car.ClassNumber)
-	text += "⚠️ *Вы использовали свой реролл в этой гонке. -1 балл будет вычтен из вашего итогового результата.*\n\n"
+	text += fmt.Sprintf("⚠️ *Вы использовали свой реролл в этой гонке. -%d %s будет вычтено из вашего итогового результата.*\n\n",
+		rerollPenalty, pointsWord(rerollPenalty))
 	text += "✅ *Машина автоматически подтверждена!*"
 
 	// Добавляем клавиатуру для возврата к гонке
@@ -1894,7 +1954,8 @@ func (b *Bot) notifyAdminsAboutReroll(raceID int, driverID int, newCarName strin
 	text := fmt.Sprintf("🎲 *Реролл машины:* Гонщик *%s* использовал реролл в гонке '%s'.\n",
 		driverName, race.Name)
 	text += fmt.Sprintf("Новая машина: *%s*\n", newCarName)
-	text += "Штраф -1 балл будет применен к результату."
+	penalty := b.getScoringRules(raceID).RerollPenalty
+	text += fmt.Sprintf("Штраф -%d %s будет применен к результату.", penalty, pointsWord(penalty))
 
 	// Отправляем уведомления всем админам
 	for adminID := range b.AdminIDs {
@@ -0,0 +1,610 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Поля пула машин, которые вводятся текстом
+const (
+	carPoolFieldYears   = "years"
+	carPoolFieldPrice   = "price"
+	carPoolFieldStats   = "stats"
+	carPoolFieldInclude = "include"
+	carPoolFieldExclude = "exclude"
+)
+
+// carPoolPrompts - подсказки для ввода полей пула
+var carPoolPrompts = map[string]string{
+	carPoolFieldYears:   "📅 Введите годы выпуска: 1990-2005, 1990- или -2005.",
+	carPoolFieldPrice:   "💰 Введите максимальную цену машины в CR.",
+	carPoolFieldStats:   "📊 Введите ограничения характеристик, по одному на строку:\nскорость 6-8\nторможение 5-\nстарт -7\n\nХарактеристики: Скорость, Управление, Ускорение, Старт, Торможение.",
+	carPoolFieldInclude: "🏭 Введите производителей, машины которых можно выдавать, через запятую: ferrari, porsche, aston martin.",
+	carPoolFieldExclude: "🚷 Введите производителей, машины которых выдавать нельзя, через запятую: ferrari, porsche.",
+}
+
+// registerCarPoolHandlers регистрирует обработчики пула машин гонки
+func (b *Bot) registerCarPoolHandlers() {
+	b.CallbackHandlers["car_pool"] = b.callbackCarPool
+	b.CallbackHandlers["car_pool_edit"] = b.callbackCarPoolEdit
+	b.CallbackHandlers["car_pool_rarity"] = b.callbackCarPoolRarity
+	b.CallbackHandlers["car_pool_rarity_toggle"] = b.callbackCarPoolRarityToggle
+	b.CallbackHandlers["car_pool_ban"] = b.callbackCarPoolBan
+	b.CallbackHandlers["car_pool_ban_pick"] = b.callbackCarPoolBanPick
+	b.CallbackHandlers["car_pool_unban"] = b.callbackCarPoolUnban
+	b.CallbackHandlers["car_pool_reset"] = b.callbackCarPoolReset
+}
+
+// formatRacePool возвращает строку с размером пула машин для карточки гонки
+func (b *Bot) formatRacePool(race *models.Race) string {
+	cars, err := b.CarRepo.GetByClass(race.CarClass)
+	if err != nil {
+		log.Printf("Ошибка получения машин класса %s: %v", race.CarClass, err)
+		return ""
+	}
+
+	pool, err := b.CarRepo.GetRacePool(race.ID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин гонки %d: %v", race.ID, err)
+		return ""
+	}
+
+	if pool.IsEmpty() {
+		return fmt.Sprintf("🎯 Пул машин: %d\n", len(cars))
+	}
+
+	return fmt.Sprintf("🎯 Пул машин: %d из %d (с фильтрами)\n", len(pool.Filter(cars)), len(cars))
+}
+
+// buildCarPoolView формирует экран пула машин гонки с кнопками настройки
+func (b *Bot) buildCarPoolView(raceID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if race == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("гонка %d не найдена", raceID)
+	}
+
+	cars, err := b.CarRepo.GetByClass(race.CarClass)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	pool, err := b.CarRepo.GetRacePool(raceID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	registrations, err := b.RaceRepo.GetRegisteredDrivers(raceID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	poolSize := len(pool.Filter(cars))
+
+	text := fmt.Sprintf("🎯 *Пул машин гонки: %s*\n\n", race.Name)
+	text += fmt.Sprintf("🚗 Класс: %s\n", race.CarClass)
+
+	if lines := pool.Describe(); len(lines) > 0 {
+		for _, line := range lines {
+			text += markdownStripper.Replace(line) + "\n"
+		}
+	} else {
+		text += "Фильтров нет: выдаются все машины класса.\n"
+	}
+
+	text += fmt.Sprintf("\n🚘 Машин в пуле: *%d* из %d\n", poolSize, len(cars))
+	if poolSize == 0 {
+		text += "⚠️ В пуле нет ни одной машины, выдать машины не получится.\n"
+	} else if poolSize < len(registrations) {
+		text += fmt.Sprintf("⚠️ Машин меньше, чем зарегистрированных гонщиков (%d): некоторые машины достанутся нескольким гонщикам.\n",
+			len(registrations))
+	}
+	text += "\nМашины выдаются и перевыдаются при реролле только из пула."
+
+	keyboard := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📅 Годы", fmt.Sprintf("car_pool_edit:%d:%s", raceID, carPoolFieldYears)),
+			tgbotapi.NewInlineKeyboardButtonData("💰 Цена", fmt.Sprintf("car_pool_edit:%d:%s", raceID, carPoolFieldPrice)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⭐ Редкость", fmt.Sprintf("car_pool_rarity:%d", raceID)),
+			tgbotapi.NewInlineKeyboardButtonData("📊 Характеристики", fmt.Sprintf("car_pool_edit:%d:%s", raceID, carPoolFieldStats)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🏭 Производители", fmt.Sprintf("car_pool_edit:%d:%s", raceID, carPoolFieldInclude)),
+			tgbotapi.NewInlineKeyboardButtonData("🚷 Исключить", fmt.Sprintf("car_pool_edit:%d:%s", raceID, carPoolFieldExclude)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🚫 Запретить машину", fmt.Sprintf("car_pool_ban:%d", raceID)),
+		),
+	}
+
+	for _, ban := range pool.Banned {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"♻️ Разрешить "+ban.Title,
+				fmt.Sprintf("car_pool_unban:%d:%d", raceID, ban.CarID),
+			),
+		))
+	}
+
+	if !pool.IsEmpty() {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Сбросить фильтры", fmt.Sprintf("car_pool_reset:%d", raceID)),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад к панели гонки", fmt.Sprintf("admin_race_panel:%d", raceID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// sendCarPoolView отправляет экран пула машин гонки
+func (b *Bot) sendCarPoolView(chatID int64, raceID int) {
+	text, keyboard, err := b.buildCarPoolView(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении пула машин.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// carPoolRace проверяет права администратора и возвращает гонку, пул которой можно менять.
+// При отказе отвечает на запрос и возвращает nil.
+func (b *Bot) carPoolRace(query *tgbotapi.CallbackQuery) *models.Race {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут настраивать пул машин", true)
+		return nil
+	}
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", false)
+		return nil
+	}
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return nil
+	}
+
+	if race.State == models.RaceStateCompleted {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка уже завершена", true)
+		return nil
+	}
+
+	return race
+}
+
+// checkCarPoolSize проверяет, что в пуле останется хотя бы одна машина. Маленький пул допустим:
+// машины выдаются по кругу и могут повторяться, как в гонках на одинаковых машинах.
+// Возвращает текст отказа или пустую строку.
+func (b *Bot) checkCarPoolSize(raceID int, pool *models.CarPool) (string, error) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil {
+		return "", err
+	}
+
+	if race == nil {
+		return "", fmt.Errorf("гонка %d не найдена", raceID)
+	}
+
+	cars, err := b.CarRepo.GetByClass(race.CarClass)
+	if err != nil {
+		return "", err
+	}
+
+	if len(pool.Filter(cars)) == 0 {
+		return "⚠️ С такими фильтрами в пуле не останется ни одной машины.", nil
+	}
+
+	return "", nil
+}
+
+// allowCarPoolSize проверяет размер пула перед сохранением из кнопки. При отказе отвечает на запрос.
+func (b *Bot) allowCarPoolSize(query *tgbotapi.CallbackQuery, raceID int, pool *models.CarPool) bool {
+	shortage, err := b.checkCarPoolSize(raceID, pool)
+	if err != nil {
+		log.Printf("Ошибка проверки пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка проверки пула машин", true)
+		return false
+	}
+
+	if shortage != "" {
+		b.answerCallbackQuery(query.ID, shortage, true)
+		return false
+	}
+
+	return true
+}
+
+// callbackCarPool показывает пул машин гонки (car_pool:raceID)
+func (b *Bot) callbackCarPool(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	text, keyboard, err := b.buildCarPoolView(race.ID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения пула машин", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendMessageWithKeyboard(query.Message.Chat.ID, text, keyboard)
+	b.deleteMessage(query.Message.Chat.ID, query.Message.MessageID)
+}
+
+// callbackCarPoolEdit запрашивает значение поля пула (car_pool_edit:raceID:field)
+func (b *Bot) callbackCarPoolEdit(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", false)
+		return
+	}
+
+	field := parts[2]
+	prompt, exists := carPoolPrompts[field]
+	if !exists {
+		b.answerCallbackQuery(query.ID, "⚠️ Неизвестный фильтр", false)
+		return
+	}
+
+	b.StateManager.SetState(query.From.ID, "car_pool_input", map[string]interface{}{
+		"race_id": race.ID,
+		"field":   field,
+	})
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendMessage(query.Message.Chat.ID, prompt+"\n\nОтправьте «-», чтобы снять ограничение, или /cancel для отмены.")
+}
+
+// handleCarPoolInput обрабатывает ввод значения фильтра пула
+func (b *Bot) handleCarPoolInput(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	raceID := state.ContextData["race_id"].(int)
+	field := state.ContextData["field"].(string)
+
+	pool, err := b.CarRepo.GetRacePool(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении пула машин.")
+		return
+	}
+
+	text := strings.TrimSpace(message.Text)
+	reset := text == "-"
+
+	switch field {
+	case carPoolFieldYears:
+		pool.YearFrom, pool.YearTo = 0, 0
+		if !reset {
+			pool.YearFrom, pool.YearTo, err = models.ParseYearRange(text)
+		}
+	case carPoolFieldPrice:
+		pool.MaxPrice = 0
+		if !reset {
+			pool.MaxPrice, err = strconv.Atoi(strings.Join(strings.Fields(text), ""))
+			if err == nil && pool.MaxPrice <= 0 {
+				err = fmt.Errorf("цена должна быть больше нуля")
+			}
+		}
+	case carPoolFieldStats:
+		pool.Stats = nil
+		if !reset {
+			pool.Stats, err = models.ParseCarStatRanges(text)
+		}
+	case carPoolFieldInclude:
+		pool.IncludeMakers = nil
+		if !reset {
+			pool.IncludeMakers = models.ParseMakers(text)
+		}
+	case carPoolFieldExclude:
+		pool.ExcludeMakers = nil
+		if !reset {
+			pool.ExcludeMakers = models.ParseMakers(text)
+		}
+	}
+
+	if err != nil {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ %s\n\nПопробуйте еще раз или используйте /cancel для отмены.",
+			markdownStripper.Replace(err.Error())))
+		return
+	}
+
+	shortage, err := b.checkCarPoolSize(raceID, pool)
+	if err != nil {
+		log.Printf("Ошибка проверки пула машин: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при проверке пула машин.")
+		return
+	}
+
+	if shortage != "" {
+		b.sendMessage(chatID, shortage+"\n\nПопробуйте еще раз или используйте /cancel для отмены.")
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	if err := b.CarRepo.SaveRacePool(raceID, pool); err != nil {
+		log.Printf("Ошибка сохранения пула машин: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении пула машин.")
+		return
+	}
+
+	log.Printf("Администратор %d изменил фильтр %s пула машин гонки %d", userID, field, raceID)
+
+	b.sendCarPoolView(chatID, raceID)
+}
+
+// buildCarPoolRarityView формирует список редкостей класса с отметками разрешенных
+func (b *Bot) buildCarPoolRarityView(race *models.Race) (string, tgbotapi.InlineKeyboardMarkup, []string, error) {
+	cars, err := b.CarRepo.GetByClass(race.CarClass)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, nil, err
+	}
+
+	pool, err := b.CarRepo.GetRacePool(race.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, car := range cars {
+		counts[car.Rarity]++
+	}
+
+	rarities := make([]string, 0, len(counts))
+	for rarity := range counts {
+		rarities = append(rarities, rarity)
+	}
+	sort.Strings(rarities)
+
+	allowed := make(map[string]bool)
+	for _, rarity := range pool.Rarities {
+		allowed[strings.ToLower(rarity)] = true
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for i, rarity := range rarities {
+		mark := "▫️"
+		if allowed[strings.ToLower(rarity)] {
+			mark = "✅"
+		}
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s (%d)", mark, rarity, counts[rarity]),
+				fmt.Sprintf("car_pool_rarity_toggle:%d:%d", race.ID, i),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад к пулу машин", fmt.Sprintf("car_pool:%d", race.ID)),
+	))
+
+	text := fmt.Sprintf("⭐ *Редкость машин класса %s*\n\n", race.CarClass)
+	text += "Отметьте редкости, машины которых можно выдавать. Если не отмечено ничего, подходит любая редкость."
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), rarities, nil
+}
+
+// callbackCarPoolRarity показывает выбор редкостей пула (car_pool_rarity:raceID)
+func (b *Bot) callbackCarPoolRarity(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	text, keyboard, _, err := b.buildCarPoolRarityView(race)
+	if err != nil {
+		log.Printf("Ошибка получения редкостей машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения редкостей", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackCarPoolRarityToggle разрешает или запрещает редкость (car_pool_rarity_toggle:raceID:index)
+func (b *Bot) callbackCarPoolRarityToggle(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	index, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", false)
+		return
+	}
+
+	_, _, rarities, err := b.buildCarPoolRarityView(race)
+	if err != nil || index < 0 || index >= len(rarities) {
+		b.answerCallbackQuery(query.ID, "⚠️ Редкость не найдена", true)
+		return
+	}
+
+	pool, err := b.CarRepo.GetRacePool(race.ID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения пула машин", true)
+		return
+	}
+
+	pool.ToggleRarity(rarities[index])
+
+	if !b.allowCarPoolSize(query, race.ID, pool) {
+		return
+	}
+
+	if err := b.CarRepo.SaveRacePool(race.ID, pool); err != nil {
+		log.Printf("Ошибка сохранения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка сохранения пула машин", true)
+		return
+	}
+
+	text, keyboard, _, err := b.buildCarPoolRarityView(race)
+	if err != nil {
+		log.Printf("Ошибка получения редкостей машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения редкостей", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// askCarPoolBanQuery запрашивает машину для бана в пуле гонки
+func (b *Bot) askCarPoolBanQuery(chatID, userID int64, raceID int, carClass string) {
+	b.StateManager.SetState(userID, "car_pool_ban_search", map[string]interface{}{
+		"race_id":   raceID,
+		"car_class": carClass,
+	})
+
+	b.sendMessage(chatID, fmt.Sprintf("🚫 Введите название машины класса %s, которую нужно запретить (можно с годом и с опечатками):", carClass))
+}
+
+// callbackCarPoolBan начинает поиск машины для бана (car_pool_ban:raceID)
+func (b *Bot) callbackCarPoolBan(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.askCarPoolBanQuery(query.Message.Chat.ID, query.From.ID, race.ID, race.CarClass)
+}
+
+// callbackCarPoolBanPick запрещает найденную машину в гонке (car_pool_ban_pick:carID)
+func (b *Bot) callbackCarPoolBanPick(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут настраивать пул машин", true)
+		return
+	}
+
+	carID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID машины", false)
+		return
+	}
+
+	search, exists := b.CarSearches.Get(userID)
+	if !exists || search.Mode != carSearchPoolBan {
+		b.answerCallbackQuery(query.ID, "⚠️ Поиск устарел, начните заново из пула машин", true)
+		return
+	}
+
+	car, err := b.CarRepo.GetByID(carID)
+	if err != nil || car == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Машина не найдена", true)
+		return
+	}
+
+	pool, err := b.CarRepo.GetRacePool(search.RaceID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения пула машин", true)
+		return
+	}
+
+	pool.Ban(car)
+
+	if !b.allowCarPoolSize(query, search.RaceID, pool) {
+		return
+	}
+
+	if err := b.CarRepo.SaveRacePool(search.RaceID, pool); err != nil {
+		log.Printf("Ошибка сохранения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка сохранения пула машин", true)
+		return
+	}
+
+	log.Printf("Администратор %d запретил машину %d в гонке %d", userID, car.ID, search.RaceID)
+
+	b.CarSearches.Clear(userID)
+	b.answerCallbackQuery(query.ID, "🚫 Машина запрещена", false)
+	b.deleteMessage(chatID, query.Message.MessageID)
+	b.sendCarPoolView(chatID, search.RaceID)
+}
+
+// callbackCarPoolUnban убирает машину из бан-листа (car_pool_unban:raceID:carID)
+func (b *Bot) callbackCarPoolUnban(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	carID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID машины", false)
+		return
+	}
+
+	pool, err := b.CarRepo.GetRacePool(race.ID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения пула машин", true)
+		return
+	}
+
+	pool.Unban(carID)
+	b.saveCarPoolAndRefresh(query, race.ID, pool, "♻️ Машина снова в пуле")
+}
+
+// callbackCarPoolReset снимает все фильтры пула (car_pool_reset:raceID)
+func (b *Bot) callbackCarPoolReset(query *tgbotapi.CallbackQuery) {
+	race := b.carPoolRace(query)
+	if race == nil {
+		return
+	}
+
+	b.saveCarPoolAndRefresh(query, race.ID, &models.CarPool{}, "🗑 Фильтры сброшены")
+}
+
+// saveCarPoolAndRefresh сохраняет пул и обновляет экран пула в том же сообщении
+func (b *Bot) saveCarPoolAndRefresh(query *tgbotapi.CallbackQuery, raceID int, pool *models.CarPool, notice string) {
+	if err := b.CarRepo.SaveRacePool(raceID, pool); err != nil {
+		log.Printf("Ошибка сохранения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка сохранения пула машин", true)
+		return
+	}
+
+	text, keyboard, err := b.buildCarPoolView(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения пула машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения пула машин", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, notice, false)
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
@@ -0,0 +1,481 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Режимы поиска машины: просмотр, замена машины гонщика и бан машины в пуле гонки
+const (
+	carSearchBrowse   = ""
+	carSearchOverride = "override"
+	carSearchPoolBan  = "pool_ban"
+)
+
+// carSearch - последний поиск машины пользователя. В режимах замены и бана найденную
+// машину можно назначить гонщику или запретить в гонке RaceID.
+type carSearch struct {
+	Query      string
+	Results    []*models.CarSearchResult
+	Mode       string
+	CarClass   string // если задан, ищутся только машины этого класса
+	RaceID     int
+	DriverID   int
+	DriverName string
+}
+
+// CarSearchStore хранит результаты поиска машин, чтобы листать их кнопками:
+// запрос целиком не помещается в данные кнопки
+type CarSearchStore struct {
+	searches map[int64]*carSearch
+	mu       sync.RWMutex
+}
+
+// NewCarSearchStore создает хранилище поисков машин
+func NewCarSearchStore() *CarSearchStore {
+	return &CarSearchStore{
+		searches: make(map[int64]*carSearch),
+	}
+}
+
+// Set сохраняет поиск пользователя
+func (s *CarSearchStore) Set(userID int64, search *carSearch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.searches[userID] = search
+}
+
+// Get возвращает последний поиск пользователя
+func (s *CarSearchStore) Get(userID int64) (*carSearch, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	search, exists := s.searches[userID]
+	return search, exists
+}
+
+// Clear удаляет поиск пользователя
+func (s *CarSearchStore) Clear(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.searches, userID)
+}
+
+// markdownStripper убирает из пользовательского текста символы разметки Markdown
+var markdownStripper = strings.NewReplacer("*", "", "_", "", "`", "", "[", "", "]", "")
+
+// registerCarSearchHandlers регистрирует обработчики поиска машин
+func (b *Bot) registerCarSearchHandlers() {
+	b.CommandHandlers["car"] = b.handleCarSearch
+
+	b.CallbackHandlers["car_search_page"] = b.callbackCarSearchPage
+	b.CallbackHandlers["car_search_new"] = b.callbackCarSearchNew
+	b.CallbackHandlers["car_override"] = b.callbackCarOverride
+	b.CallbackHandlers["car_override_driver"] = b.callbackCarOverrideDriver
+	b.CallbackHandlers["car_override_pick"] = b.callbackCarOverridePick
+}
+
+// formatCarCard формирует карточку машины с характеристиками
+func formatCarCard(car *models.Car) string {
+	text := fmt.Sprintf("🚗 *%s (%s)*\n\n", car.Name, car.Year)
+	text += fmt.Sprintf("💰 Цена: %d CR\n", car.Price)
+	text += fmt.Sprintf("⭐ Редкость: %s\n\n", car.Rarity)
+	text += "*Характеристики:*\n"
+	text += fmt.Sprintf("🏁 Скорость: %.1f/10\n", car.Speed)
+	text += fmt.Sprintf("🔄 Управление: %.1f/10\n", car.Handling)
+	text += fmt.Sprintf("⚡ Ускорение: %.1f/10\n", car.Acceleration)
+	text += fmt.Sprintf("🚦 Старт: %.1f/10\n", car.Launch)
+	text += fmt.Sprintf("🛑 Торможение: %.1f/10\n\n", car.Braking)
+	text += fmt.Sprintf("🏆 Класс: %s, PI %d\n", car.ClassLetter, car.ClassNumber)
+	text += fmt.Sprintf("📍 Источник: %s", car.Source)
+
+	return text
+}
+
+// sendCarCard отправляет карточку машины с картинкой, если она есть
+func (b *Bot) sendCarCard(chatID int64, car *models.Car, text string, keyboard tgbotapi.InlineKeyboardMarkup) {
+	if car.ImageURL != "" {
+		b.sendPhotoWithKeyboard(chatID, car.ImageURL, text, keyboard)
+	} else {
+		b.sendMessageWithKeyboard(chatID, text, keyboard)
+	}
+}
+
+// handleCarSearch обрабатывает команду /car <запрос>. Команда не сбрасывает текущий диалог,
+// поэтому машину можно найти посреди любого сценария.
+func (b *Bot) handleCarSearch(message *tgbotapi.Message) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	query := strings.TrimSpace(message.CommandArguments())
+	if query != "" {
+		b.runCarSearch(chatID, userID, &carSearch{Query: query})
+		return
+	}
+
+	if _, exists := b.StateManager.GetState(userID); exists {
+		b.sendMessage(chatID, "🔎 Укажите запрос после команды, например: /car ferrari 2015")
+		return
+	}
+
+	b.StateManager.SetState(userID, "car_search", map[string]interface{}{})
+	b.sendMessage(chatID, "🔎 Введите название машины, можно с годом и с опечатками (например: lamborgini huracan 2015):")
+}
+
+// handleCarSearchQuery обрабатывает ввод запроса в диалоге поиска машины
+func (b *Bot) handleCarSearchQuery(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	query := strings.TrimSpace(message.Text)
+	if query == "" {
+		b.sendMessage(chatID, "⚠️ Введите название машины или используйте /cancel для отмены.")
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	search := &carSearch{Query: query}
+	switch state.State {
+	case "car_override_search":
+		search.Mode = carSearchOverride
+		search.RaceID = state.ContextData["race_id"].(int)
+		search.DriverID = state.ContextData["driver_id"].(int)
+		search.DriverName = state.ContextData["driver_name"].(string)
+	case "car_pool_ban_search":
+		search.Mode = carSearchPoolBan
+		search.RaceID = state.ContextData["race_id"].(int)
+		search.CarClass = state.ContextData["car_class"].(string)
+	}
+
+	b.runCarSearch(chatID, userID, search)
+}
+
+// runCarSearch ищет машины в каталоге и показывает первую карточку
+func (b *Bot) runCarSearch(chatID, userID int64, search *carSearch) {
+	var cars []*models.Car
+	var err error
+	if search.CarClass != "" {
+		cars, err = b.CarRepo.GetByClass(search.CarClass)
+	} else {
+		cars, err = b.CarRepo.GetAll()
+	}
+	if err != nil {
+		log.Printf("Ошибка получения машин для поиска: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при поиске машин.")
+		return
+	}
+
+	search.Results = models.SearchCars(cars, search.Query, models.CarSearchLimit)
+	b.CarSearches.Set(userID, search)
+
+	if len(search.Results) == 0 {
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🔎 Новый поиск", "car_search_new"),
+			),
+		)
+
+		b.sendMessageWithKeyboard(chatID,
+			fmt.Sprintf("🔍 По запросу «%s» ничего не найдено.", markdownStripper.Replace(search.Query)), keyboard)
+		return
+	}
+
+	b.sendCarSearchPage(chatID, search, 0)
+}
+
+// sendCarSearchPage отправляет карточку найденной машины с кнопками листания
+func (b *Bot) sendCarSearchPage(chatID int64, search *carSearch, page int) {
+	total := len(search.Results)
+	if page < 0 || page >= total {
+		page = 0
+	}
+
+	car := search.Results[page].Car
+
+	text := fmt.Sprintf("🔍 «%s»: %d из %d\n", markdownStripper.Replace(search.Query), page+1, total)
+	switch search.Mode {
+	case carSearchOverride:
+		text += fmt.Sprintf("🔧 Замена машины для гонщика *%s*\n", search.DriverName)
+	case carSearchPoolBan:
+		text += "🚫 Бан машины в пуле гонки\n"
+	}
+	text += "\n" + formatCarCard(car)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if total > 1 {
+		prev := (page - 1 + total) % total
+		next := (page + 1) % total
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("◀️", fmt.Sprintf("car_search_page:%d", prev)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", page+1, total), fmt.Sprintf("car_search_page:%d", page)),
+			tgbotapi.NewInlineKeyboardButtonData("▶️", fmt.Sprintf("car_search_page:%d", next)),
+		))
+	}
+
+	switch search.Mode {
+	case carSearchOverride:
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("✅ Назначить гонщику %s", search.DriverName),
+				fmt.Sprintf("car_override_pick:%d", car.ID),
+			),
+		))
+	case carSearchPoolBan:
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🚫 Запретить в гонке",
+				fmt.Sprintf("car_pool_ban_pick:%d", car.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔎 Новый поиск", "car_search_new"),
+	))
+
+	switch search.Mode {
+	case carSearchOverride:
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔙 Назад к панели гонки",
+				fmt.Sprintf("admin_race_panel:%d", search.RaceID),
+			),
+		))
+	case carSearchPoolBan:
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔙 Назад к пулу машин",
+				fmt.Sprintf("car_pool:%d", search.RaceID),
+			),
+		))
+	}
+
+	b.sendCarCard(chatID, car, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackCarSearchPage листает результаты поиска (car_search_page:page)
+func (b *Bot) callbackCarSearchPage(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	page, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", false)
+		return
+	}
+
+	search, exists := b.CarSearches.Get(query.From.ID)
+	if !exists || len(search.Results) == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ Поиск устарел, повторите /car", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendCarSearchPage(chatID, search, page)
+	b.deleteMessage(chatID, query.Message.MessageID)
+}
+
+// callbackCarSearchNew запрашивает новый запрос, сохраняя гонку и гонщика для замены машины
+func (b *Bot) callbackCarSearchNew(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	b.answerCallbackQuery(query.ID, "", false)
+
+	if search, exists := b.CarSearches.Get(userID); exists && b.IsAdmin(userID) {
+		switch search.Mode {
+		case carSearchOverride:
+			b.askCarOverrideQuery(chatID, userID, search.RaceID, search.DriverID, search.DriverName)
+			return
+		case carSearchPoolBan:
+			b.askCarPoolBanQuery(chatID, userID, search.RaceID, search.CarClass)
+			return
+		}
+	}
+
+	b.StateManager.SetState(userID, "car_search", map[string]interface{}{})
+	b.sendMessage(chatID, "🔎 Введите название машины, можно с годом и с опечатками:")
+}
+
+// askCarOverrideQuery запрашивает у администратора машину для гонщика
+func (b *Bot) askCarOverrideQuery(chatID, userID int64, raceID, driverID int, driverName string) {
+	b.StateManager.SetState(userID, "car_override_search", map[string]interface{}{
+		"race_id":     raceID,
+		"driver_id":   driverID,
+		"driver_name": driverName,
+	})
+
+	b.sendMessage(chatID, fmt.Sprintf("🔎 Введите название машины для гонщика *%s* (можно с годом и с опечатками):", driverName))
+}
+
+// callbackCarOverride показывает гонщиков гонки для замены машины (car_override:raceID)
+func (b *Bot) callbackCarOverride(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут менять машины", true)
+		return
+	}
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", false)
+		return
+	}
+
+	registrations, err := b.RaceRepo.GetRegisteredDrivers(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения зарегистрированных гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка получения участников", true)
+		return
+	}
+
+	if len(registrations) == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ На гонку никто не зарегистрирован", true)
+		return
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, reg := range registrations {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				reg.DriverName,
+				fmt.Sprintf("car_override_driver:%d:%d", raceID, reg.DriverID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("admin_race_panel:%d", raceID)),
+	))
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID,
+		"🔧 *Замена машины*\n\nВыберите гонщика:", tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackCarOverrideDriver запрашивает машину для выбранного гонщика (car_override_driver:raceID:driverID)
+func (b *Bot) callbackCarOverrideDriver(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут менять машины", true)
+		return
+	}
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", false)
+		return
+	}
+
+	driverID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", false)
+		return
+	}
+
+	driver, err := b.DriverRepo.GetByID(driverID)
+	if err != nil || driver == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонщик не найден", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.deleteMessage(chatID, query.Message.MessageID)
+
+	if assignment, err := b.CarRepo.GetDriverCarAssignment(raceID, driverID); err == nil && assignment != nil {
+		b.sendMessage(chatID, fmt.Sprintf("🚗 Сейчас у гонщика *%s*: %s (%s)", driver.Name, assignment.Car.Name, assignment.Car.Year))
+	}
+
+	b.askCarOverrideQuery(chatID, userID, raceID, driverID, driver.Name)
+}
+
+// callbackCarOverridePick назначает гонщику найденную машину (car_override_pick:carID)
+func (b *Bot) callbackCarOverridePick(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут менять машины", true)
+		return
+	}
+
+	carID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID машины", false)
+		return
+	}
+
+	search, exists := b.CarSearches.Get(userID)
+	if !exists || search.Mode != carSearchOverride {
+		b.answerCallbackQuery(query.ID, "⚠️ Поиск устарел, начните замену заново", true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(search.RaceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	if race.State == models.RaceStateCompleted {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка уже завершена", true)
+		return
+	}
+
+	car, err := b.CarRepo.GetByID(carID)
+	if err != nil || car == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Машина не найдена", true)
+		return
+	}
+
+	if err := b.CarRepo.OverrideCarForDriver(race.ID, search.DriverID, car.ID); err != nil {
+		log.Printf("Ошибка замены машины: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Ошибка замены машины", true)
+		return
+	}
+
+	log.Printf("Администратор %d назначил гонщику %d машину %d в гонке %d", userID, search.DriverID, car.ID, race.ID)
+
+	b.CarSearches.Clear(userID)
+	b.answerCallbackQuery(query.ID, "✅ Машина назначена", false)
+	b.deleteMessage(chatID, query.Message.MessageID)
+
+	b.sendMessage(chatID, fmt.Sprintf("✅ Гонщику *%s* назначена машина %s (%s).", search.DriverName, car.Name, car.Year))
+	b.notifyDriverAboutCarOverride(race, search.DriverID, car)
+	b.showAdminRacePanel(chatID, race.ID)
+}
+
+// notifyDriverAboutCarOverride сообщает гонщику о машине, назначенной администратором
+func (b *Bot) notifyDriverAboutCarOverride(race *models.Race, driverID int, car *models.Car) {
+	driver, err := b.DriverRepo.GetByID(driverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика %d для уведомления: %v", driverID, err)
+		return
+	}
+
+	text := fmt.Sprintf("🔧 *Администратор заменил вашу машину в гонке '%s'*\n\n", race.Name)
+	text += formatCarCard(car)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"✅ Подтвердить выбор машины",
+				fmt.Sprintf("confirm_car:%d", race.ID),
+			),
+		),
+	)
+
+	b.sendCarCard(driver.TelegramID, car, text, keyboard)
+}
@@ -0,0 +1,325 @@
+package telegram
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"github.com/athebyme/forza-top-gear-bot/internal/parser"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxCatalogFileSize - наибольший размер файла каталога машин, который принимает бот
+const maxCatalogFileSize = 5 << 20
+
+// maxCatalogRowErrors - сколько ошибок строк показывать в ответе на файл
+const maxCatalogRowErrors = 20
+
+// Сколько изменений машин показывать в сообщении и сколько версий в истории каталога
+const (
+	carImportChangesPreview = 15
+	carVersionChangesLimit  = 30
+	carVersionsLimit        = 10
+)
+
+// registerCarCatalogHandlers регистрирует обработчики импорта и экспорта каталога машин
+func (b *Bot) registerCarCatalogHandlers() {
+	b.CallbackHandlers["cars_export"] = b.callbackCarsExport
+	b.CallbackHandlers["cars_import"] = b.callbackCarsImport
+	b.CallbackHandlers["car_versions"] = b.callbackCarVersions
+	b.CallbackHandlers["car_version"] = b.callbackCarVersion
+	b.CallbackHandlers["car_version_file"] = b.callbackCarVersionFile
+}
+
+// formatCarChanges форматирует журнал изменений машин блоком кода (названия машин не ломают разметку)
+func formatCarChanges(changes []*models.CarChange, limit int) string {
+	if len(changes) == 0 {
+		return "Машины не менялись.\n"
+	}
+
+	text := "```\n"
+	for i, change := range changes {
+		if i == limit {
+			text += fmt.Sprintf("...и еще %d\n", len(changes)-limit)
+			break
+		}
+		text += strings.ReplaceAll(change.String(), "`", "'") + "\n"
+	}
+	text += "```\n"
+
+	return text
+}
+
+// formatCatalogCounts форматирует итоги версии каталога
+func formatCatalogCounts(total, added, updated, removed, unchanged int) string {
+	text := fmt.Sprintf("Машин в источнике: %d\n", total)
+	text += fmt.Sprintf("➕ Добавлено: %d\n", added)
+	text += fmt.Sprintf("✏️ Изменено: %d\n", updated)
+	text += fmt.Sprintf("➖ Выведено из каталога: %d\n", removed)
+	text += fmt.Sprintf("Без изменений: %d\n", unchanged)
+	return text
+}
+
+// sendCarImportReport отправляет администратору итоги импорта с журналом изменений
+func (b *Bot) sendCarImportReport(chatID int64, report *models.CarImportReport) {
+	text := fmt.Sprintf("✅ *Каталог машин обновлен: версия #%d*\n\n", report.VersionID)
+	text += formatCatalogCounts(report.Total, report.Added, report.Updated, report.Removed, report.Unchanged)
+	if report.Skipped > 0 {
+		text += fmt.Sprintf("Повторы в источнике пропущены: %d\n", report.Skipped)
+	}
+
+	text += "\n*Изменения:*\n"
+	text += formatCarChanges(report.Changes, carImportChangesPreview)
+	text += "\nМашины, которые уже выдавались на гонки, при изменении сохраняются как были: " +
+		"прошлые гонки показывают те же класс и характеристики."
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📜 Журнал версии", fmt.Sprintf("car_version:%d", report.VersionID)),
+			tgbotapi.NewInlineKeyboardButtonData("🗂 История каталога", "car_versions"),
+		),
+	)
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackCarVersions показывает последние версии каталога машин
+func (b *Bot) callbackCarVersions(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ История каталога доступна только администраторам", true)
+		return
+	}
+
+	versions, err := b.CarRepo.GetCatalogVersions(carVersionsLimit)
+	if err != nil {
+		log.Printf("Ошибка получения версий каталога: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении истории каталога", true)
+		return
+	}
+
+	text := "🗂 *История каталога машин*\n\n"
+	if len(versions) == 0 {
+		text += "Каталог еще ни разу не импортировался."
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, version := range versions {
+		text += fmt.Sprintf("*#%d* %s — ➕%d ✏️%d ➖%d\n", version.ID, b.formatDate(version.CreatedAt),
+			version.Added, version.Updated, version.Removed)
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("#%d от %s", version.ID, b.formatDate(version.CreatedAt)),
+				fmt.Sprintf("car_version:%d", version.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🚗 К машинам", "cars"),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackCarVersion показывает журнал изменений версии каталога (car_version:versionID)
+func (b *Bot) callbackCarVersion(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ История каталога доступна только администраторам", true)
+		return
+	}
+
+	versionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат данных", true)
+		return
+	}
+
+	version, err := b.CarRepo.GetCatalogVersion(versionID)
+	if err != nil || version == nil {
+		log.Printf("Ошибка получения версии каталога %d: %v", versionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Версия каталога не найдена", true)
+		return
+	}
+
+	changes, err := b.CarRepo.GetCatalogChanges(versionID)
+	if err != nil {
+		log.Printf("Ошибка получения изменений каталога: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении журнала", true)
+		return
+	}
+
+	text := fmt.Sprintf("📜 *Версия каталога #%d* от %s\n", version.ID, version.CreatedAt.Format("02.01.2006 15:04"))
+	text += fmt.Sprintf("Источник: `%s`\n\n", strings.ReplaceAll(version.Source, "`", "'"))
+	text += formatCatalogCounts(version.Total, version.Added, version.Updated, version.Removed, version.Unchanged)
+	text += "\n" + formatCarChanges(changes, carVersionChangesLimit)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	if len(changes) > 0 {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📄 Полный журнал файлом", fmt.Sprintf("car_version_file:%d", version.ID)),
+		))
+	}
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К истории", "car_versions"),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackCarVersionFile отправляет полный журнал изменений версии каталога файлом (car_version_file:versionID)
+func (b *Bot) callbackCarVersionFile(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ История каталога доступна только администраторам", true)
+		return
+	}
+
+	versionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат данных", true)
+		return
+	}
+
+	changes, err := b.CarRepo.GetCatalogChanges(versionID)
+	if err != nil {
+		log.Printf("Ошибка получения изменений каталога: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении журнала", true)
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, change := range changes {
+		buf.WriteString(change.String())
+		buf.WriteString("\n")
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendDocument(query.Message.Chat.ID, fmt.Sprintf("cars_changelog_%d.txt", versionID), buf.Bytes(),
+		fmt.Sprintf("📜 Журнал версии каталога #%d: изменений %d", versionID, len(changes)))
+}
+
+// callbackCarsExport отправляет администратору каталог машин файлом (cars_export:format)
+func (b *Bot) callbackCarsExport(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут выгружать каталог машин", true)
+		return
+	}
+
+	format := parser.FormatCSV
+	if parts := strings.Split(query.Data, ":"); len(parts) > 1 && parts[1] == parser.FormatJSON {
+		format = parser.FormatJSON
+	}
+
+	cars, err := b.CarRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения машин для выгрузки: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении машин", true)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := parser.WriteCatalog(&buf, format, cars); err != nil {
+		log.Printf("Ошибка выгрузки каталога машин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при выгрузке каталога", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "📤 Выгружаем каталог...", false)
+
+	fileName := fmt.Sprintf("cars_%s.%s", time.Now().Format("2006-01-02"), format)
+	b.sendDocument(chatID, fileName, buf.Bytes(), fmt.Sprintf("🚗 Каталог машин: %d шт.", len(cars)))
+}
+
+// callbackCarsImport объясняет, как загрузить каталог машин из файла
+func (b *Bot) callbackCarsImport(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Только администраторы могут загружать каталог машин", true)
+		return
+	}
+
+	text := "📥 *Загрузка каталога машин*\n\n"
+	text += "Отправьте боту файл `.csv` или `.json`. Колонки и поля те же, что в выгрузке:\n"
+	text += fmt.Sprintf("`%s`\n\n", strings.Join(parser.CatalogColumns(), ", "))
+	text += "Машины сопоставляются по `id` (если он есть в базе), иначе по названию и году: " +
+		"новые добавляются, существующие обновляются, остальные машины не трогаются.\n"
+	text += "Если хотя бы в одной строке есть ошибка, каталог не меняется, а бот присылает список ошибок."
+
+	b.sendMessage(query.Message.Chat.ID, text)
+	b.answerCallbackQuery(query.ID, "", false)
+}
+
+// handleCarCatalogDocument загружает каталог машин из файла, присланного администратором
+func (b *Bot) handleCarCatalogDocument(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	document := message.Document
+
+	format, err := parser.FormatFromName(document.FileName)
+	if err != nil {
+		b.sendMessage(chatID, "⚠️ Каталог машин принимается только в файлах .csv и .json.")
+		return
+	}
+
+	if document.FileSize > maxCatalogFileSize {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Файл слишком большой: максимум %d МБ.", maxCatalogFileSize>>20))
+		return
+	}
+
+	fileURL, err := b.API.GetFileDirectURL(document.FileID)
+	if err != nil {
+		log.Printf("Ошибка получения ссылки на файл: %v", err)
+		b.sendMessage(chatID, "⚠️ Не удалось получить файл из Telegram.")
+		return
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(fileURL)
+	if err != nil {
+		log.Printf("Ошибка загрузки файла каталога: %v", err)
+		b.sendMessage(chatID, "⚠️ Не удалось скачать файл.")
+		return
+	}
+	defer resp.Body.Close()
+
+	cars, rowErrors, err := parser.ReadCatalog(resp.Body, format)
+	if err != nil {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Не удалось прочитать файл:\n```\n%v\n```", err))
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		text := fmt.Sprintf("⚠️ *В файле ошибок: %d.* Каталог не изменен.\n\n```\n", len(rowErrors))
+		for i, rowErr := range rowErrors {
+			if i == maxCatalogRowErrors {
+				text += fmt.Sprintf("...и еще %d\n", len(rowErrors)-maxCatalogRowErrors)
+				break
+			}
+			text += rowErr.Error() + "\n"
+		}
+		text += "```"
+
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	if len(cars) == 0 {
+		b.sendMessage(chatID, "⚠️ В файле нет ни одной машины.")
+		return
+	}
+
+	report, err := b.CarRepo.Import(cars, false, "файл "+document.FileName, message.From.ID)
+	if err != nil {
+		log.Printf("Ошибка импорта каталога машин из файла: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении каталога машин.")
+		return
+	}
+
+	log.Printf("Каталог машин загружен из файла %s пользователем %d: версия %d", document.FileName, message.From.ID, report.VersionID)
+
+	b.sendCarImportReport(chatID, report)
+}
@@ -77,7 +77,9 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 		Wins         int
 		SecondPlaces int
 		ThirdPlaces  int
-		BestRally    string
+		PlaceSum     int
+		Finishes     int
+		BestRally    int // лучшее время в Ралли, мс
 	}
 
 	var stats []driverStats
@@ -104,6 +106,11 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 
 		// Analyze results
 		for _, result := range results {
+			// Unconfirmed results are not counted in standings
+			if !result.IsConfirmed() {
+				continue
+			}
+
 			// Skip if filtering by season and this result is not from that season
 			if seasonID > 0 {
 				race, err := b.RaceRepo.GetByID(result.RaceID)
@@ -126,24 +133,18 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 				case 3:
 					ds.ThirdPlaces++
 				}
+
+				if place > 0 {
+					ds.PlaceSum += place
+					ds.Finishes++
+				}
 			}
 
-			// Check rally discipline if there is one
-			race, err := b.RaceRepo.GetByID(result.RaceID)
-			if err == nil && race != nil {
-				for _, discipline := range race.Disciplines {
-					// Check if this is a rally discipline and the driver participated
-					if strings.Contains(strings.ToLower(discipline), "ралли") {
-						place, exists := result.Results[discipline]
-						if exists && place > 0 {
-							// In a real implementation, you would get the actual rally time
-							rallyTime := "2:34.567" // Example time
-
-							// If no best rally yet, or this one is better
-							if ds.BestRally == "" || rallyTime < ds.BestRally {
-								ds.BestRally = rallyTime
-							}
-						}
+			// Check rally times if there are any
+			for discipline, rallyTime := range result.Times {
+				if strings.Contains(strings.ToLower(discipline), "ралли") {
+					if ds.BestRally == 0 || rallyTime < ds.BestRally {
+						ds.BestRally = rallyTime
 					}
 				}
 			}
@@ -155,11 +156,15 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 		}
 	}
 
-	// Sort by total score (descending)
+	// Sort by total score (descending), then by average finish position
 	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].TotalScore > stats[j].TotalScore
+		return models.StandingsLess(stats[i].TotalScore, stats[i].PlaceSum, stats[i].Finishes,
+			stats[j].TotalScore, stats[j].PlaceSum, stats[j].Finishes)
 	})
 
+	// Positions before the last completed race for movement arrows
+	previous := b.previousStandingsPositions(seasonID)
+
 	// Format the message
 	var title string
 	if seasonID == 0 {
@@ -180,20 +185,21 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 		text += "Нет данных для отображения."
 	} else {
 		// Add header row
-		text += "# | Гонщик | Очки | Гонки | 🥇 | 🥈 | 🥉\n"
-		text += "---|--------|------|-------|---|---|---\n"
+		text += "# | Гонщик | Очки | Гонки | 🥇 | 🥈 | 🥉 | Ср. место\n"
+		text += "---|--------|------|-------|---|---|---|---\n"
 
 		// Add driver rows
 		for i, s := range stats {
-			text += fmt.Sprintf("%d | *%s* | %d | %d | %d | %d | %d\n",
-				i+1, s.Name, s.TotalScore, s.Races, s.Wins, s.SecondPlaces, s.ThirdPlaces)
+			text += fmt.Sprintf("%d%s | *%s* | %d | %d | %d | %d | %d | %s\n",
+				i+1, formatPositionMove(previous, s.ID, i+1), s.Name, s.TotalScore, s.Races, s.Wins, s.SecondPlaces, s.ThirdPlaces,
+				formatAveragePlace(s.PlaceSum, s.Finishes))
 		}
 
 		// Add rally records section if any
 		var rallyRecords []string
 		for _, s := range stats {
-			if s.BestRally != "" {
-				rallyRecords = append(rallyRecords, fmt.Sprintf("• *%s*: %s", s.Name, s.BestRally))
+			if s.BestRally > 0 {
+				rallyRecords = append(rallyRecords, fmt.Sprintf("• *%s*: %s", s.Name, models.FormatLapTime(s.BestRally)))
 			}
 		}
 
@@ -234,6 +240,32 @@ func (b *Bot) showLeaderboard(chatID int64, seasonID int) {
 		))
 	}
 
+	// Standings history and discipline championships buttons
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"📋 История таблицы",
+			fmt.Sprintf("standings_season:%d", seasonID),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🎯 Зачеты дисциплин",
+			fmt.Sprintf("discipline_board:%d", seasonID),
+		),
+	))
+
+	// Teams and divisions are formed per season, so they are only available for a season
+	if seasonID > 0 {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"👥 Командный зачет",
+				fmt.Sprintf("teams:%d", seasonID),
+			),
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🏷 Дивизионы",
+				fmt.Sprintf("divisions:%d", seasonID),
+			),
+		))
+	}
+
 	// Back button
 	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
@@ -364,9 +396,11 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 	// Prepare driver stats map
 	driverStats := make(map[int]map[int]int) // driverID -> raceID -> score
 	driverTotalScores := make(map[int]int)   // driverID -> total score
+	driverPlaceSums := make(map[int]int)     // driverID -> sum of finishing positions
+	driverFinishes := make(map[int]int)      // driverID -> number of finishes
 
 	// Get rally records for each driver
-	driverRallyRecords := make(map[int]map[int]string) // driverID -> raceID -> rally time
+	driverRallyRecords := make(map[int]map[int]int) // driverID -> raceID -> rally time, ms
 
 	// For each completed race, get results
 	for _, race := range completedRaces {
@@ -377,6 +411,11 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 		}
 
 		for _, result := range results {
+			// Unconfirmed results are not counted in standings
+			if !result.IsConfirmed() {
+				continue
+			}
+
 			// Initialize driver's map if needed
 			if _, exists := driverStats[result.DriverID]; !exists {
 				driverStats[result.DriverID] = make(map[int]int)
@@ -388,16 +427,22 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 			// Add to total score
 			driverTotalScores[result.DriverID] += result.TotalScore
 
-			// Check for rally disciplines
-			for discipline, place := range result.Results {
-				if strings.Contains(strings.ToLower(discipline), "ралли") && place > 0 {
+			// Accumulate finishing positions for the average
+			for _, place := range result.Results {
+				if place > 0 {
+					driverPlaceSums[result.DriverID] += place
+					driverFinishes[result.DriverID]++
+				}
+			}
+
+			// Check for rally times
+			for discipline, rallyTime := range result.Times {
+				if strings.Contains(strings.ToLower(discipline), "ралли") {
 					if _, exists := driverRallyRecords[result.DriverID]; !exists {
-						driverRallyRecords[result.DriverID] = make(map[int]string)
+						driverRallyRecords[result.DriverID] = make(map[int]int)
 					}
 
-					// Store rally time (this is a placeholder - in a real implementation,
-					// you would get the actual time from the results)
-					driverRallyRecords[result.DriverID][race.ID] = "2:34.567" // Example time
+					driverRallyRecords[result.DriverID][race.ID] = rallyTime
 				}
 			}
 		}
@@ -433,7 +478,7 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 		for _, race := range completedRaces {
 			text += fmt.Sprintf("%s | ", race.Name[:3]) // First 3 chars of race name
 		}
-		text += "Всего\n"
+		text += "Всего | Ср. место\n"
 		text += strings.Repeat("-", 50) + "\n"
 
 		// Driver rows
@@ -451,8 +496,9 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 				}
 			}
 
-			// Total score
-			text += fmt.Sprintf("*%d*\n", driverTotalScores[driver.ID])
+			// Total score and average finish position
+			text += fmt.Sprintf("*%d* | %s\n", driverTotalScores[driver.ID],
+				formatAveragePlace(driverPlaceSums[driver.ID], driverFinishes[driver.ID]))
 		}
 
 		// Best rally times
@@ -462,9 +508,9 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 			if records, exists := driverRallyRecords[driver.ID]; exists && len(records) > 0 {
 				// Find best time
 				var bestRaceID int
-				var bestTime string
+				var bestTime int
 				for raceID, time := range records {
-					if bestTime == "" || time < bestTime {
+					if bestTime == 0 || time < bestTime {
 						bestTime = time
 						bestRaceID = raceID
 					}
@@ -479,7 +525,7 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 					}
 				}
 
-				text += fmt.Sprintf("• *%s*: %s (%s)\n", driver.Name, bestTime, raceName)
+				text += fmt.Sprintf("• *%s*: %s (%s)\n", driver.Name, models.FormatLapTime(bestTime), raceName)
 			}
 		}
 	}
@@ -513,6 +559,18 @@ func (b *Bot) showDriverStats(chatID int64, seasonID int) {
 		keyboard = append(keyboard, seasonsRow)
 	}
 
+	// Breakdown pages for the selected season
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🎯 По дисциплинам",
+			fmt.Sprintf("stats_breakdown:%d:%s", seasonID, breakdownDisciplines),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🚗 По классам",
+			fmt.Sprintf("stats_breakdown:%d:%s", seasonID, breakdownClasses),
+		),
+	))
+
 	// Add back button
 	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
@@ -575,80 +633,37 @@ func (b *Bot) handleDriver(message *tgbotapi.Message) {
 		return
 	}
 
-	// Get all race results for this driver to find rally time record
-	results, err := b.ResultRepo.GetByDriverID(driver.ID)
+	// Get personal best times per discipline and car class
+	personalBests, err := b.ResultRepo.GetTimeRecords(driver.ID)
 	if err != nil {
-		log.Printf("Ошибка получения результатов гонщика: %v", err)
+		log.Printf("Ошибка получения личных рекордов гонщика: %v", err)
 		// Continue anyway, this is not critical
 	}
 
-	// Find best rally time
-	type rallyRecord struct {
-		Time     string
-		RaceName string
-		SeasonID int
-	}
-
-	var bestRally *rallyRecord
-
-	// Look through all results for rally disciplines
-	for _, result := range results {
-		// Get race to find disciplines and season
-		race, err := b.RaceRepo.GetByID(result.RaceID)
-		if err != nil {
-			log.Printf("Ошибка получения гонки %d: %v", result.RaceID, err)
-			continue
-		}
-
-		if race == nil {
-			continue
-		}
-
-		// Look for rally discipline in this race
-		for _, discipline := range race.Disciplines {
-			if strings.Contains(strings.ToLower(discipline), "ралли") {
-				// Check if driver has a result for this discipline
-				place, exists := result.Results[discipline]
-				if exists && place > 0 {
-					// In a real implementation, you would get the time from the result
-					// This is a placeholder
-					rallyTime := "2:34.567" // Example time
-
-					// If this is the first or better record, save it
-					if bestRally == nil || rallyTime < bestRally.Time {
-						bestRally = &rallyRecord{
-							Time:     rallyTime,
-							RaceName: race.Name,
-							SeasonID: race.SeasonID,
-						}
-					}
-				}
-			}
-		}
-	}
-
-	// Get season name for the rally record
-	var rallySeasonName string
-	if bestRally != nil {
-		season, err := b.SeasonRepo.GetByID(bestRally.SeasonID)
-		if err == nil && season != nil {
-			rallySeasonName = season.Name
-		}
-	}
-
 	// Format driver profile
 	text := fmt.Sprintf("👨‍🏎️ *Карточка гонщика*\n\n*%s*\n", driver.Name)
 
+	text += b.formatDriverTeam(driver.ID)
+
 	if driver.Description != "" {
 		text += fmt.Sprintf("📋 *Описание:* %s\n\n", driver.Description)
 	}
 
 	text += fmt.Sprintf("🏆 *Всего очков:* %d\n", stats.TotalScore)
 	text += fmt.Sprintf("🏁 *Гонок:* %d\n", stats.TotalRaces)
-
-	// Add rally record if available
-	if bestRally != nil {
-		text += fmt.Sprintf("⏱️ *Рекорд в Ралли:* %s (%s, %s)\n", bestRally.Time, bestRally.RaceName, rallySeasonName)
+	if stats.AveragePlace > 0 {
+		text += fmt.Sprintf("📈 *Среднее место:* %.2f\n", stats.AveragePlace)
+	}
+	text += formatStatusCounts(stats)
+	text += b.formatDriverRating(driver.ID)
+	text += formatAchievements(stats)
+
+	// Add personal bests if available
+	if len(personalBests) > 0 {
+		text += "\n⏱️ *Личные рекорды:*\n"
+		for _, pb := range personalBests {
+			text += fmt.Sprintf("• %s (%s): %s — %s\n", pb.Discipline, pb.CarClass, models.FormatLapTime(pb.TimeMs), pb.RaceName)
+		}
 	}
 
 	text += "\n"
@@ -662,8 +677,9 @@ func (b *Bot) handleDriver(message *tgbotapi.Message) {
 		text += "*Пока нет завершенных гонок*"
 	}
 
-	// Клавиатура для редактирования профиля
+	// Клавиатура для редактирования профиля и страниц статистики
 	keyboard := DriverProfileKeyboard()
+	keyboard.InlineKeyboard = append([][]tgbotapi.InlineKeyboardButton{driverBreakdownRow(driver.ID)}, keyboard.InlineKeyboard...)
 
 	if driver.PhotoURL != "" {
 		// Отправляем фото с подписью
@@ -811,7 +827,16 @@ func (b *Bot) handleHelp(message *tgbotapi.Message) {
 /races - Просмотр гонок текущего сезона
 /results - Просмотр результатов гонок
 /leaderboard - Рейтинг гонщиков
+/rating - Рейтинг Эло с изменениями по гонкам
+/standings [сезон] [гонка] - Турнирная таблица после любой гонки
+/h2h - Очные встречи двух гонщиков
+/teams - Командный зачет активного сезона
+/divisions - Дивизионы активного сезона
+/records - Зал славы и рекорды за все время
+/title - Борьба за титул активного сезона
+/car <запрос> - Поиск машины по названию и году
 /stats - Детальная статистика гонщиков
+/times [класс] - Рекорды по времени и личные рекорды
 /help - Эта справка
 /cancel - Отмена текущего действия
 
@@ -822,6 +847,7 @@ func (b *Bot) handleHelp(message *tgbotapi.Message) {
 /leaverage - Отмена регистрации на гонку
 /mycar - Просмотр назначенной машины для гонки
 /addresult - Добавить свой результат в гонке
+/vote - Голосование в судейских дисциплинах (Визуал)
 /racedetails [ID] - Подробная информация о гонке
 
 *Просмотр машин:*
@@ -835,7 +861,11 @@ func (b *Bot) handleHelp(message *tgbotapi.Message) {
 *Команды администратора:*
 /adminrace - Панель управления текущей гонкой
 /editresult [ID] - Редактирование результатов участников
-/newrace - Создание новой гонки`
+/newrace - Создание новой гонки
+/scoring - Настройка таблицы очков сезона
+/recalc [ID сезона|all] - Пересчет сохраненных очков по текущим правилам
+/pending - Очередь результатов на подтверждение
+/finishseason - Завершение активного сезона с рассылкой итогов`
 	}
 
 	text += `
@@ -843,15 +873,16 @@ func (b *Bot) handleHelp(message *tgbotapi.Message) {
 *Как проходит гонка:*
 1. Гонщик регистрируется на предстоящую гонку через /joinrace
 2. Администратор запускает гонку, и всем участникам выдаются случайные машины
-3. Гонщик может принять машину или использовать реролл (штраф -1 очко)
-4. Гонщики проводят заезды в каждой дисциплине и вводят свои результаты
-5. Администратор завершает гонку и публикует результаты
+3. Гонщик может принять машину или использовать реролл (со штрафом по правилам сезона)
+4. Гонщики проводят заезды в каждой дисциплине и вводят свои результаты (место, время или статус DNS/DNF/DSQ), а в Визуале голосуют за машины соперников через /vote
+5. Администратор завершает гонку: если два гонщика заявили одно место, бот покажет конфликт и предложит исправить его в одно касание
 
 *Система подсчета очков:*
-🥇 1 место - 3 очка
-🥈 2 место - 2 очка
-🥉 3 место - 1 очко
-⚠️ Реролл машины - штраф -1 очко`
+`
+
+	// Таблица очков берется из настроек активного сезона
+	text += formatScoringRules(b.getActiveScoringRules())
+	text += "Подробнее: /scoring"
 
 	// Create helpful keyboard for main commands
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
@@ -931,6 +962,28 @@ func (b *Bot) handleStateInput(message *tgbotapi.Message, state models.UserState
 		b.handleNewSeasonName(message, state)
 	case "new_season_start_date":
 		b.handleNewSeasonStartDate(message, state)
+	case "season_next_name":
+		b.handleSeasonNextName(message, state)
+	case "team_name":
+		b.handleTeamName(message, state)
+	case "team_badge":
+		b.handleTeamBadge(message, state)
+	case "division_name":
+		b.handleDivisionName(message, state)
+	case "scoring_edit":
+		b.handleScoringEditInput(message, state)
+	case "result_screenshot":
+		b.handleResultScreenshot(message, state)
+	case "penalty_value":
+		b.handlePenaltyValue(message, state)
+	case "penalty_reason":
+		b.handlePenaltyReason(message, state)
+	case "car_search", "car_override_search", "car_pool_ban_search":
+		b.handleCarSearchQuery(message, state)
+	case "car_pool_input":
+		b.handleCarPoolInput(message, state)
+	case "vote_ballot":
+		b.sendMessage(message.Chat.ID, "🗳 Расставьте участников кнопками бюллетеня или используйте /cancel для отмены.")
 	default:
 		b.sendMessage(message.Chat.ID, "⚠️ Неизвестное состояние. Используйте /cancel для отмены текущего действия.")
 	}
@@ -1264,15 +1317,8 @@ func (b *Bot) handleAddResultCarPhoto(message *tgbotapi.Message, state models.Us
 	userID := message.From.ID
 	chatID := message.Chat.ID
 
-	var photoURL string
-
-	if message.Text == "-" {
-		photoURL = ""
-	} else if message.Photo != nil && len(message.Photo) > 0 {
-		// Получаем ID фото для сохранения
-		photo := message.Photo[len(message.Photo)-1]
-		photoURL = photo.FileID
-	} else {
+	photoURL, ok := photoFileIDOrSkip(message)
+	if !ok {
 		b.sendMessage(chatID, "⚠️ Пожалуйста, отправьте фото или '-' для пропуска.")
 		return
 	}
@@ -1292,22 +1338,32 @@ func (b *Bot) handleAddResultCarPhoto(message *tgbotapi.Message, state models.Us
 		return
 	}
 
+	// Места в судейских дисциплинах определяются голосованием, гонщик их не вводит
+	disciplines := selfReportedDisciplines(race.Disciplines)
+	if len(disciplines) == 0 {
+		b.submitJudgedOnlyResult(chatID, userID, race,
+			state.ContextData["car_number"].(int), state.ContextData["car_name"].(string), photoURL)
+		return
+	}
+
 	// Сохраняем данные и переходим к вводу результатов первой дисциплины
 	b.StateManager.SetState(userID, "add_result_discipline", map[string]interface{}{
 		"race_id":     raceID,
 		"car_number":  state.ContextData["car_number"],
 		"car_name":    state.ContextData["car_name"],
 		"car_photo":   photoURL,
-		"disciplines": race.Disciplines,
+		"disciplines": disciplines,
 		"current_idx": 0,
 		"results":     make(map[string]int),
+		"times":       make(map[string]int),
+		"statuses":    make(map[string]string),
 	})
 
 	// Запрашиваем результат первой дисциплины
 	b.sendMessage(
 		chatID,
-		fmt.Sprintf("Введите ваше место в дисциплине '%s' (1-3 или 0 если не участвовали):",
-			race.Disciplines[0]),
+		fmt.Sprintf("%sВведите ваше место в дисциплине '%s' (1-%d или 0 если не участвовали):%s",
+			judgedDisciplinesNote(race), disciplines[0], b.getRaceFieldSize(raceID), disciplineTimeHint(disciplines[0])),
 	)
 }
 
@@ -1316,21 +1372,29 @@ func (b *Bot) handleAddResultDiscipline(message *tgbotapi.Message, state models.
 	userID := message.From.ID
 	chatID := message.Chat.ID
 
-	// Проверяем, что введено корректное число
-	place, err := strconv.Atoi(message.Text)
-	if err != nil || place < 0 || place > 3 {
-		b.sendMessage(chatID, "⚠️ Пожалуйста, введите число от 0 до 3 (0 - не участвовал, 1-3 - место).")
-		return
-	}
-
 	// Получаем текущие данные
 	disciplines := state.ContextData["disciplines"].([]string)
 	currentIdx := state.ContextData["current_idx"].(int)
 	results := state.ContextData["results"].(map[string]int)
+	times := stateTimes(state)
+	statuses := stateStatuses(state)
+	currentDiscipline := disciplines[currentIdx]
+
+	// Проверяем, что введено корректное место или время
+	fieldSize := b.getRaceFieldSize(state.ContextData["race_id"].(int))
+	place, timeMs, status, ok := parseDisciplineInput(message.Text, currentDiscipline, fieldSize)
+	if !ok {
+		b.sendMessage(chatID, disciplineInputError(currentDiscipline, fieldSize))
+		return
+	}
 
 	// Сохраняем результат текущей дисциплины
-	currentDiscipline := disciplines[currentIdx]
-	results[currentDiscipline] = place
+	models.SetDisciplineResult(results, statuses, currentDiscipline, place, status)
+	if timeMs > 0 {
+		times[currentDiscipline] = timeMs
+	} else {
+		delete(times, currentDiscipline)
+	}
 
 	// Переходим к следующей дисциплине или завершаем
 	currentIdx++
@@ -1345,13 +1409,15 @@ func (b *Bot) handleAddResultDiscipline(message *tgbotapi.Message, state models.
 			"disciplines": disciplines,
 			"current_idx": currentIdx,
 			"results":     results,
+			"times":       times,
+			"statuses":    statuses,
 		})
 
 		// Запрашиваем результат следующей дисциплины
 		b.sendMessage(
 			chatID,
-			fmt.Sprintf("Введите ваше место в дисциплине '%s' (1-3 или 0 если не участвовали):",
-				disciplines[currentIdx]),
+			fmt.Sprintf("Введите ваше место в дисциплине '%s' (1-%d или 0 если не участвовали):%s",
+				disciplines[currentIdx], fieldSize, disciplineTimeHint(disciplines[currentIdx])),
 		)
 	} else {
 		// Все дисциплины заполнены, сохраняем результат
@@ -1369,38 +1435,44 @@ func (b *Bot) handleAddResultDiscipline(message *tgbotapi.Message, state models.
 			return
 		}
 
-		// Вычисляем общий счет
-		totalScore := 0
-		for _, place := range results {
-			switch place {
-			case 1:
-				totalScore += 3
-			case 2:
-				totalScore += 2
-			case 3:
-				totalScore += 1
-			}
+		raceID := state.ContextData["race_id"].(int)
+		rules := b.getScoringRules(raceID)
+
+		// Учитываем штраф за реролл по правилам сезона
+		rerollPenalty := 0
+		if rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(raceID, driver.ID); err == nil && rerollUsed {
+			rerollPenalty = rules.RerollPenalty
 		}
 
+		// Вычисляем общий счет
+		totalScore := rules.CalculateTotalScore(results, statuses, rerollPenalty)
+
 		// Создаем результат гонки
 		result := &models.RaceResult{
-			RaceID:      state.ContextData["race_id"].(int),
-			DriverID:    driver.ID,
-			CarNumber:   state.ContextData["car_number"].(int),
-			CarName:     state.ContextData["car_name"].(string),
-			CarPhotoURL: state.ContextData["car_photo"].(string),
-			Results:     results,
-			TotalScore:  totalScore,
+			RaceID:             raceID,
+			DriverID:           driver.ID,
+			CarNumber:          state.ContextData["car_number"].(int),
+			CarName:            state.ContextData["car_name"].(string),
+			CarPhotoURL:        state.ContextData["car_photo"].(string),
+			Results:            results,
+			TotalScore:         totalScore,
+			RerollPenalty:      rerollPenalty,
+			Times:              times,
+			Statuses:           statuses,
+			ConfirmationStatus: models.ResultStatusPending,
 		}
 
 		// Сохраняем результат в БД
-		_, err = b.ResultRepo.Create(result)
+		resultID, err := b.ResultRepo.CreateWithRerollPenalty(result)
 		if err != nil {
 			log.Printf("Ошибка сохранения результата: %v", err)
 			b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении результатов.")
 			return
 		}
 
+		// Места в дисциплинах на время и судейских дисциплинах рассчитываются по времени и голосам всех участников
+		totalScore = b.finalizeResultPlaces(resultID, result)
+
 		// Очищаем состояние
 		b.StateManager.ClearState(userID)
 
@@ -1411,6 +1483,9 @@ func (b *Bot) handleAddResultDiscipline(message *tgbotapi.Message, state models.
 
 		// Показываем результаты гонки
 		b.showRaceResults(chatID, result.RaceID)
+
+		// Предлагаем приложить скриншоты и ставим результат в очередь подтверждения
+		b.onResultSubmitted(chatID, resultID)
 	}
 }
 
@@ -33,7 +33,16 @@ func (b *Bot) handleCars(message *tgbotapi.Message) {
 
 	// Проверяем, есть ли машины в базе
 	if len(classCounts) == 0 {
-		b.sendMessage(chatID, "⚠️ База данных машин пуста. Необходимо запустить парсер.")
+		if !b.IsAdmin(message.From.ID) {
+			b.sendMessage(chatID, "⚠️ База данных машин пуста. Обратитесь к администратору.")
+			return
+		}
+
+		b.sendMessageWithKeyboard(chatID, "⚠️ База данных машин пуста. Загрузите каталог машин из вики или из файла.",
+			tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить базу машин", "update_cars_db"),
+				tgbotapi.NewInlineKeyboardButtonData("📥 Загрузить файл", "cars_import"),
+			)))
 		return
 	}
 
@@ -72,6 +81,14 @@ func (b *Bot) handleCars(message *tgbotapi.Message) {
 				"update_cars_db",
 			),
 		))
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📤 CSV", "cars_export:csv"),
+			tgbotapi.NewInlineKeyboardButtonData("📤 JSON", "cars_export:json"),
+			tgbotapi.NewInlineKeyboardButtonData("📥 Загрузить файл", "cars_import"),
+		))
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗂 История каталога", "car_versions"),
+		))
 	}
 
 	// Отправляем сообщение с клавиатурой
@@ -125,7 +142,7 @@ func (b *Bot) handleCarClass(message *tgbotapi.Message) {
 			break
 		}
 
-		text += fmt.Sprintf("%d. *%s (%d)* - %d CR\n", i+1, car.Name, car.Year, car.Price)
+		text += fmt.Sprintf("%d. *%s (%s)* - %d CR\n", i+1, car.Name, car.Year, car.Price)
 	}
 
 	// Добавляем примечание, если показаны не все машины
@@ -384,6 +401,8 @@ func (b *Bot) notifyDriversAboutCarAssignments(raceID int) {
 	// Logging for debugging
 	log.Printf("Отправка уведомлений о машинах для %d гонщиков", len(registrations))
 
+	rerollPenalty := b.getScoringRules(raceID).RerollPenalty
+
 	for _, reg := range registrations {
 		// Get driver's Telegram ID
 		var telegramID int64
@@ -420,7 +439,8 @@ func (b *Bot) notifyDriversAboutCarAssignments(raceID int) {
 		text += fmt.Sprintf("🚦 Старт: %.1f/10\n", car.Launch)
 		text += fmt.Sprintf("🛑 Торможение: %.1f/10\n\n", car.Braking)
 		text += fmt.Sprintf("🏆 Класс: %s %d\n\n", car.ClassLetter, car.ClassNumber)
-		text += "*У вас есть возможность сделать реролл машины (получить другую), но это будет стоить -1 балл в итоговом зачете гонки.*"
+		text += fmt.Sprintf("*У вас есть возможность сделать реролл машины (получить другую), но это будет стоить -%d %s в итоговом зачете гонки.*",
+			rerollPenalty, pointsWord(rerollPenalty))
 
 		// Create keyboard for confirmation or reroll
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
@@ -432,7 +452,7 @@ func (b *Bot) notifyDriversAboutCarAssignments(raceID int) {
 			),
 			tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData(
-					"🎲 Реролл (-1 балл)",
+					fmt.Sprintf("🎲 Реролл (-%d %s)", rerollPenalty, pointsWord(rerollPenalty)),
 					fmt.Sprintf("reroll_car:%d", raceID),
 				),
 			),
@@ -450,6 +470,9 @@ func (b *Bot) notifyDriversAboutCarAssignments(raceID int) {
 		}
 
 		log.Printf("Уведомление отправлено гонщику %d, ID сообщения: %d", reg.DriverID, sentMsg.MessageID)
+
+		// Invite the driver to vote in judged disciplines
+		b.sendBallotInvitation(telegramID, race)
 	}
 }
 
@@ -479,20 +502,27 @@ func (b *Bot) notifyDriversAboutRaceCompletion(raceID int) {
 		return
 	}
 
+	penalties, err := b.PenaltyRepo.GetByRaceID(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов гонки: %v", err)
+	}
+
+	teams := b.seasonDriverTeams(race.SeasonID)
+
 	// Format results message
 	text := fmt.Sprintf("🏁 *Гонка завершена: %s*\n\n", race.Name)
 	text += "*Итоговые результаты:*\n\n"
 
 	for i, result := range results {
-		text += fmt.Sprintf("%d. *%s* (%s)\n", i+1, result.DriverName, result.CarName)
+		text += fmt.Sprintf("%d. *%s*%s (%s)\n", i+1, result.DriverName, teamBadge(teams, result.DriverID), result.CarName)
 		text += fmt.Sprintf("   🔢 Номер: %d\n", result.CarNumber)
 
 		// Add disciplinе results
 		var placesText []string
 		for _, discipline := range race.Disciplines {
-			place := result.Results[discipline]
-			emoji := getPlaceEmoji(place)
-			placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, getPlaceText(place)))
+			place, status := result.Results[discipline], result.Statuses[discipline]
+			emoji := getResultEmoji(place, status)
+			placesText = append(placesText, fmt.Sprintf("%s %s: %s", emoji, discipline, getResultText(place, status)))
 		}
 
 		text += fmt.Sprintf("   📊 %s\n", strings.Join(placesText, " | "))
@@ -502,6 +532,8 @@ func (b *Bot) notifyDriversAboutRaceCompletion(raceID int) {
 			text += fmt.Sprintf("   ⚠️ Штраф за реролл: -%d\n", result.RerollPenalty)
 		}
 
+		text += formatPenalties(penalties[result.ID], "   ")
+
 		text += fmt.Sprintf("   🏆 Всего очков: %d\n\n", result.TotalScore)
 	}
 
@@ -529,13 +561,15 @@ func (b *Bot) notifyDriversAboutRaceCompletion(raceID int) {
 
 // getPlaceEmoji returns emoji for a place
 func getPlaceEmoji(place int) string {
-	switch place {
-	case 1:
+	switch {
+	case place == 1:
 		return "🥇"
-	case 2:
+	case place == 2:
 		return "🥈"
-	case 3:
+	case place == 3:
 		return "🥉"
+	case place > 3:
+		return "🏁"
 	default:
 		return "➖"
 	}
@@ -543,16 +577,18 @@ func getPlaceEmoji(place int) string {
 
 // getPlaceText returns text for a place
 func getPlaceText(place int) string {
-	switch place {
-	case 0:
-		return "не участвовал"
-	case 1:
-		return "1 место"
-	case 2:
-		return "2 место"
-	case 3:
-		return "3 место"
-	default:
+	if place <= 0 {
 		return "не участвовал"
 	}
+
+	return fmt.Sprintf("%d место", place)
+}
+
+// formatAveragePlace форматирует среднее место по сумме мест и количеству финишей
+func formatAveragePlace(sum, count int) string {
+	if count == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f", float64(sum)/float64(count))
 }
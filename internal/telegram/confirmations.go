@@ -0,0 +1,588 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerConfirmationHandlers регистрирует обработчики подтверждения результатов
+func (b *Bot) registerConfirmationHandlers() {
+	b.CommandHandlers["pending"] = b.handlePending
+
+	b.CallbackHandlers["pending_queue"] = b.callbackPendingQueue
+	b.CallbackHandlers["pending_view"] = b.callbackPendingView
+	b.CallbackHandlers["race_confirmations"] = b.callbackRaceConfirmations
+	b.CallbackHandlers["result_confirm"] = b.callbackResultConfirm
+	b.CallbackHandlers["result_reject"] = b.callbackResultReject
+	b.CallbackHandlers["result_screenshots"] = b.callbackResultScreenshots
+	b.CallbackHandlers["result_screenshot"] = b.callbackResultScreenshot
+}
+
+// onResultSubmitted сообщает гонщику, что результат ожидает подтверждения,
+// предлагает прикрепить скриншоты и уведомляет администраторов
+func (b *Bot) onResultSubmitted(chatID int64, resultID int) {
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"📸 Прикрепить скриншоты",
+				fmt.Sprintf("result_screenshots:%d", resultID),
+			),
+		),
+	)
+
+	b.sendMessageWithKeyboard(
+		chatID,
+		"⏳ Результат ожидает подтверждения администратором или другим участником гонки и пока не учитывается в рейтинге.\n\n"+
+			"Вы можете прикрепить скриншоты результатов по дисциплинам — это ускорит проверку.",
+		keyboard,
+	)
+
+	go b.notifyAdminsAboutPendingResult(resultID)
+}
+
+// notifyAdminsAboutPendingResult уведомляет администраторов о новом результате в очереди
+func (b *Bot) notifyAdminsAboutPendingResult(resultID int) {
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		log.Printf("Ошибка получения результата %d для уведомления: %v", resultID, err)
+		return
+	}
+
+	driver, err := b.DriverRepo.GetByID(result.DriverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика для уведомления: %v", err)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(result.RaceID)
+	if err != nil || race == nil {
+		log.Printf("Ошибка получения гонки для уведомления: %v", err)
+		return
+	}
+
+	text := fmt.Sprintf("📝 *Новый результат на подтверждение*\n\nГонщик *%s* добавил результат в гонке '%s': %d %s.",
+		driver.Name, race.Name, result.TotalScore, pointsWord(result.TotalScore))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔍 Проверить",
+				fmt.Sprintf("pending_view:%d", resultID),
+			),
+		),
+	)
+
+	for adminID := range b.AdminIDs {
+		b.sendMessageWithKeyboard(adminID, text, keyboard)
+	}
+}
+
+// canConfirmResult проверяет, может ли пользователь подтвердить результат:
+// администратор или другой участник той же гонки
+func (b *Bot) canConfirmResult(userID int64, result *models.RaceResult) bool {
+	if b.IsAdmin(userID) {
+		return true
+	}
+
+	driver, err := b.DriverRepo.GetByTelegramID(userID)
+	if err != nil || driver == nil || driver.ID == result.DriverID {
+		return false
+	}
+
+	registered, err := b.RaceRepo.CheckDriverRegistered(result.RaceID, driver.ID)
+	if err != nil {
+		log.Printf("Ошибка проверки регистрации гонщика: %v", err)
+		return false
+	}
+
+	return registered
+}
+
+// handlePending обрабатывает команду /pending - очередь результатов на подтверждение
+func (b *Bot) handlePending(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	if !b.IsAdmin(message.From.ID) {
+		b.sendMessage(chatID, "⛔ Эта команда доступна только администраторам.")
+		return
+	}
+
+	text, keyboard, err := b.buildPendingQueue()
+	if err != nil {
+		log.Printf("Ошибка получения очереди подтверждений: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении очереди подтверждений.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// buildPendingQueue формирует очередь результатов, ожидающих подтверждения
+func (b *Bot) buildPendingQueue() (string, tgbotapi.InlineKeyboardMarkup, error) {
+	pending, err := b.ResultRepo.GetPending(0)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	text := "⏳ *Результаты на подтверждение*\n\n"
+
+	if len(pending) == 0 {
+		text += "Очередь пуста — все результаты подтверждены."
+	}
+
+	for _, result := range pending {
+		text += fmt.Sprintf("• *%s* — %s: %d %s", result.RaceName, result.DriverName,
+			result.TotalScore, pointsWord(result.TotalScore))
+		if result.Screenshots > 0 {
+			text += fmt.Sprintf(" (📸 %d)", result.Screenshots)
+		}
+		text += "\n"
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🔍 %s — %s", result.DriverName, result.RaceName),
+				fmt.Sprintf("pending_view:%d", result.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить", "pending_queue"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackPendingQueue обновляет очередь подтверждений
+func (b *Bot) callbackPendingQueue(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав администратора", true)
+		return
+	}
+
+	text, keyboard, err := b.buildPendingQueue()
+	if err != nil {
+		log.Printf("Ошибка получения очереди подтверждений: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении очереди", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackPendingView показывает результат со скриншотами и кнопками подтверждения (pending_view:resultID)
+func (b *Bot) callbackPendingView(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.sendMessage(chatID, "⚠️ Результат не найден — возможно, он уже отклонен.")
+		return
+	}
+
+	if !b.canConfirmResult(query.From.ID, result) {
+		b.answerCallbackQuery(query.ID, "⛔ Подтверждать результаты могут администраторы и другие участники гонки", true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(result.RaceID)
+	if err != nil || race == nil {
+		b.sendMessage(chatID, "⚠️ Гонка не найдена.")
+		return
+	}
+
+	driverName := "?"
+	if driver, err := b.DriverRepo.GetByID(result.DriverID); err == nil && driver != nil {
+		driverName = driver.Name
+	}
+
+	// Сначала отправляем скриншоты, затем карточку результата с кнопками
+	screenshots, err := b.ResultRepo.GetScreenshots(resultID)
+	if err != nil {
+		log.Printf("Ошибка получения скриншотов: %v", err)
+	}
+
+	for _, screenshot := range screenshots {
+		b.sendPhoto(chatID, screenshot.FileID, fmt.Sprintf("📸 %s — %s", driverName, screenshot.Discipline))
+	}
+
+	text := fmt.Sprintf("📝 *Результат гонщика %s*\n🏁 Гонка: %s\n🚗 %s (№%d)\n\n", driverName, race.Name, result.CarName, result.CarNumber)
+
+	for _, discipline := range race.Disciplines {
+		text += fmt.Sprintf("%s %s: %s\n", getResultEmoji(result.Results[discipline], result.Statuses[discipline]), discipline, formatDisciplineResult(result, discipline))
+	}
+
+	if result.RerollPenalty > 0 {
+		text += fmt.Sprintf("\n⚠️ Штраф за реролл: -%d", result.RerollPenalty)
+	}
+	text += fmt.Sprintf("\n🏆 Всего очков: %d\n", result.TotalScore)
+
+	if len(screenshots) == 0 {
+		text += "\n📸 Скриншоты не приложены"
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if result.IsConfirmed() {
+		text += "\n✅ Результат уже подтвержден"
+	} else {
+		row := tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Подтвердить", fmt.Sprintf("result_confirm:%d", resultID)),
+		)
+		if b.IsAdmin(query.From.ID) {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData("❌ Отклонить", fmt.Sprintf("result_reject:%d", resultID)))
+		}
+		keyboard = append(keyboard, row)
+	}
+
+	if b.IsAdmin(query.From.ID) {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К очереди", "pending_queue"),
+		))
+	} else {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("race_confirmations:%d", result.RaceID)),
+		))
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+	b.deleteMessage(chatID, query.Message.MessageID)
+}
+
+// callbackRaceConfirmations показывает неподтвержденные результаты гонки (race_confirmations:raceID)
+func (b *Bot) callbackRaceConfirmations(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	pending, err := b.ResultRepo.GetPending(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения неподтвержденных результатов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении результатов.")
+		return
+	}
+
+	var own int
+	if driver, err := b.DriverRepo.GetByTelegramID(userID); err == nil && driver != nil {
+		own = driver.ID
+	}
+
+	text := "⏳ *Результаты, ожидающие подтверждения*\n\n"
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for _, result := range pending {
+		text += fmt.Sprintf("• %s: %d %s\n", result.DriverName, result.TotalScore, pointsWord(result.TotalScore))
+
+		if result.DriverID == own || !b.canConfirmResult(userID, &result.RaceResult) {
+			continue
+		}
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🔍 %s", result.DriverName),
+				fmt.Sprintf("pending_view:%d", result.ID),
+			),
+		))
+	}
+
+	if len(pending) == 0 {
+		text += "Все результаты гонки подтверждены."
+	} else if len(keyboard) == 0 {
+		text += "\nПодтверждать результаты могут администраторы и другие участники гонки."
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К результатам гонки", fmt.Sprintf("race_results:%d", raceID)),
+	))
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackResultConfirm подтверждает результат (result_confirm:resultID)
+func (b *Bot) callbackResultConfirm(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.editMessage(chatID, query.Message.MessageID, "⚠️ Результат не найден — возможно, он уже отклонен.")
+		return
+	}
+
+	if !b.canConfirmResult(userID, result) {
+		b.answerCallbackQuery(query.ID, "⛔ Подтверждать результаты могут администраторы и другие участники гонки", true)
+		return
+	}
+
+	confirmed, err := b.ResultRepo.Confirm(resultID, userID)
+	if err != nil {
+		log.Printf("Ошибка подтверждения результата: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при подтверждении результата.")
+		return
+	}
+
+	if !confirmed {
+		b.editMessage(chatID, query.Message.MessageID, "ℹ️ Результат уже был подтвержден.")
+		return
+	}
+
+	log.Printf("Результат %d подтвержден пользователем %d", resultID, userID)
+
+	// Результат завершенной гонки, подтвержденный позже, меняет рейтинг Эло и может принести достижения
+	b.recalculateRatingsForRace(result.RaceID)
+	go b.evaluateRaceAchievements(result.RaceID)
+	go b.checkTitleClinch(result.RaceID)
+
+	b.editMessage(chatID, query.Message.MessageID, "✅ Результат подтвержден и учтен в рейтинге.")
+	b.notifyResultOwner(result, "✅ Ваш результат в гонке '%s' подтвержден и учтен в рейтинге.")
+}
+
+// callbackResultReject отклоняет результат и удаляет его (result_reject:resultID)
+func (b *Bot) callbackResultReject(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Отклонять результаты могут только администраторы", true)
+		return
+	}
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.editMessage(chatID, query.Message.MessageID, "⚠️ Результат не найден — возможно, он уже отклонен.")
+		return
+	}
+
+	if result.IsConfirmed() {
+		b.editMessage(chatID, query.Message.MessageID, "ℹ️ Результат уже подтвержден, отклонить его нельзя. Используйте /editresult.")
+		return
+	}
+
+	if err := b.ResultRepo.Delete(resultID); err != nil {
+		log.Printf("Ошибка удаления результата: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при отклонении результата.")
+		return
+	}
+
+	log.Printf("Результат %d отклонен администратором %d", resultID, query.From.ID)
+
+	// Отклоненный результат больше не держит борьбу за титул открытой
+	go b.checkTitleClinch(result.RaceID)
+
+	b.editMessage(chatID, query.Message.MessageID, "❌ Результат отклонен. Гонщику отправлено уведомление.")
+	b.notifyResultOwner(result, "❌ Ваш результат в гонке '%s' отклонен администратором. Проверьте места и добавьте результат заново через /addresult.")
+}
+
+// notifyResultOwner отправляет гонщику уведомление о результате.
+// Первый глагол %s в format заменяется названием гонки, остальные - значениями args.
+func (b *Bot) notifyResultOwner(result *models.RaceResult, format string, args ...interface{}) {
+	driver, err := b.DriverRepo.GetByID(result.DriverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика %d для уведомления: %v", result.DriverID, err)
+		return
+	}
+
+	raceName := fmt.Sprintf("#%d", result.RaceID)
+	if race, err := b.RaceRepo.GetByID(result.RaceID); err == nil && race != nil {
+		raceName = race.Name
+	}
+
+	b.sendMessage(driver.TelegramID, fmt.Sprintf(format, append([]interface{}{raceName}, args...)...))
+}
+
+// buildScreenshotsMenu формирует меню прикрепления скриншотов по дисциплинам
+func (b *Bot) buildScreenshotsMenu(result *models.RaceResult) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	race, err := b.RaceRepo.GetByID(result.RaceID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if race == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("гонка %d не найдена", result.RaceID)
+	}
+
+	screenshots, err := b.ResultRepo.GetScreenshots(result.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	attached := make(map[string]bool)
+	for _, screenshot := range screenshots {
+		attached[screenshot.Discipline] = true
+	}
+
+	text := fmt.Sprintf("📸 *Скриншоты результата в гонке '%s'*\n\nВыберите дисциплину, чтобы прикрепить или заменить скриншот:", race.Name)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, discipline := range race.Disciplines {
+		label := "➕ " + discipline
+		if attached[discipline] {
+			label = "✅ " + discipline
+		}
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("result_screenshot:%d:%s", result.ID, discipline)),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✅ Готово", "back_to_main"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// getOwnResult возвращает результат, если он принадлежит пользователю
+func (b *Bot) getOwnResult(userID int64, resultID int) *models.RaceResult {
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		return nil
+	}
+
+	driver, err := b.DriverRepo.GetByTelegramID(userID)
+	if err != nil || driver == nil || driver.ID != result.DriverID {
+		return nil
+	}
+
+	return result
+}
+
+// callbackResultScreenshots показывает меню скриншотов результата (result_screenshots:resultID)
+func (b *Bot) callbackResultScreenshots(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	result := b.getOwnResult(query.From.ID, resultID)
+	if result == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Результат не найден", true)
+		return
+	}
+
+	text, keyboard, err := b.buildScreenshotsMenu(result)
+	if err != nil {
+		log.Printf("Ошибка формирования меню скриншотов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении скриншотов.")
+		return
+	}
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackResultScreenshot запрашивает скриншот для дисциплины (result_screenshot:resultID:discipline)
+func (b *Bot) callbackResultScreenshot(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+
+	if b.getOwnResult(userID, resultID) == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Результат не найден", true)
+		return
+	}
+
+	discipline := parts[2]
+
+	b.StateManager.SetState(userID, "result_screenshot", map[string]interface{}{
+		"result_id":  resultID,
+		"discipline": discipline,
+	})
+
+	b.sendMessage(chatID, fmt.Sprintf("Отправьте скриншот результата в дисциплине '%s' (или '-' для отмены):", discipline))
+}
+
+// handleResultScreenshot сохраняет скриншот результата
+func (b *Bot) handleResultScreenshot(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	fileID, ok := photoFileIDOrSkip(message)
+	if !ok {
+		b.sendMessage(chatID, "⚠️ Пожалуйста, отправьте фото или '-' для отмены.")
+		return
+	}
+
+	resultID := state.ContextData["result_id"].(int)
+	discipline := state.ContextData["discipline"].(string)
+
+	b.StateManager.ClearState(userID)
+
+	result := b.getOwnResult(userID, resultID)
+	if result == nil {
+		b.sendMessage(chatID, "⚠️ Результат не найден.")
+		return
+	}
+
+	if fileID != "" {
+		if err := b.ResultRepo.SaveScreenshot(resultID, discipline, fileID); err != nil {
+			log.Printf("Ошибка сохранения скриншота: %v", err)
+			b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении скриншота.")
+			return
+		}
+	}
+
+	text, keyboard, err := b.buildScreenshotsMenu(result)
+	if err != nil {
+		log.Printf("Ошибка формирования меню скриншотов: %v", err)
+		b.sendMessage(chatID, "✅ Скриншот сохранен.")
+		return
+	}
+
+	if fileID != "" {
+		text = fmt.Sprintf("✅ Скриншот для '%s' сохранен.\n\n", discipline) + text
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// parseIDArg разбирает числовой аргумент callback-данных с указанным индексом
+func parseIDArg(query *tgbotapi.CallbackQuery, index int) (int, bool) {
+	parts := strings.Split(query.Data, ":")
+	if len(parts) <= index {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(parts[index])
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
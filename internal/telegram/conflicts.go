@@ -0,0 +1,416 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerConflictHandlers регистрирует обработчики разрешения конфликтов мест
+func (b *Bot) registerConflictHandlers() {
+	b.CallbackHandlers["race_conflicts"] = b.callbackRaceConflicts
+	b.CallbackHandlers["conflict_keep"] = b.callbackConflictKeep
+	b.CallbackHandlers["conflict_edit"] = b.callbackConflictEdit
+	b.CallbackHandlers["conflict_set"] = b.callbackConflictSet
+}
+
+// findRaceConflicts проверяет места всех результатов гонки по каждой дисциплине
+func (b *Bot) findRaceConflicts(race *models.Race) ([]models.PlaceConflict, error) {
+	results, err := b.ResultRepo.GetRaceResultsWithDriverNames(race.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSize := b.getRaceFieldSize(race.ID)
+
+	var conflicts []models.PlaceConflict
+
+	for _, discipline := range race.Disciplines {
+		var claims []models.PlaceClaim
+		for _, result := range results {
+			claims = append(claims, models.PlaceClaim{
+				ResultID:   result.ID,
+				DriverID:   result.DriverID,
+				DriverName: result.DriverName,
+				Place:      result.Results[discipline],
+				TimeMs:     result.Times[discipline],
+			})
+		}
+
+		conflicts = append(conflicts, models.FindPlaceConflicts(discipline, claims, fieldSize)...)
+	}
+
+	return conflicts, nil
+}
+
+// takenPlaces возвращает места, занятые в дисциплине, без учета указанных результатов
+func (b *Bot) takenPlaces(raceID int, discipline string, exclude map[int]bool) (map[int]bool, error) {
+	results, err := b.ResultRepo.GetByRaceID(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[int]bool)
+	for _, result := range results {
+		if exclude[result.ID] {
+			continue
+		}
+		if place := result.Results[discipline]; place > 0 {
+			taken[place] = true
+		}
+	}
+
+	return taken, nil
+}
+
+// setResultPlace устанавливает место или статус результата в дисциплине по значению кнопки
+// и пересчитывает очки по правилам сезона
+func (b *Bot) setResultPlace(resultID int, discipline string, value string) (*models.RaceResult, error) {
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("результат %d не найден", resultID)
+	}
+
+	place, status, ok := parsePlaceValue(value, b.getRaceFieldSize(result.RaceID))
+	if !ok {
+		return nil, fmt.Errorf("неверное значение места: %s", value)
+	}
+
+	models.SetDisciplineResult(result.Results, result.Statuses, discipline, place, status)
+
+	rules := b.getScoringRules(result.RaceID)
+	result.TotalScore, err = b.scoreWithPenalties(rules, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.ResultRepo.Update(result); err != nil {
+		return nil, err
+	}
+
+	// Штрафы на позиции сдвигают соседей, поэтому очки остальных результатов гонки тоже пересчитываются
+	if err := b.ResultRepo.RescoreRace(result.RaceID, rules); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildConflictsView формирует экран разрешения конфликтов мест гонки
+func (b *Bot) buildConflictsView(race *models.Race) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	conflicts, err := b.findRaceConflicts(race)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if len(conflicts) == 0 {
+		text := fmt.Sprintf("✅ *Конфликтов в результатах гонки '%s' нет*\n\nГонку можно завершить.", race.Name)
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🏁 Завершить гонку",
+				fmt.Sprintf("complete_race_confirm:%d", race.ID),
+			),
+		))
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔙 Админ-панель",
+				fmt.Sprintf("admin_race_panel:%d", race.ID),
+			),
+		))
+
+		return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+	}
+
+	fieldSize := b.getRaceFieldSize(race.ID)
+
+	text := fmt.Sprintf("⚠️ *Конфликты в результатах гонки '%s'*\n\n", race.Name)
+	text += "Завершение гонки заблокировано, пока места не исправлены.\n"
+	text += "✅ - оставить гонщику место, остальных сдвинуть на ближайшие свободные места\n"
+	text += "✏️ - выбрать место вручную\n\n"
+
+	for _, conflict := range conflicts {
+		var names []string
+		for _, claim := range conflict.Claims {
+			names = append(names, claim.DriverName)
+		}
+
+		if conflict.Duplicate {
+			text += fmt.Sprintf("• *%s*: %s %d место заявили %s\n",
+				conflict.Discipline, getPlaceEmoji(conflict.Place), conflict.Place, strings.Join(names, ", "))
+		} else {
+			text += fmt.Sprintf("• *%s*: %d место при %d участниках у %s\n",
+				conflict.Discipline, conflict.Place, fieldSize, strings.Join(names, ", "))
+		}
+
+		for _, claim := range conflict.Claims {
+			editButton := tgbotapi.NewInlineKeyboardButtonData(
+				"✏️",
+				fmt.Sprintf("conflict_edit:%d:%s", claim.ResultID, conflict.Discipline),
+			)
+
+			if conflict.Duplicate {
+				keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(
+						fmt.Sprintf("✅ %s: %s — %d место", conflict.Discipline, claim.DriverName, conflict.Place),
+						fmt.Sprintf("conflict_keep:%d:%s", claim.ResultID, conflict.Discipline),
+					),
+					editButton,
+				))
+				continue
+			}
+
+			// Невозможное место: предлагаем ближайшее свободное
+			taken, err := b.takenPlaces(race.ID, conflict.Discipline, map[int]bool{claim.ResultID: true})
+			if err != nil {
+				return "", tgbotapi.InlineKeyboardMarkup{}, err
+			}
+
+			row := []tgbotapi.InlineKeyboardButton{}
+			if suggested := models.NearestFreePlace(taken, conflict.Place, fieldSize); suggested > 0 {
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+					fmt.Sprintf("➡️ %s: %s → %d место", conflict.Discipline, claim.DriverName, suggested),
+					fmt.Sprintf("conflict_set:%d:%s:%d", claim.ResultID, conflict.Discipline, suggested),
+				))
+			}
+			keyboard = append(keyboard, append(row, editButton))
+		}
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🔄 Проверить снова",
+			fmt.Sprintf("race_conflicts:%d", race.ID),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🔙 Админ-панель",
+			fmt.Sprintf("admin_race_panel:%d", race.ID),
+		),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// showRaceConflicts отправляет экран разрешения конфликтов мест гонки
+func (b *Bot) showRaceConflicts(chatID int64, race *models.Race) {
+	text, keyboard, err := b.buildConflictsView(race)
+	if err != nil {
+		log.Printf("Ошибка проверки конфликтов гонки %d: %v", race.ID, err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при проверке результатов гонки.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// refreshRaceConflicts обновляет экран конфликтов в исходном сообщении
+func (b *Bot) refreshRaceConflicts(query *tgbotapi.CallbackQuery, raceID int) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	text, keyboard, err := b.buildConflictsView(race)
+	if err != nil {
+		log.Printf("Ошибка проверки конфликтов гонки %d: %v", raceID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при проверке результатов", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackRaceConflicts показывает экран конфликтов (race_conflicts:raceID)
+func (b *Bot) callbackRaceConflicts(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав администратора", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	raceID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", true)
+		return
+	}
+
+	b.refreshRaceConflicts(query, raceID)
+}
+
+// callbackConflictKeep оставляет гонщику заявленное место, а остальных
+// гонщиков с тем же местом сдвигает на ближайшие свободные места (conflict_keep:resultID:discipline)
+func (b *Bot) callbackConflictKeep(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав администратора", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	discipline := parts[2]
+
+	kept, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || kept == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Результат не найден", true)
+		return
+	}
+
+	place := kept.Results[discipline]
+
+	results, err := b.ResultRepo.GetByRaceID(kept.RaceID)
+	if err != nil {
+		log.Printf("Ошибка получения результатов гонки: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении результатов", true)
+		return
+	}
+
+	// Гонщики, заявившие то же место, уступают его
+	displaced := make(map[int]bool)
+	for _, result := range results {
+		if result.ID != resultID && result.Results[discipline] == place {
+			displaced[result.ID] = true
+		}
+	}
+
+	taken, err := b.takenPlaces(kept.RaceID, discipline, displaced)
+	if err != nil {
+		log.Printf("Ошибка получения занятых мест: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении результатов", true)
+		return
+	}
+
+	fieldSize := b.getRaceFieldSize(kept.RaceID)
+
+	for _, result := range results {
+		if !displaced[result.ID] {
+			continue
+		}
+
+		newPlace := models.NearestFreePlace(taken, place+1, fieldSize)
+		if newPlace == 0 {
+			continue
+		}
+		taken[newPlace] = true
+
+		if _, err := b.setResultPlace(result.ID, discipline, strconv.Itoa(newPlace)); err != nil {
+			log.Printf("Ошибка изменения места результата %d: %v", result.ID, err)
+			b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сохранении результата", true)
+			return
+		}
+	}
+
+	// Места завершенной гонки влияют на рейтинг Эло и снимки таблицы
+	b.recalculateRatingsForRace(kept.RaceID)
+
+	b.refreshRaceConflicts(query, kept.RaceID)
+}
+
+// callbackConflictEdit показывает выбор места для результата (conflict_edit:resultID:discipline)
+func (b *Bot) callbackConflictEdit(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав администратора", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	discipline := parts[2]
+
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Результат не найден", true)
+		return
+	}
+
+	driver, err := b.DriverRepo.GetByID(result.DriverID)
+	driverName := "гонщика"
+	if err == nil && driver != nil {
+		driverName = driver.Name
+	}
+
+	text := fmt.Sprintf("Выберите место для *%s* в дисциплине '%s' (сейчас: %s):",
+		driverName, discipline, getResultText(result.Results[discipline], result.Statuses[discipline]))
+
+	keyboard := PlaceButtons(b.getRaceFieldSize(result.RaceID), func(value string) string {
+		return fmt.Sprintf("conflict_set:%d:%s:%s", resultID, discipline, value)
+	})
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🔙 Назад к конфликтам",
+			fmt.Sprintf("race_conflicts:%d", result.RaceID),
+		),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackConflictSet устанавливает место результата (conflict_set:resultID:discipline:place)
+func (b *Bot) callbackConflictSet(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав администратора", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 4 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	discipline := parts[2]
+
+	result, err := b.setResultPlace(resultID, discipline, parts[3])
+	if err != nil {
+		log.Printf("Ошибка изменения места результата %d: %v", resultID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сохранении результата", true)
+		return
+	}
+
+	// Места завершенной гонки влияют на рейтинг Эло и снимки таблицы
+	b.recalculateRatingsForRace(result.RaceID)
+
+	b.refreshRaceConflicts(query, result.RaceID)
+}
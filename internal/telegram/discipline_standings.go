@@ -0,0 +1,153 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerDisciplineStandingsHandlers регистрирует обработчики зачетов по дисциплинам
+func (b *Bot) registerDisciplineStandingsHandlers() {
+	b.CallbackHandlers["discipline_board"] = b.callbackDisciplineBoard
+}
+
+// championNames перечисляет имена чемпионов через запятую
+func championNames(champions []*models.DisciplineStanding) string {
+	names := make([]string, 0, len(champions))
+	for _, champion := range champions {
+		names = append(names, champion.DriverName)
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatDisciplineChampions форматирует чемпионов дисциплин для итогов сезона
+func (b *Bot) formatDisciplineChampions(seasonID int) string {
+	standings, err := b.ResultRepo.GetDisciplineStandings(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения зачетов дисциплин сезона %d: %v", seasonID, err)
+		return ""
+	}
+
+	var lines []string
+	for _, discipline := range models.SortedDisciplines(standings) {
+		champions := models.DisciplineChampions(standings[discipline])
+		if len(champions) == 0 {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("👑 *%s* (%s): %s — %d %s", models.DisciplineTitle(discipline), discipline,
+			championNames(champions), champions[0].Points, pointsWord(champions[0].Points)))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "*Чемпионы дисциплин:*\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// buildDisciplineBoardView формирует обзор зачетов дисциплин (index < 0) или таблицу одной дисциплины
+func (b *Bot) buildDisciplineBoardView(seasonID, index int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	standings, err := b.ResultRepo.GetDisciplineStandings(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	disciplines := models.SortedDisciplines(standings)
+	scope := b.standingsScopeName(seasonID)
+
+	var text string
+
+	if index < 0 || index >= len(disciplines) {
+		text = fmt.Sprintf("🎯 *Зачеты дисциплин: %s*\n\n", scope)
+
+		if len(disciplines) == 0 {
+			text += "Пока нет подтвержденных результатов."
+		}
+
+		for _, discipline := range disciplines {
+			leaders := models.DisciplineChampions(standings[discipline])
+			if len(leaders) == 0 {
+				text += fmt.Sprintf("*%s*: лидера пока нет\n", discipline)
+				continue
+			}
+			text += fmt.Sprintf("*%s*: %s — %d %s\n", discipline, championNames(leaders),
+				leaders[0].Points, pointsWord(leaders[0].Points))
+		}
+
+		if len(disciplines) > 0 {
+			text += "\nВ зачете дисциплины учитываются только очки, набранные в ней. Выберите дисциплину:"
+		}
+	} else {
+		discipline := disciplines[index]
+		text = fmt.Sprintf("🎯 *%s: %s*\n", discipline, scope)
+		text += fmt.Sprintf("Титул: _%s_\n\n", models.DisciplineTitle(discipline))
+
+		for _, standing := range standings[discipline] {
+			text += fmt.Sprintf("%s %d. *%s* — %d %s (🥇 %d, 🏆 %d, стартов %d)\n",
+				getPlaceEmoji(standing.Position), standing.Position, standing.DriverName,
+				standing.Points, pointsWord(standing.Points), standing.Wins, standing.Podiums, standing.Entries)
+		}
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+
+	for i, discipline := range disciplines {
+		label := discipline
+		if i == index {
+			label = "✅ " + label
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("discipline_board:%d:%d", seasonID, i)))
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	if index >= 0 {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("👑 Все лидеры", fmt.Sprintf("discipline_board:%d", seasonID)),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К рейтингу", fmt.Sprintf("leaderboard:%d", seasonID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackDisciplineBoard показывает зачеты дисциплин (discipline_board:seasonID[:index])
+func (b *Bot) callbackDisciplineBoard(query *tgbotapi.CallbackQuery) {
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	index := -1
+	if len(strings.Split(query.Data, ":")) > 2 {
+		index, ok = parseIDArg(query, 2)
+		if !ok {
+			b.answerCallbackQuery(query.ID, "⚠️ Неверная дисциплина", true)
+			return
+		}
+	}
+
+	text, keyboard, err := b.buildDisciplineBoardView(seasonID, index)
+	if err != nil {
+		log.Printf("Ошибка получения зачетов дисциплин: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении зачетов дисциплин", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
@@ -0,0 +1,802 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerDivisionHandlers регистрирует обработчики дивизионов
+func (b *Bot) registerDivisionHandlers() {
+	b.CommandHandlers["divisions"] = b.handleDivisions
+
+	b.CallbackHandlers["divisions"] = b.callbackDivisions
+	b.CallbackHandlers["division_admin"] = b.callbackDivisionAdmin
+	b.CallbackHandlers["division_create"] = b.callbackDivisionCreate
+	b.CallbackHandlers["division_carry"] = b.callbackDivisionCarry
+	b.CallbackHandlers["division_manage"] = b.callbackDivisionManage
+	b.CallbackHandlers["division_add_pick"] = b.callbackDivisionAddPick
+	b.CallbackHandlers["division_add"] = b.callbackDivisionAdd
+	b.CallbackHandlers["division_remove"] = b.callbackDivisionRemove
+	b.CallbackHandlers["division_swap"] = b.callbackDivisionSwap
+	b.CallbackHandlers["division_delete"] = b.callbackDivisionDelete
+	b.CallbackHandlers["division_delete_confirm"] = b.callbackDivisionDeleteConfirm
+	b.CallbackHandlers["race_division"] = b.callbackRaceDivision
+}
+
+// divisionStandings возвращает дивизионы сезона от высшего к низшему и их таблицы
+func (b *Bot) divisionStandings(seasonID int) ([]*models.Division, map[int][]*models.StandingsEntry, error) {
+	divisions, err := b.DivisionRepo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	standings := make(map[int][]*models.StandingsEntry, len(divisions))
+	for _, division := range divisions {
+		table, err := b.DivisionRepo.GetStandings(division.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		standings[division.ID] = table
+	}
+
+	return divisions, standings, nil
+}
+
+// formatDivisionMoves форматирует повышения и понижения между дивизионами
+func formatDivisionMoves(divisions []*models.Division, moves []*models.DivisionMove) string {
+	if len(moves) == 0 {
+		return ""
+	}
+
+	names := make(map[int]string, len(divisions))
+	for _, division := range divisions {
+		names[division.ID] = division.Name
+	}
+
+	text := "*Повышения и понижения:*\n"
+	for _, move := range moves {
+		arrow := "⬇️"
+		if move.Promoted {
+			arrow = "⬆️"
+		}
+		text += fmt.Sprintf("%s %s: %s → %s\n", arrow, move.DriverName, names[move.FromDivisionID], names[move.ToDivisionID])
+	}
+
+	return text
+}
+
+// formatSeasonDivisionMoves форматирует сохраненные переходы между дивизионами завершенного сезона
+func (b *Bot) formatSeasonDivisionMoves(seasonID int) string {
+	divisions, err := b.DivisionRepo.GetBySeason(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов сезона %d: %v", seasonID, err)
+		return ""
+	}
+
+	moves, err := b.DivisionRepo.GetMoves(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения переходов между дивизионами сезона %d: %v", seasonID, err)
+		return ""
+	}
+
+	return formatDivisionMoves(divisions, moves)
+}
+
+// planSeasonDivisionMoves рассчитывает переходы между дивизионами по текущим таблицам сезона
+func (b *Bot) planSeasonDivisionMoves(seasonID int) ([]*models.Division, []*models.DivisionMove, error) {
+	divisions, standings, err := b.divisionStandings(seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return divisions, models.PlanDivisionMoves(divisions, standings), nil
+}
+
+// applyDivisionMoves фиксирует повышения и понижения по итогам завершенного сезона
+func (b *Bot) applyDivisionMoves(seasonID int) {
+	_, moves, err := b.planSeasonDivisionMoves(seasonID)
+	if err != nil {
+		log.Printf("Ошибка расчета переходов между дивизионами сезона %d: %v", seasonID, err)
+		return
+	}
+
+	if err := b.DivisionRepo.SaveMoves(seasonID, moves); err != nil {
+		log.Printf("Ошибка сохранения переходов между дивизионами сезона %d: %v", seasonID, err)
+	}
+}
+
+// carryOverDivisions переносит дивизионы завершенного сезона в новый с учетом переходов
+func (b *Bot) carryOverDivisions(fromSeasonID, toSeasonID int) (int, error) {
+	divisions, err := b.DivisionRepo.GetBySeason(fromSeasonID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(divisions) == 0 {
+		return 0, nil
+	}
+
+	return b.DivisionRepo.CarryOver(fromSeasonID, toSeasonID)
+}
+
+// buildDivisionsView формирует таблицы дивизионов сезона с зонами повышения и понижения
+func (b *Bot) buildDivisionsView(seasonID int, isAdmin bool) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	divisions, standings, err := b.divisionStandings(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	zones := make(map[int]string)
+	for _, move := range models.PlanDivisionMoves(divisions, standings) {
+		zones[move.DriverID] = " ⬇️"
+		if move.Promoted {
+			zones[move.DriverID] = " ⬆️"
+		}
+	}
+
+	text := fmt.Sprintf("🏷 *Дивизионы: %s*\n\n", b.standingsScopeName(seasonID))
+
+	if len(divisions) == 0 {
+		text += "В этом сезоне гонщики не разделены на дивизионы, все гонки открыты для всех."
+	}
+
+	for i, division := range divisions {
+		text += fmt.Sprintf("*%d. %s*\n", division.Tier, division.Name)
+
+		if len(standings[division.ID]) == 0 {
+			text += "Пока нет гонщиков.\n"
+		}
+
+		for _, entry := range standings[division.ID] {
+			text += fmt.Sprintf("%s %d. %s — %d %s%s\n", getPlaceEmoji(entry.Position), entry.Position,
+				entry.DriverName, entry.Points, pointsWord(entry.Points), zones[entry.DriverID])
+		}
+
+		if i+1 < len(divisions) {
+			text += fmt.Sprintf("_По итогам сезона %d худших меняются с %d лучшими дивизиона «%s»_\n",
+				division.SwapCount, division.SwapCount, divisions[i+1].Name)
+		}
+		text += "\n"
+	}
+
+	if len(zones) > 0 {
+		text += "⬆️ — зона повышения, ⬇️ — зона понижения"
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if isAdmin {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⚙️ Управление дивизионами", fmt.Sprintf("division_admin:%d", seasonID)),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К рейтингу", fmt.Sprintf("leaderboard:%d", seasonID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleDivisions обрабатывает команду /divisions - дивизионы активного сезона
+func (b *Bot) handleDivisions(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активного сезона.")
+		return
+	}
+
+	if season == nil {
+		b.sendMessage(chatID, "⚠️ Нет активного сезона.")
+		return
+	}
+
+	text, keyboard, err := b.buildDivisionsView(season.ID, b.IsAdmin(message.From.ID))
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении дивизионов.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackDivisions показывает дивизионы сезона (divisions:seasonID)
+func (b *Bot) callbackDivisions(query *tgbotapi.CallbackQuery) {
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildDivisionsView(seasonID, b.IsAdmin(query.From.ID))
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении дивизионов", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// buildDivisionAdminView формирует список дивизионов сезона для управления
+func (b *Bot) buildDivisionAdminView(seasonID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	divisions, err := b.DivisionRepo.GetBySeason(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("⚙️ *Управление дивизионами: %s*\n\n", b.standingsScopeName(seasonID))
+	text += "Новый дивизион создается уровнем ниже существующих. Гонщик может состоять только в одном дивизионе сезона, " +
+		"а на гонку дивизиона могут зарегистрироваться только его гонщики.\n"
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for _, division := range divisions {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%d. %s", division.Tier, division.Name),
+				fmt.Sprintf("division_manage:%d", division.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("➕ Создать дивизион", fmt.Sprintf("division_create:%d", seasonID)),
+	))
+
+	// Пустой сезон может получить дивизионы прошлого сезона с учетом повышений и понижений
+	if len(divisions) == 0 {
+		sourceID, err := b.DivisionRepo.GetLatestSourceSeason(seasonID)
+		if err != nil {
+			log.Printf("Ошибка поиска сезона с дивизионами: %v", err)
+		} else if sourceID > 0 {
+			keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					fmt.Sprintf("📥 Перенести из «%s»", b.standingsScopeName(sourceID)),
+					fmt.Sprintf("division_carry:%d:%d", seasonID, sourceID),
+				),
+			))
+		}
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К дивизионам", fmt.Sprintf("divisions:%d", seasonID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// showDivisionAdmin обновляет сообщение списком дивизионов для управления
+func (b *Bot) showDivisionAdmin(query *tgbotapi.CallbackQuery, seasonID int) {
+	text, keyboard, err := b.buildDivisionAdminView(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов сезона: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении дивизионов", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackDivisionAdmin показывает список дивизионов сезона для управления (division_admin:seasonID)
+func (b *Bot) callbackDivisionAdmin(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	b.showDivisionAdmin(query, seasonID)
+}
+
+// callbackDivisionCreate запрашивает название нового дивизиона (division_create:seasonID)
+func (b *Bot) callbackDivisionCreate(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	b.StateManager.SetState(userID, "division_name", map[string]interface{}{
+		"season_id": seasonID,
+	})
+
+	b.sendMessage(query.Message.Chat.ID, "Введите название дивизиона (от 2 до 30 символов):")
+}
+
+// handleDivisionName обрабатывает ввод названия дивизиона и создает дивизион
+func (b *Bot) handleDivisionName(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	name := strings.TrimSpace(message.Text)
+	if err := models.ValidateDivisionName(name); err != nil {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ %v. Пожалуйста, введите корректное название:", err))
+		return
+	}
+
+	seasonID, _ := state.ContextData["season_id"].(int)
+
+	divisionID, err := b.DivisionRepo.Create(seasonID, name)
+	if err != nil {
+		log.Printf("Ошибка создания дивизиона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при создании дивизиона.")
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	text, keyboard, err := b.buildDivisionManageView(divisionID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+		b.sendMessage(chatID, fmt.Sprintf("✅ Дивизион '%s' создан.", name))
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, fmt.Sprintf("✅ Дивизион создан!\n\n%s", text), keyboard)
+}
+
+// callbackDivisionCarry переносит дивизионы прошлого сезона (division_carry:seasonID:sourceSeasonID)
+func (b *Bot) callbackDivisionCarry(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	sourceID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	count, err := b.carryOverDivisions(sourceID, seasonID)
+	if err != nil {
+		log.Printf("Ошибка переноса дивизионов из сезона %d в сезон %d: %v", sourceID, seasonID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Не удалось перенести дивизионы", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, fmt.Sprintf("✅ Дивизионы перенесены, гонщиков: %d", count), false)
+	b.showDivisionAdmin(query, seasonID)
+}
+
+// buildDivisionManageView формирует карточку дивизиона с кнопками управления
+func (b *Bot) buildDivisionManageView(divisionID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	division, err := b.DivisionRepo.GetByID(divisionID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if division == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("дивизион %d не найден", divisionID)
+	}
+
+	members, err := b.DivisionRepo.GetMembers(divisionID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("*%d. %s*\n", division.Tier, division.Name)
+	text += fmt.Sprintf("Гонщиков: %d\n", len(members))
+	text += fmt.Sprintf("Обмен с дивизионом ниже: %d\n\n", division.SwapCount)
+
+	if len(members) == 0 {
+		text += "В дивизионе пока нет гонщиков."
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for _, member := range members {
+		text += fmt.Sprintf("• %s\n", member.Name)
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("❌ %s", member.Name),
+				fmt.Sprintf("division_remove:%d:%d", divisionID, member.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➕ Добавить гонщика", fmt.Sprintf("division_add_pick:%d", divisionID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➖ Обмен", fmt.Sprintf("division_swap:%d:%d", divisionID, division.SwapCount-1)),
+			tgbotapi.NewInlineKeyboardButtonData("➕ Обмен", fmt.Sprintf("division_swap:%d:%d", divisionID, division.SwapCount+1)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить дивизион", fmt.Sprintf("division_delete:%d", divisionID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К списку дивизионов", fmt.Sprintf("division_admin:%d", division.SeasonID)),
+		),
+	)
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// showDivisionManage обновляет сообщение карточкой дивизиона
+func (b *Bot) showDivisionManage(query *tgbotapi.CallbackQuery, divisionID int) {
+	text, keyboard, err := b.buildDivisionManageView(divisionID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Дивизион не найден", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackDivisionManage показывает карточку дивизиона (division_manage:divisionID)
+func (b *Bot) callbackDivisionManage(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	b.showDivisionManage(query, divisionID)
+}
+
+// callbackDivisionAddPick показывает гонщиков без дивизиона в сезоне (division_add_pick:divisionID)
+func (b *Bot) callbackDivisionAddPick(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	division, err := b.DivisionRepo.GetByID(divisionID)
+	if err != nil || division == nil {
+		log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Дивизион не найден", true)
+		return
+	}
+
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка гонщиков", true)
+		return
+	}
+
+	assigned, err := b.DivisionRepo.GetDriverDivisions(division.SeasonID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении дивизионов гонщиков", true)
+		return
+	}
+
+	var free []*models.Driver
+	for _, driver := range drivers {
+		if _, ok := assigned[driver.ID]; !ok {
+			free = append(free, driver)
+		}
+	}
+
+	if len(free) == 0 {
+		b.answerCallbackQuery(query.ID, "ℹ️ Все гонщики уже распределены по дивизионам", true)
+		return
+	}
+
+	keyboard := DriverPickerRows(free, fmt.Sprintf("division_add:%d", divisionID))
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("division_manage:%d", divisionID)),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		fmt.Sprintf("Выберите гонщика для дивизиона *%s*:", division.Name), tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackDivisionAdd добавляет гонщика в дивизион (division_add:divisionID:driverID)
+func (b *Bot) callbackDivisionAdd(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	driverID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	added, err := b.DivisionRepo.AddMember(divisionID, driverID)
+	if err != nil {
+		log.Printf("Ошибка добавления гонщика %d в дивизион %d: %v", driverID, divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при добавлении гонщика", true)
+		return
+	}
+
+	if !added {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонщик уже состоит в дивизионе этого сезона", true)
+	} else {
+		b.answerCallbackQuery(query.ID, "✅ Гонщик добавлен в дивизион", false)
+	}
+
+	b.showDivisionManage(query, divisionID)
+}
+
+// callbackDivisionRemove исключает гонщика из дивизиона (division_remove:divisionID:driverID)
+func (b *Bot) callbackDivisionRemove(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	driverID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	if err := b.DivisionRepo.RemoveMember(divisionID, driverID); err != nil {
+		log.Printf("Ошибка исключения гонщика %d из дивизиона %d: %v", driverID, divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при исключении гонщика", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Гонщик исключен из дивизиона", false)
+	b.showDivisionManage(query, divisionID)
+}
+
+// callbackDivisionSwap задает число гонщиков для обмена с дивизионом ниже (division_swap:divisionID:count)
+func (b *Bot) callbackDivisionSwap(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	count, ok := parseIDArg(query, 2)
+	if !ok || count < 1 || count > models.MaxDivisionSwapCount {
+		b.answerCallbackQuery(query.ID, fmt.Sprintf("⚠️ Обмен возможен от 1 до %d гонщиков", models.MaxDivisionSwapCount), true)
+		return
+	}
+
+	if err := b.DivisionRepo.SetSwapCount(divisionID, count); err != nil {
+		log.Printf("Ошибка изменения обмена дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при изменении обмена", true)
+		return
+	}
+
+	b.showDivisionManage(query, divisionID)
+}
+
+// callbackDivisionDelete запрашивает подтверждение удаления дивизиона (division_delete:divisionID)
+func (b *Bot) callbackDivisionDelete(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	division, err := b.DivisionRepo.GetByID(divisionID)
+	if err != nil || division == nil {
+		log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Дивизион не найден", true)
+		return
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Да, удалить", fmt.Sprintf("division_delete_confirm:%d", divisionID)),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", fmt.Sprintf("division_manage:%d", divisionID)),
+		),
+	)
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		fmt.Sprintf("Удалить дивизион *%s*? Его гонки станут открытыми для всех гонщиков.", division.Name), keyboard)
+}
+
+// callbackDivisionDeleteConfirm удаляет дивизион (division_delete_confirm:divisionID)
+func (b *Bot) callbackDivisionDeleteConfirm(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления дивизионами", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	division, err := b.DivisionRepo.GetByID(divisionID)
+	if err != nil || division == nil {
+		log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Дивизион не найден", true)
+		return
+	}
+
+	if err := b.DivisionRepo.Delete(divisionID); err != nil {
+		log.Printf("Ошибка удаления дивизиона %d: %v", divisionID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при удалении дивизиона", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Дивизион удален", false)
+	b.showDivisionAdmin(query, division.SeasonID)
+}
+
+// sendRaceDivisionPicker предлагает выбрать дивизион новой гонки, если в сезоне есть дивизионы
+func (b *Bot) sendRaceDivisionPicker(chatID int64, race *models.Race) {
+	divisions, err := b.DivisionRepo.GetBySeason(race.SeasonID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизионов сезона %d: %v", race.SeasonID, err)
+		return
+	}
+
+	if len(divisions) == 0 {
+		return
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, division := range divisions {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🏷 %s", division.Name),
+				fmt.Sprintf("race_division:%d:%d", race.ID, division.ID),
+			),
+		))
+	}
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🌐 Открыта для всех", fmt.Sprintf("race_division:%d:0", race.ID)),
+	))
+
+	b.sendMessageWithKeyboard(chatID, fmt.Sprintf("🏷 Выберите дивизион гонки '%s':", race.Name),
+		tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackRaceDivision привязывает гонку к дивизиону (race_division:raceID:divisionID, 0 - открытая гонка)
+func (b *Bot) callbackRaceDivision(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для изменения гонки", true)
+		return
+	}
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", true)
+		return
+	}
+
+	divisionID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID дивизиона", true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		log.Printf("Ошибка получения гонки %d: %v", raceID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	label := "открыта для всех"
+	if divisionID > 0 {
+		division, err := b.DivisionRepo.GetByID(divisionID)
+		if err != nil || division == nil || division.SeasonID != race.SeasonID {
+			log.Printf("Ошибка получения дивизиона %d: %v", divisionID, err)
+			b.answerCallbackQuery(query.ID, "⚠️ Дивизион не найден", true)
+			return
+		}
+		label = "дивизион " + division.Name
+	}
+
+	if err := b.DivisionRepo.SetRaceDivision(raceID, divisionID); err != nil {
+		log.Printf("Ошибка изменения дивизиона гонки %d: %v", raceID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при изменении дивизиона гонки", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Дивизион гонки сохранен", false)
+	b.editMessage(chatID, query.Message.MessageID, fmt.Sprintf("🏷 Гонка '%s': %s.", race.Name, label))
+}
+
+// formatRaceDivision форматирует строку дивизиона для карточки гонки
+func (b *Bot) formatRaceDivision(raceID int) string {
+	division, err := b.DivisionRepo.GetRaceDivision(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения дивизиона гонки %d: %v", raceID, err)
+		return ""
+	}
+
+	if division == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("🏷 Дивизион: %s\n", division.Name)
+}
+
+// checkRaceDivision проверяет, что гонщик может зарегистрироваться на гонку дивизиона.
+// Возвращает текст отказа или пустую строку.
+func (b *Bot) checkRaceDivision(race *models.Race, driverID int) (string, error) {
+	division, err := b.DivisionRepo.GetRaceDivision(race.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if division == nil {
+		return "", nil
+	}
+
+	divisions, err := b.DivisionRepo.GetDriverDivisions(race.SeasonID)
+	if err != nil {
+		return "", err
+	}
+
+	own, ok := divisions[driverID]
+	if !ok {
+		return fmt.Sprintf("⛔ Гонка '%s' проводится для дивизиона «%s», а вы не состоите ни в одном дивизионе сезона. Обратитесь к администратору.",
+			race.Name, division.Name), nil
+	}
+
+	if own.ID != division.ID {
+		return fmt.Sprintf("⛔ Гонка '%s' проводится для дивизиона «%s», а вы выступаете в дивизионе «%s».",
+			race.Name, division.Name, own.Name), nil
+	}
+
+	return "", nil
+}
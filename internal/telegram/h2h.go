@@ -0,0 +1,321 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	h2hRecentMeetings = 5
+	h2hAllClasses     = "all"
+)
+
+// registerHeadToHeadHandlers регистрирует обработчики сравнения двух гонщиков
+func (b *Bot) registerHeadToHeadHandlers() {
+	b.CommandHandlers["h2h"] = b.handleHeadToHead
+
+	b.CallbackHandlers["h2h"] = b.callbackHeadToHead
+	b.CallbackHandlers["h2h_first"] = b.callbackHeadToHeadFirst
+	b.CallbackHandlers["h2h_view"] = b.callbackHeadToHeadView
+	b.CallbackHandlers["h2h_season"] = b.callbackHeadToHeadSeason
+}
+
+// buildHeadToHeadFirstPicker формирует выбор первого гонщика
+func (b *Bot) buildHeadToHeadFirstPicker() (string, tgbotapi.InlineKeyboardMarkup, error) {
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if len(drivers) < 2 {
+		return "⚠️ Для сравнения нужно хотя бы два зарегистрированных гонщика.",
+			tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+			)), nil
+	}
+
+	keyboard := DriverPickerRows(drivers, "h2h_first")
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+	))
+
+	return "⚔️ *Очные встречи*\n\nВыберите первого гонщика:", tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleHeadToHead обрабатывает команду /h2h
+func (b *Bot) handleHeadToHead(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	text, keyboard, err := b.buildHeadToHeadFirstPicker()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении списка гонщиков.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackHeadToHead показывает выбор первого гонщика (h2h)
+func (b *Bot) callbackHeadToHead(query *tgbotapi.CallbackQuery) {
+	text, keyboard, err := b.buildHeadToHeadFirstPicker()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка гонщиков", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackHeadToHeadFirst показывает выбор соперника (h2h_first:driverID)
+func (b *Bot) callbackHeadToHeadFirst(query *tgbotapi.CallbackQuery) {
+	firstID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка гонщиков", true)
+		return
+	}
+
+	var firstName string
+	var opponents []*models.Driver
+	for _, driver := range drivers {
+		if driver.ID == firstID {
+			firstName = driver.Name
+			continue
+		}
+		opponents = append(opponents, driver)
+	}
+
+	if firstName == "" {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонщик не найден", true)
+		return
+	}
+
+	keyboard := DriverPickerRows(opponents, fmt.Sprintf("h2h_view:%d", firstID))
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", "h2h"),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		fmt.Sprintf("⚔️ *Очные встречи*\n\nПервый гонщик: *%s*\nВыберите соперника:", firstName),
+		tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// parseHeadToHeadArgs разбирает callback-данные вида "prefix:firstID:secondID[:seasonID[:class]]"
+func parseHeadToHeadArgs(query *tgbotapi.CallbackQuery) (firstID, secondID, seasonID int, carClass string, ok bool) {
+	firstID, ok = parseIDArg(query, 1)
+	if !ok {
+		return
+	}
+	secondID, ok = parseIDArg(query, 2)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) > 3 {
+		seasonID, ok = parseIDArg(query, 3)
+		if !ok {
+			return
+		}
+	}
+
+	carClass = h2hAllClasses
+	if len(parts) > 4 {
+		carClass = parts[4]
+	}
+
+	ok = carClass == h2hAllClasses || models.GetCarClassByLetter(carClass) != nil
+	return
+}
+
+// buildHeadToHeadView формирует сравнение двух гонщиков с фильтрами по сезону и классу
+func (b *Bot) buildHeadToHeadView(firstID, secondID, seasonID int, carClass string) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	first, err := b.DriverRepo.GetByID(firstID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	second, err := b.DriverRepo.GetByID(secondID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	if first == nil || second == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("гонщик %d или %d не найден", firstID, secondID)
+	}
+
+	classFilter := carClass
+	if classFilter == h2hAllClasses {
+		classFilter = ""
+	}
+
+	meetings, err := b.ResultRepo.GetHeadToHead(firstID, secondID, seasonID, classFilter)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("⚔️ *%s vs %s*\n", first.Name, second.Name)
+
+	seasonName := "все сезоны"
+	if seasonID > 0 {
+		seasonName = b.standingsScopeName(seasonID)
+	}
+	className := "все классы"
+	if classFilter != "" {
+		className = "класс " + classFilter
+	}
+	text += fmt.Sprintf("Фильтр: %s, %s\n\n", seasonName, className)
+
+	if len(meetings) == 0 {
+		text += "Гонщики еще не встречались в подтвержденных результатах."
+	} else {
+		summary := models.SummarizeHeadToHead(meetings)
+
+		text += fmt.Sprintf("🏁 *Встреч:* %d\n", summary.Meetings)
+		text += fmt.Sprintf("🏆 *Больше очков в гонке:* %d – %d\n", summary.FirstRaceWins, summary.SecondRaceWins)
+		text += fmt.Sprintf("💯 *Очки:* %d – %d (разница %+d)\n", summary.FirstPoints, summary.SecondPoints,
+			summary.FirstPoints-summary.SecondPoints)
+		text += fmt.Sprintf("⚖️ *Выиграно дисциплин:* %d – %d\n", summary.FirstDiscipline, summary.SecondDiscipline)
+
+		if len(summary.Disciplines) > 0 {
+			text += "\n*По дисциплинам:*\n"
+			for _, d := range summary.Disciplines {
+				text += fmt.Sprintf("• %s: %d – %d", d.Discipline, d.FirstWins, d.SecondWins)
+				if d.Draws > 0 {
+					text += fmt.Sprintf(" (ничьих: %d)", d.Draws)
+				}
+				text += "\n"
+			}
+		}
+
+		text += "\n*Последние встречи:*\n"
+		for i, meeting := range meetings {
+			if i == h2hRecentMeetings {
+				break
+			}
+
+			mark := "🤝"
+			if meeting.First.TotalScore > meeting.Second.TotalScore {
+				mark = "⬅️"
+			} else if meeting.First.TotalScore < meeting.Second.TotalScore {
+				mark = "➡️"
+			}
+
+			text += fmt.Sprintf("%s %s (%s, %s): %d – %d\n", mark, meeting.RaceName, b.formatDate(meeting.RaceDate),
+				meeting.CarClass, meeting.First.TotalScore, meeting.Second.TotalScore)
+		}
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	// Фильтр по классу: "все" и классы по четыре в ряд
+	classes := []string{h2hAllClasses}
+	for _, class := range models.CarClasses {
+		classes = append(classes, class.Letter)
+	}
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, class := range classes {
+		label := class
+		if class == h2hAllClasses {
+			label = "Все"
+		}
+		if class == carClass {
+			label = "✅ " + label
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label,
+			fmt.Sprintf("h2h_view:%d:%d:%d:%s", firstID, secondID, seasonID, class)))
+		if len(row) == 4 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	keyboard = append(keyboard,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("📅 Сезон: %s", seasonName),
+				fmt.Sprintf("h2h_season:%d:%d:%d:%s", firstID, secondID, seasonID, carClass)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔄 Поменять местами",
+				fmt.Sprintf("h2h_view:%d:%d:%d:%s", secondID, firstID, seasonID, carClass)),
+			tgbotapi.NewInlineKeyboardButtonData("👥 Другие гонщики", "h2h"),
+		),
+	)
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackHeadToHeadView показывает сравнение гонщиков (h2h_view:firstID:secondID[:seasonID[:class]])
+func (b *Bot) callbackHeadToHeadView(query *tgbotapi.CallbackQuery) {
+	firstID, secondID, seasonID, carClass, ok := parseHeadToHeadArgs(query)
+	if !ok || firstID == secondID {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	text, keyboard, err := b.buildHeadToHeadView(firstID, secondID, seasonID, carClass)
+	if err != nil {
+		log.Printf("Ошибка сравнения гонщиков %d и %d: %v", firstID, secondID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сравнении гонщиков", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackHeadToHeadSeason показывает выбор сезона для сравнения (h2h_season:firstID:secondID:seasonID:class)
+func (b *Bot) callbackHeadToHeadSeason(query *tgbotapi.CallbackQuery) {
+	firstID, secondID, seasonID, carClass, ok := parseHeadToHeadArgs(query)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasons, err := b.SeasonRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения списка сезонов: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка сезонов", true)
+		return
+	}
+
+	allText := "📊 Все сезоны"
+	if seasonID == 0 {
+		allText = "✅ " + allText
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(allText,
+			fmt.Sprintf("h2h_view:%d:%d:0:%s", firstID, secondID, carClass)),
+	))
+
+	for _, season := range seasons {
+		seasonText := season.Name
+		if season.ID == seasonID {
+			seasonText = "✅ " + seasonText
+		}
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(seasonText,
+				fmt.Sprintf("h2h_view:%d:%d:%d:%s", firstID, secondID, season.ID, carClass)),
+		))
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		"📅 Выберите сезон для сравнения:", tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
@@ -277,18 +277,20 @@ func (b *Bot) startAddRaceResult(chatID, userID int64, raceID int) {
 	b.sendMessage(chatID, "Введите номер вашей машины:")
 }
 
-// getCarPlaceEmoji возвращает эмодзи для места в гонке
-func getCarPlaceEmoji(place int) string {
-	switch place {
-	case 1:
-		return "🥇"
-	case 2:
-		return "🥈"
-	case 3:
-		return "🥉"
-	default:
-		return "➖"
+// getRaceFieldSize возвращает количество участников гонки для выбора места.
+// Если на гонку никто не зарегистрирован, используется размер подиума.
+func (b *Bot) getRaceFieldSize(raceID int) int {
+	size, err := b.RaceRepo.GetFieldSize(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения количества участников гонки %d: %v", raceID, err)
+		return defaultFieldSize
+	}
+
+	if size < defaultFieldSize {
+		return defaultFieldSize
 	}
+
+	return size
 }
 
 // parseDate парсит строку даты из формата ДД.ММ.ГГГГ
@@ -296,6 +298,20 @@ func parseDate(dateStr string) (time.Time, error) {
 	return time.Parse("02.01.2006", dateStr)
 }
 
+// photoFileIDOrSkip возвращает ID самого крупного фото из сообщения или пустую строку,
+// если пользователь пропустил шаг символом '-'. ok = false, если сообщение не подходит.
+func photoFileIDOrSkip(message *tgbotapi.Message) (fileID string, ok bool) {
+	if message.Text == "-" {
+		return "", true
+	}
+
+	if len(message.Photo) > 0 {
+		return message.Photo[len(message.Photo)-1].FileID, true
+	}
+
+	return "", false
+}
+
 // Переименуем обработчики для избежания конфликтов с handlers_car.go
 func (b *Bot) handleResultCarNumber(message *tgbotapi.Message, state models.UserState) {
 	userID := message.From.ID
@@ -344,15 +360,8 @@ func (b *Bot) handleResultCarPhoto(message *tgbotapi.Message, state models.UserS
 	userID := message.From.ID
 	chatID := message.Chat.ID
 
-	var photoURL string
-
-	if message.Text == "-" {
-		photoURL = ""
-	} else if message.Photo != nil && len(message.Photo) > 0 {
-		// Получаем ID фото для сохранения
-		photo := message.Photo[len(message.Photo)-1]
-		photoURL = photo.FileID
-	} else {
+	photoURL, ok := photoFileIDOrSkip(message)
+	if !ok {
 		b.sendMessage(chatID, "⚠️ Пожалуйста, отправьте фото или '-' для пропуска.")
 		return
 	}
@@ -372,24 +381,35 @@ func (b *Bot) handleResultCarPhoto(message *tgbotapi.Message, state models.UserS
 		return
 	}
 
+	// Места в судейских дисциплинах определяются голосованием, гонщик их не вводит
+	disciplines := selfReportedDisciplines(race.Disciplines)
+	if len(disciplines) == 0 {
+		b.submitJudgedOnlyResult(chatID, userID, race,
+			state.ContextData["car_number"].(int), state.ContextData["car_name"].(string), photoURL)
+		return
+	}
+
 	// Сохраняем данные и переходим к вводу результатов первой дисциплины
 	b.StateManager.SetState(userID, "add_result_discipline", map[string]interface{}{
 		"race_id":     raceID,
 		"car_number":  state.ContextData["car_number"],
 		"car_name":    state.ContextData["car_name"],
 		"car_photo":   photoURL,
-		"disciplines": race.Disciplines,
+		"disciplines": disciplines,
 		"current_idx": 0,
 		"results":     make(map[string]int),
+		"times":       make(map[string]int),
+		"statuses":    make(map[string]string),
 	})
 
 	// Запрашиваем результат первой дисциплины
-	disciplineName := race.Disciplines[0]
-	keyboard := PlacesKeyboard(disciplineName)
+	disciplineName := disciplines[0]
+	keyboard := PlacesKeyboard(disciplineName, b.getRaceFieldSize(raceID))
 
 	b.sendMessageWithKeyboard(
 		chatID,
-		fmt.Sprintf("Выберите ваше место в дисциплине '%s':", disciplineName),
+		fmt.Sprintf("%sВыберите ваше место в дисциплине '%s':%s",
+			judgedDisciplinesNote(race), disciplineName, disciplineTimeHint(disciplineName)),
 		keyboard,
 	)
 }
@@ -742,25 +762,35 @@ func (b *Bot) handleAddResult(message *tgbotapi.Message) {
 		return
 	}
 
+	// Places in judged disciplines come from the vote, drivers don't enter them
+	disciplines := selfReportedDisciplines(activeRace.Disciplines)
+	if len(disciplines) == 0 {
+		b.submitJudgedOnlyResult(chatID, userID, activeRace, assignment.AssignmentNumber,
+			assignment.Car.Name+" ("+assignment.Car.Year+")", assignment.Car.ImageURL)
+		return
+	}
+
 	// Set state for adding result, pre-filling car info
 	b.StateManager.SetState(userID, "add_result_discipline", map[string]interface{}{
 		"race_id":     activeRace.ID,
 		"car_number":  assignment.AssignmentNumber,
 		"car_name":    assignment.Car.Name + " (" + assignment.Car.Year + ")",
 		"car_photo":   assignment.Car.ImageURL,
-		"disciplines": activeRace.Disciplines,
+		"disciplines": disciplines,
 		"current_idx": 0,
 		"results":     make(map[string]int),
+		"times":       make(map[string]int),
+		"statuses":    make(map[string]string),
 	})
 
 	// Ask for first discipline result
-	disciplineName := activeRace.Disciplines[0]
-	keyboard := PlacesKeyboard(disciplineName)
+	disciplineName := disciplines[0]
+	keyboard := PlacesKeyboard(disciplineName, b.getRaceFieldSize(activeRace.ID))
 
 	b.sendMessageWithKeyboard(
 		chatID,
-		fmt.Sprintf("Ввод результатов для гонки '%s'.\n\nВыберите ваше место в дисциплине '%s':",
-			activeRace.Name, disciplineName),
+		fmt.Sprintf("Ввод результатов для гонки '%s'.\n\n%sВыберите ваше место в дисциплине '%s':%s",
+			activeRace.Name, judgedDisciplinesNote(activeRace), disciplineName, disciplineTimeHint(disciplineName)),
 		keyboard,
 	)
 }
@@ -770,21 +800,29 @@ func (b *Bot) handleResultDiscipline(message *tgbotapi.Message, state models.Use
 	userID := message.From.ID
 	chatID := message.Chat.ID
 
-	// Check valid place
-	place, err := strconv.Atoi(message.Text)
-	if err != nil || place < 0 || place > 3 {
-		b.sendMessage(chatID, "⚠️ Пожалуйста, введите число от 0 до 3 (0 - не участвовал, 1-3 - место).")
-		return
-	}
-
 	// Get state data
 	disciplines := state.ContextData["disciplines"].([]string)
 	currentIdx := state.ContextData["current_idx"].(int)
 	results := state.ContextData["results"].(map[string]int)
+	times := stateTimes(state)
+	statuses := stateStatuses(state)
+	currentDiscipline := disciplines[currentIdx]
+
+	// Check valid place or time
+	fieldSize := b.getRaceFieldSize(state.ContextData["race_id"].(int))
+	place, timeMs, status, ok := parseDisciplineInput(message.Text, currentDiscipline, fieldSize)
+	if !ok {
+		b.sendMessage(chatID, disciplineInputError(currentDiscipline, fieldSize))
+		return
+	}
 
 	// Save current discipline result
-	currentDiscipline := disciplines[currentIdx]
-	results[currentDiscipline] = place
+	models.SetDisciplineResult(results, statuses, currentDiscipline, place, status)
+	if timeMs > 0 {
+		times[currentDiscipline] = timeMs
+	} else {
+		delete(times, currentDiscipline)
+	}
 
 	// Move to next discipline or finish
 	currentIdx++
@@ -799,15 +837,17 @@ func (b *Bot) handleResultDiscipline(message *tgbotapi.Message, state models.Use
 			"disciplines": disciplines,
 			"current_idx": currentIdx,
 			"results":     results,
+			"times":       times,
+			"statuses":    statuses,
 		})
 
 		// Ask for next discipline
 		disciplineName := disciplines[currentIdx]
-		keyboard := PlacesKeyboard(disciplineName)
+		keyboard := PlacesKeyboard(disciplineName, fieldSize)
 
 		b.sendMessageWithKeyboard(
 			chatID,
-			fmt.Sprintf("Выберите ваше место в дисциплине '%s':", disciplineName),
+			fmt.Sprintf("Выберите ваше место в дисциплине '%s':%s", disciplineName, disciplineTimeHint(disciplineName)),
 			keyboard,
 		)
 	} else {
@@ -825,21 +865,11 @@ func (b *Bot) handleResultDiscipline(message *tgbotapi.Message, state models.Use
 			return
 		}
 
-		// Calculate total score
-		totalScore := 0
-		for _, place := range results {
-			switch place {
-			case 1:
-				totalScore += 3
-			case 2:
-				totalScore += 2
-			case 3:
-				totalScore += 1
-			}
-		}
+		raceID := state.ContextData["race_id"].(int)
+		rules := b.getScoringRules(raceID)
 
 		// Check if driver used reroll for this race
-		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(state.ContextData["race_id"].(int), driver.ID)
+		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(raceID, driver.ID)
 		if err != nil {
 			log.Printf("Ошибка проверки статуса реролла: %v", err)
 			rerollUsed = false // Assume not used if error
@@ -848,28 +878,33 @@ func (b *Bot) handleResultDiscipline(message *tgbotapi.Message, state models.Use
 		// Apply reroll penalty if used
 		rerollPenalty := 0
 		if rerollUsed {
-			rerollPenalty = 1
-			totalScore -= rerollPenalty
+			rerollPenalty = rules.RerollPenalty
 		}
 
+		// Calculate total score by season rules
+		totalScore := rules.CalculateTotalScore(results, statuses, rerollPenalty)
+
 		// Create race result
 		result := &models.RaceResult{
-			RaceID:        state.ContextData["race_id"].(int),
-			DriverID:      driver.ID,
-			CarNumber:     state.ContextData["car_number"].(int),
-			CarName:       state.ContextData["car_name"].(string),
-			CarPhotoURL:   state.ContextData["car_photo"].(string),
-			Results:       results,
-			TotalScore:    totalScore,
-			RerollPenalty: rerollPenalty,
+			RaceID:             state.ContextData["race_id"].(int),
+			DriverID:           driver.ID,
+			CarNumber:          state.ContextData["car_number"].(int),
+			CarName:            state.ContextData["car_name"].(string),
+			CarPhotoURL:        state.ContextData["car_photo"].(string),
+			Results:            results,
+			TotalScore:         totalScore,
+			RerollPenalty:      rerollPenalty,
+			Times:              times,
+			Statuses:           statuses,
+			ConfirmationStatus: models.ResultStatusPending,
 		}
 
 		// Save result to DB
-		var _ int
+		var resultID int
 		if rerollPenalty > 0 {
-			_, err = b.ResultRepo.CreateWithRerollPenalty(result)
+			resultID, err = b.ResultRepo.CreateWithRerollPenalty(result)
 		} else {
-			_, err = b.ResultRepo.Create(result)
+			resultID, err = b.ResultRepo.Create(result)
 		}
 
 		if err != nil {
@@ -878,19 +913,25 @@ func (b *Bot) handleResultDiscipline(message *tgbotapi.Message, state models.Use
 			return
 		}
 
+		// Places in timed and judged disciplines are derived from the times and votes of all drivers
+		totalScore = b.finalizeResultPlaces(resultID, result)
+
 		// Clear state
 		b.StateManager.ClearState(userID)
 
 		// Format success message with penalties
 		successMsg := fmt.Sprintf("✅ Результаты успешно сохранены!")
 		if rerollPenalty > 0 {
-			successMsg += fmt.Sprintf("\n\n⚠️ Учтен штраф -%d балл за реролл машины.", rerollPenalty)
+			successMsg += fmt.Sprintf("\n\n⚠️ Учтен штраф -%d %s за реролл машины.", rerollPenalty, pointsWord(rerollPenalty))
 		}
 		successMsg += fmt.Sprintf("\n\nВы набрали %d очков в этой гонке.", totalScore)
 		b.sendMessage(chatID, successMsg)
 
 		// Show race results
 		b.showRaceResults(chatID, result.RaceID)
+
+		// Offer screenshots and queue the result for confirmation
+		b.onResultSubmitted(chatID, resultID)
 	}
 }
 
@@ -1119,9 +1160,10 @@ func showCarForRace(b *Bot, chatID int64, raceID int, driverID int) {
 
 		// Add reroll button if not used yet
 		if !rerollUsed {
+			penalty := b.getScoringRules(raceID).RerollPenalty
 			keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData(
-					"🎲 Реролл (-1 балл)",
+					fmt.Sprintf("🎲 Реролл (-%d %s)", penalty, pointsWord(penalty)),
 					fmt.Sprintf("reroll_car:%d", raceID),
 				),
 			))
@@ -1469,6 +1511,7 @@ func (b *Bot) callbackAdminAddResult(query *tgbotapi.CallbackQuery) {
 		"disciplines": race.Disciplines,
 		"current_idx": 0,
 		"results":     make(map[string]int),
+		"statuses":    make(map[string]string),
 	})
 
 	// Format message
@@ -1483,7 +1526,8 @@ func (b *Bot) callbackAdminAddResult(query *tgbotapi.CallbackQuery) {
     `, raceID, driverID).Scan(&rerollUsed)
 
 	if err == nil && rerollUsed {
-		text += "⚠️ *Был использован реролл* (-1 балл к результату)\n\n"
+		penalty := b.getScoringRules(raceID).RerollPenalty
+		text += fmt.Sprintf("⚠️ *Был использован реролл* (-%d %s к результату)\n\n", penalty, pointsWord(penalty))
 	}
 
 	// First discipline
@@ -1491,34 +1535,7 @@ func (b *Bot) callbackAdminAddResult(query *tgbotapi.CallbackQuery) {
 	text += fmt.Sprintf("Выберите место в дисциплине '*%s*':", disciplineName)
 
 	// Create place selection keyboard
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥇 1 место",
-				fmt.Sprintf("admin_select_place:%d:%d:%s:1", raceID, driverID, disciplineName),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥈 2 место",
-				fmt.Sprintf("admin_select_place:%d:%d:%s:2", raceID, driverID, disciplineName),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥉 3 место",
-				fmt.Sprintf("admin_select_place:%d:%d:%s:3", raceID, driverID, disciplineName),
-			),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"❌ Не участвовал",
-				fmt.Sprintf("admin_select_place:%d:%d:%s:0", raceID, driverID, disciplineName),
-			),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🔙 Отмена",
-				fmt.Sprintf("admin_edit_results_menu:%d", raceID),
-			),
-		),
-	)
+	keyboard := AdminSelectPlaceKeyboard(raceID, driverID, disciplineName, b.getRaceFieldSize(raceID))
 
 	// Send message with keyboard
 	b.sendMessageWithKeyboard(chatID, text, keyboard)
@@ -1559,8 +1576,8 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 
 	disciplineName := parts[3]
 
-	place, err := strconv.Atoi(parts[4])
-	if err != nil || place < 0 || place > 3 {
+	place, status, ok := parsePlaceValue(parts[4], b.getRaceFieldSize(raceID))
+	if !ok {
 		b.answerCallbackQuery(query.ID, "⚠️ Неверное значение места", true)
 		return
 	}
@@ -1574,7 +1591,8 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 
 	// Update results in state
 	results := state.ContextData["results"].(map[string]int)
-	results[disciplineName] = place
+	statuses := stateStatuses(state)
+	models.SetDisciplineResult(results, statuses, disciplineName, place, status)
 
 	// Get race disciplines
 	race, err := b.RaceRepo.GetByID(raceID)
@@ -1607,6 +1625,7 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 			"disciplines": race.Disciplines,
 			"current_idx": currentIdx,
 			"results":     results,
+			"statuses":    statuses,
 		})
 
 		// Show next discipline selection
@@ -1620,59 +1639,21 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 		text += "*Выбранные места:*\n"
 		for i := 0; i < currentIdx; i++ {
 			disc := race.Disciplines[i]
-			placeEmoji := getPlaceEmoji(results[disc])
-			placeText := getPlaceText(results[disc])
+			placeEmoji := getResultEmoji(results[disc], statuses[disc])
+			placeText := getResultText(results[disc], statuses[disc])
 			text += fmt.Sprintf("• %s: %s %s\n", disc, placeEmoji, placeText)
 		}
 
 		text += fmt.Sprintf("\nВыберите место в дисциплине '*%s*':", nextDiscipline)
 
 		// Create keyboard for next discipline
-		keyboard := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(
-					"🥇 1 место",
-					fmt.Sprintf("admin_select_place:%d:%d:%s:1", raceID, driverID, nextDiscipline),
-				),
-				tgbotapi.NewInlineKeyboardButtonData(
-					"🥈 2 место",
-					fmt.Sprintf("admin_select_place:%d:%d:%s:2", raceID, driverID, nextDiscipline),
-				),
-				tgbotapi.NewInlineKeyboardButtonData(
-					"🥉 3 место",
-					fmt.Sprintf("admin_select_place:%d:%d:%s:3", raceID, driverID, nextDiscipline),
-				),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(
-					"❌ Не участвовал",
-					fmt.Sprintf("admin_select_place:%d:%d:%s:0", raceID, driverID, nextDiscipline),
-				),
-			),
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(
-					"🔙 Отмена",
-					fmt.Sprintf("admin_edit_results_menu:%d", raceID),
-				),
-			),
-		)
+		keyboard := AdminSelectPlaceKeyboard(raceID, driverID, nextDiscipline, b.getRaceFieldSize(raceID))
 
 		// Update message
 		b.editMessageWithKeyboard(chatID, messageID, text, keyboard)
 	} else {
 		// All disciplines completed, save result
-		// Calculate total score
-		totalScore := 0
-		for _, place := range results {
-			switch place {
-			case 1:
-				totalScore += 3
-			case 2:
-				totalScore += 2
-			case 3:
-				totalScore += 1
-			}
-		}
+		rules := b.getScoringRules(raceID)
 
 		// Check if driver used reroll
 		rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(raceID, driverID)
@@ -1684,10 +1665,12 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 		// Apply reroll penalty
 		rerollPenalty := 0
 		if rerollUsed {
-			rerollPenalty = 1
-			totalScore -= rerollPenalty
+			rerollPenalty = rules.RerollPenalty
 		}
 
+		// Calculate total score by season rules
+		totalScore := rules.CalculateTotalScore(results, statuses, rerollPenalty)
+
 		// Get car assignment for photo
 		assignment, err := b.CarRepo.GetDriverCarAssignment(raceID, driverID)
 		if err != nil || assignment == nil {
@@ -1707,6 +1690,7 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 			CarName:       state.ContextData["car_name"].(string),
 			CarPhotoURL:   state.ContextData["car_photo"].(string),
 			Results:       results,
+			Statuses:      statuses,
 			TotalScore:    totalScore,
 			RerollPenalty: rerollPenalty,
 		}
@@ -1736,8 +1720,8 @@ func (b *Bot) callbackAdminSelectPlace(query *tgbotapi.CallbackQuery) {
 		text += "*Итоговые места:*\n"
 
 		for _, discipline := range race.Disciplines {
-			placeEmoji := getPlaceEmoji(results[discipline])
-			placeText := getPlaceText(results[discipline])
+			placeEmoji := getResultEmoji(results[discipline], statuses[discipline])
+			placeText := getResultText(results[discipline], statuses[discipline])
 			text += fmt.Sprintf("• %s: %s %s\n", discipline, placeEmoji, placeText)
 		}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/athebyme/forza-top-gear-bot/internal/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strconv"
 )
 
 // SeasonsKeyboard создает клавиатуру для просмотра сезонов
@@ -19,8 +20,19 @@ func SeasonsKeyboard(seasons []*models.Season, isAdmin bool) tgbotapi.InlineKeyb
 		))
 	}
 
-	// Добавляем кнопку создания нового сезона для админов
+	// Добавляем кнопки завершения активного сезона и создания нового сезона для админов
 	if isAdmin {
+		for _, season := range seasons {
+			if season.Active {
+				keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(
+						fmt.Sprintf("🏁 Завершить %s", season.Name),
+						fmt.Sprintf("season_finish:%d", season.ID),
+					),
+				))
+			}
+		}
+
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				"➕ Создать новый сезон",
@@ -34,18 +46,23 @@ func SeasonsKeyboard(seasons []*models.Season, isAdmin bool) tgbotapi.InlineKeyb
 
 // DriversKeyboard создает клавиатуру для просмотра гонщиков
 func DriversKeyboard(drivers []*models.Driver) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(DriverPickerRows(drivers, "driver_card")...)
+}
+
+// DriverPickerRows создает строки кнопок выбора гонщика с callback-данными "prefix:driverID"
+func DriverPickerRows(drivers []*models.Driver, prefix string) [][]tgbotapi.InlineKeyboardButton {
 	var keyboard [][]tgbotapi.InlineKeyboardButton
 
 	for _, driver := range drivers {
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				fmt.Sprintf("👤 %s", driver.Name),
-				fmt.Sprintf("driver_card:%d", driver.ID),
+				fmt.Sprintf("%s:%d", prefix, driver.ID),
 			),
 		))
 	}
 
-	return tgbotapi.NewInlineKeyboardMarkup(keyboard...)
+	return keyboard
 }
 
 // DisciplinesKeyboard создает клавиатуру для выбора дисциплин
@@ -86,30 +103,76 @@ func DisciplinesKeyboard(selectedDisciplines []string) tgbotapi.InlineKeyboardMa
 	return tgbotapi.NewInlineKeyboardMarkup(keyboard...)
 }
 
-// PlacesKeyboard создает клавиатуру для выбора места
-func PlacesKeyboard(discipline string) tgbotapi.InlineKeyboardMarkup {
-	return tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥇 1 место",
-				fmt.Sprintf("place:%s:1", discipline),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥈 2 место",
-				fmt.Sprintf("place:%s:2", discipline),
-			),
-			tgbotapi.NewInlineKeyboardButtonData(
-				"🥉 3 место",
-				fmt.Sprintf("place:%s:3", discipline),
-			),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"❌ Не участвовал",
-				fmt.Sprintf("place:%s:0", discipline),
-			),
+// defaultFieldSize используется, если на гонку еще никто не зарегистрирован
+const defaultFieldSize = 3
+
+// PlaceButtons создает ряды кнопок мест от 1 до fieldSize, статусов DNS/DNF/DSQ и кнопку "Не участвовал".
+// data формирует callback-данные для значения: номера места (0 - не участвовал) или статуса.
+func PlaceButtons(fieldSize int, data func(value string) string) [][]tgbotapi.InlineKeyboardButton {
+	if fieldSize <= 0 {
+		fieldSize = defaultFieldSize
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	// Подиум отдельным рядом
+	var row []tgbotapi.InlineKeyboardButton
+	for place := 1; place <= fieldSize && place <= 3; place++ {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%s %d место", getPlaceEmoji(place), place),
+			data(strconv.Itoa(place)),
+		))
+	}
+	rows = append(rows, row)
+
+	// Остальные места по 4 в ряд
+	row = nil
+	for place := 4; place <= fieldSize; place++ {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%d место", place),
+			data(strconv.Itoa(place)),
+		))
+
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, statusButtonsRow(data))
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("❌ Не участвовал", data("0")),
+	))
+
+	return rows
+}
+
+// PlacesKeyboard создает клавиатуру для выбора места среди fieldSize участников
+func PlacesKeyboard(discipline string, fieldSize int) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(PlaceButtons(fieldSize, func(value string) string {
+		return fmt.Sprintf("place:%s:%s", discipline, value)
+	})...)
+}
+
+// AdminSelectPlaceKeyboard создает клавиатуру выбора места при добавлении результата администратором
+func AdminSelectPlaceKeyboard(raceID, driverID int, discipline string, fieldSize int) tgbotapi.InlineKeyboardMarkup {
+	rows := PlaceButtons(fieldSize, func(value string) string {
+		return fmt.Sprintf("admin_select_place:%d:%d:%s:%s", raceID, driverID, discipline, value)
+	})
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			"🔙 Отмена",
+			fmt.Sprintf("admin_edit_results_menu:%d", raceID),
 		),
-	)
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
 // ConfirmationKeyboard создает клавиатуру для подтверждения действия
@@ -189,6 +252,13 @@ func AdminRacePanelKeyboard(raceID int, state string) tgbotapi.InlineKeyboardMar
 			),
 		))
 
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🎯 Пул машин",
+				fmt.Sprintf("car_pool:%d", raceID),
+			),
+		))
+
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				"📨 Отправить напоминание",
@@ -211,6 +281,20 @@ func AdminRacePanelKeyboard(raceID int, state string) tgbotapi.InlineKeyboardMar
 			),
 		))
 
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🎯 Пул машин",
+				fmt.Sprintf("car_pool:%d", raceID),
+			),
+		))
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔧 Заменить машину",
+				fmt.Sprintf("car_override:%d", raceID),
+			),
+		))
+
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				"📨 Отправить машины",
@@ -218,6 +302,13 @@ func AdminRacePanelKeyboard(raceID int, state string) tgbotapi.InlineKeyboardMar
 			),
 		))
 
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🗳 Ход голосования",
+				fmt.Sprintf("vote_status:%d", raceID),
+			),
+		))
+
 		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				"✅ Завершить гонку",
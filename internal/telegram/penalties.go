@@ -0,0 +1,387 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	maxPenaltyValue     = 100
+	maxPenaltyReasonLen = 200
+)
+
+// registerPenaltyHandlers регистрирует обработчики штрафов стюардов
+func (b *Bot) registerPenaltyHandlers() {
+	b.CallbackHandlers["penalties"] = b.callbackPenalties
+	b.CallbackHandlers["penalty_add"] = b.callbackPenaltyAdd
+	b.CallbackHandlers["penalty_discipline"] = b.callbackPenaltyDiscipline
+	b.CallbackHandlers["penalty_remove"] = b.callbackPenaltyRemove
+}
+
+// scoreWithPenalties считает очки измененного результата с учетом штрафов стюардов всей гонки:
+// штрафы на позиции и дисквалификации соседей сдвигают места. ID = 0 означает новый результат.
+func (b *Bot) scoreWithPenalties(rules *models.ScoringRules, result *models.RaceResult) (int, error) {
+	raceResults, err := b.ResultRepo.GetByRaceID(result.RaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	penalties, err := b.PenaltyRepo.GetByRaceID(result.RaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Сохраненная версия результата заменяется измененной
+	results := []*models.RaceResult{result}
+	for _, other := range raceResults {
+		if other.ID != result.ID {
+			results = append(results, other)
+		}
+	}
+
+	return rules.CalculateRaceScores(results, penalties)[result.ID], nil
+}
+
+// formatPenalties форматирует список штрафов с причинами, каждая строка начинается с indent
+func formatPenalties(penalties []*models.Penalty, indent string) string {
+	var text string
+	for _, penalty := range penalties {
+		text += fmt.Sprintf("%s⚖️ Штраф: %s — %s\n", indent, penalty.Describe(), penalty.Reason)
+	}
+	return text
+}
+
+// buildPenaltiesView формирует экран штрафов результата
+func (b *Bot) buildPenaltiesView(resultID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if result == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("результат %d не найден", resultID)
+	}
+
+	penalties, err := b.PenaltyRepo.GetByResultID(resultID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	driverName := "?"
+	if driver, err := b.DriverRepo.GetByID(result.DriverID); err == nil && driver != nil {
+		driverName = driver.Name
+	}
+
+	text := fmt.Sprintf("⚖️ *Штрафы гонщика %s*\n\n", driverName)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if len(penalties) == 0 {
+		text += "Штрафов нет.\n"
+	}
+
+	for i, penalty := range penalties {
+		text += fmt.Sprintf("%d. *%s* (%s)\n", i+1, penalty.Describe(), models.PenaltyTypeName(penalty.Type))
+		text += fmt.Sprintf("   Причина: %s\n", penalty.Reason)
+		text += fmt.Sprintf("   Назначен: %s\n", b.formatDate(penalty.CreatedAt))
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🗑 Снять штраф %d", i+1),
+				fmt.Sprintf("penalty_remove:%d", penalty.ID),
+			),
+		))
+	}
+
+	text += fmt.Sprintf("\n🏆 Очки с учетом штрафов: %d", result.TotalScore)
+
+	keyboard = append(keyboard,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➖ Снять очки", fmt.Sprintf("penalty_add:%d:%s", resultID, models.PenaltyPoints)),
+			tgbotapi.NewInlineKeyboardButtonData("⬇️ Потеря позиций", fmt.Sprintf("penalty_add:%d:%s", resultID, models.PenaltyPosition)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🚫 Дисквалификация", fmt.Sprintf("penalty_add:%d:%s", resultID, models.PenaltyDisqualification)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("admin_edit_result:%d", resultID)),
+		),
+	)
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackPenalties показывает штрафы результата (penalties:resultID)
+func (b *Bot) callbackPenalties(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Назначать штрафы могут только администраторы", true)
+		return
+	}
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	text, keyboard, err := b.buildPenaltiesView(resultID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении штрафов.")
+		return
+	}
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackPenaltyAdd начинает назначение штрафа (penalty_add:resultID:type)
+func (b *Bot) callbackPenaltyAdd(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ Назначать штрафы могут только администраторы", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 || !models.IsValidPenaltyType(parts[2]) {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	penaltyType := parts[2]
+
+	// Штраф очками относится ко всей гонке, остальные - к дисциплине
+	if penaltyType == models.PenaltyPoints {
+		b.askPenaltyValue(chatID, userID, resultID, penaltyType, "")
+		return
+	}
+
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.sendMessage(chatID, "⚠️ Результат не найден.")
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(result.RaceID)
+	if err != nil || race == nil {
+		b.sendMessage(chatID, "⚠️ Гонка не найдена.")
+		return
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, discipline := range race.Disciplines {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s", getResultEmoji(result.Results[discipline], result.Statuses[discipline]), discipline),
+				fmt.Sprintf("penalty_discipline:%d:%s:%s", resultID, penaltyType, discipline),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("penalties:%d", resultID)),
+	))
+
+	b.editMessageWithKeyboard(
+		chatID,
+		query.Message.MessageID,
+		fmt.Sprintf("⚖️ *%s*\n\nВыберите дисциплину:", models.PenaltyTypeName(penaltyType)),
+		tgbotapi.NewInlineKeyboardMarkup(keyboard...),
+	)
+}
+
+// callbackPenaltyDiscipline выбирает дисциплину штрафа (penalty_discipline:resultID:type:discipline)
+func (b *Bot) callbackPenaltyDiscipline(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ Назначать штрафы могут только администраторы", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 4 || !models.IsValidPenaltyType(parts[2]) {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	resultID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID результата", true)
+		return
+	}
+
+	penaltyType := parts[2]
+	discipline := parts[3]
+
+	if penaltyType == models.PenaltyDisqualification {
+		b.askPenaltyReason(chatID, userID, resultID, penaltyType, discipline, 0)
+		return
+	}
+
+	b.askPenaltyValue(chatID, userID, resultID, penaltyType, discipline)
+}
+
+// askPenaltyValue запрашивает размер штрафа
+func (b *Bot) askPenaltyValue(chatID, userID int64, resultID int, penaltyType, discipline string) {
+	b.StateManager.SetState(userID, "penalty_value", map[string]interface{}{
+		"result_id":  resultID,
+		"type":       penaltyType,
+		"discipline": discipline,
+	})
+
+	if penaltyType == models.PenaltyPoints {
+		b.sendMessage(chatID, fmt.Sprintf("Введите количество снимаемых очков (1-%d):", maxPenaltyValue))
+	} else {
+		b.sendMessage(chatID, fmt.Sprintf("Введите количество позиций, на которое гонщик опускается в дисциплине '%s' (1-%d):", discipline, maxPenaltyValue))
+	}
+}
+
+// askPenaltyReason запрашивает причину штрафа
+func (b *Bot) askPenaltyReason(chatID, userID int64, resultID int, penaltyType, discipline string, value int) {
+	b.StateManager.SetState(userID, "penalty_reason", map[string]interface{}{
+		"result_id":  resultID,
+		"type":       penaltyType,
+		"discipline": discipline,
+		"value":      value,
+	})
+
+	b.sendMessage(chatID, "Укажите причину штрафа (она будет видна гонщику):")
+}
+
+// handlePenaltyValue обрабатывает ввод размера штрафа
+func (b *Bot) handlePenaltyValue(message *tgbotapi.Message, state models.UserState) {
+	chatID := message.Chat.ID
+
+	value, err := strconv.Atoi(strings.TrimSpace(message.Text))
+	if err != nil || value < 1 || value > maxPenaltyValue {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Пожалуйста, введите число от 1 до %d.", maxPenaltyValue))
+		return
+	}
+
+	b.askPenaltyReason(
+		chatID,
+		message.From.ID,
+		state.ContextData["result_id"].(int),
+		state.ContextData["type"].(string),
+		state.ContextData["discipline"].(string),
+		value,
+	)
+}
+
+// handlePenaltyReason обрабатывает ввод причины и назначает штраф
+func (b *Bot) handlePenaltyReason(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	reason := strings.TrimSpace(message.Text)
+	if len([]rune(reason)) < 3 || len([]rune(reason)) > maxPenaltyReasonLen {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Причина должна содержать от 3 до %d символов.", maxPenaltyReasonLen))
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	resultID := state.ContextData["result_id"].(int)
+	result, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || result == nil {
+		b.sendMessage(chatID, "⚠️ Результат не найден.")
+		return
+	}
+
+	penalty := &models.Penalty{
+		ResultID:   resultID,
+		Type:       state.ContextData["type"].(string),
+		Discipline: state.ContextData["discipline"].(string),
+		Value:      state.ContextData["value"].(int),
+		Reason:     reason,
+		IssuedBy:   userID,
+	}
+
+	if _, err := b.PenaltyRepo.Create(penalty, b.getScoringRules(result.RaceID)); err != nil {
+		log.Printf("Ошибка назначения штрафа: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при назначении штрафа.")
+		return
+	}
+
+	log.Printf("Администратор %d назначил штраф %s результату %d", userID, penalty.Describe(), resultID)
+
+	// Штраф меняет места и очки, поэтому рейтинг и снимки таблицы завершенной гонки пересчитываются
+	b.recalculateRatingsForRace(result.RaceID)
+
+	b.notifyResultOwner(result, "⚖️ Вам назначен штраф в гонке '%s': %s.\nПричина: %s", penalty.Describe(), reason)
+
+	text, keyboard, err := b.buildPenaltiesView(resultID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов: %v", err)
+		b.sendMessage(chatID, "✅ Штраф назначен.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, "✅ Штраф назначен.\n\n"+text, keyboard)
+}
+
+// callbackPenaltyRemove снимает штраф (penalty_remove:penaltyID)
+func (b *Bot) callbackPenaltyRemove(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Снимать штрафы могут только администраторы", true)
+		return
+	}
+
+	penaltyID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID штрафа", true)
+		return
+	}
+
+	penalty, err := b.PenaltyRepo.GetByID(penaltyID)
+	if err != nil || penalty == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Штраф не найден", true)
+		return
+	}
+
+	result, err := b.ResultRepo.GetByID(penalty.ResultID)
+	if err != nil || result == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Результат не найден", true)
+		return
+	}
+
+	if err := b.PenaltyRepo.Delete(penaltyID, b.getScoringRules(result.RaceID)); err != nil {
+		log.Printf("Ошибка снятия штрафа: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при снятии штрафа.")
+		return
+	}
+
+	log.Printf("Администратор %d снял штраф %d с результата %d", query.From.ID, penaltyID, penalty.ResultID)
+
+	// Снятие штрафа возвращает места и очки, поэтому рейтинг и снимки таблицы пересчитываются
+	b.recalculateRatingsForRace(result.RaceID)
+
+	b.notifyResultOwner(result, "⚖️ С вас снят штраф в гонке '%s': %s.", penalty.Describe())
+
+	text, keyboard, err := b.buildPenaltiesView(penalty.ResultID)
+	if err != nil {
+		log.Printf("Ошибка получения штрафов: %v", err)
+		return
+	}
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
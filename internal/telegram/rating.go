@@ -0,0 +1,199 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	ratingHistoryLimit = 15
+	ratingHistoryRows  = 10
+)
+
+// registerRatingHandlers регистрирует обработчики рейтинга Эло
+func (b *Bot) registerRatingHandlers() {
+	b.CommandHandlers["rating"] = b.handleRating
+
+	b.CallbackHandlers["rating"] = b.callbackRating
+	b.CallbackHandlers["rating_history"] = b.callbackRatingHistory
+}
+
+// recalculateRatings пересчитывает рейтинг по всем завершенным гонкам
+func (b *Bot) recalculateRatings() {
+	races, err := b.RatingRepo.Recalculate()
+	if err != nil {
+		log.Printf("Ошибка пересчета рейтинга: %v", err)
+		return
+	}
+	log.Printf("Рейтинг пересчитан по %d гонкам", races)
+}
+
+// recalculateRatingsForRace пересчитывает рейтинг и снимки таблицы, если гонка уже завершена
+func (b *Bot) recalculateRatingsForRace(raceID int) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		log.Printf("Ошибка получения гонки %d для пересчета рейтинга: %v", raceID, err)
+		return
+	}
+
+	if race.State == models.RaceStateCompleted {
+		b.recalculateRatings()
+		b.rebuildStandings()
+	}
+}
+
+// formatRatingDelta форматирует изменение рейтинга стрелкой
+func formatRatingDelta(delta float64) string {
+	rounded := math.Round(delta)
+	switch {
+	case rounded > 0:
+		return fmt.Sprintf("▲%.0f", rounded)
+	case rounded < 0:
+		return fmt.Sprintf("▼%.0f", -rounded)
+	default:
+		return "±0"
+	}
+}
+
+// formatDriverRating форматирует строку рейтинга для карточки гонщика
+func (b *Bot) formatDriverRating(driverID int) string {
+	rating, err := b.RatingRepo.GetByDriverID(driverID)
+	if err != nil {
+		log.Printf("Ошибка получения рейтинга гонщика %d: %v", driverID, err)
+		return ""
+	}
+
+	if rating == nil {
+		return fmt.Sprintf("📊 *Рейтинг:* %.0f (нет завершенных гонок)\n", models.DefaultRating)
+	}
+
+	return fmt.Sprintf("📊 *Рейтинг:* %.0f (%s за последнюю гонку)\n", rating.Rating, formatRatingDelta(rating.LastDelta))
+}
+
+// buildRatingView формирует таблицу рейтинга Эло
+func (b *Bot) buildRatingView() (string, tgbotapi.InlineKeyboardMarkup, error) {
+	ratings, err := b.RatingRepo.GetAll()
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := "📊 *Рейтинг гонщиков (Эло)*\n\n"
+
+	if len(ratings) == 0 {
+		text += "Рейтинг появится после первой завершенной гонки."
+	}
+
+	for i, rating := range ratings {
+		text += fmt.Sprintf("%s *%s* — %.0f (%s, гонок: %d)\n",
+			getPlaceEmoji(i+1), rating.DriverName, rating.Rating, formatRatingDelta(rating.LastDelta), rating.Races)
+	}
+
+	if len(ratings) > 0 {
+		text += fmt.Sprintf("\nРейтинг считается по попарным сравнениям мест в каждой дисциплине завершенных гонок. "+
+			"Начальный рейтинг - %.0f, за гонку он меняется не больше чем на %.0f.", models.DefaultRating, models.RatingK)
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+
+	for i, rating := range ratings {
+		if i == ratingHistoryRows {
+			break
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("📈 %s", rating.DriverName),
+			fmt.Sprintf("rating_history:%d", rating.DriverID),
+		))
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleRating обрабатывает команду /rating
+func (b *Bot) handleRating(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	text, keyboard, err := b.buildRatingView()
+	if err != nil {
+		log.Printf("Ошибка получения рейтинга: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении рейтинга.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackRating показывает таблицу рейтинга (rating)
+func (b *Bot) callbackRating(query *tgbotapi.CallbackQuery) {
+	text, keyboard, err := b.buildRatingView()
+	if err != nil {
+		log.Printf("Ошибка получения рейтинга: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении рейтинга", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackRatingHistory показывает изменения рейтинга гонщика по гонкам (rating_history:driverID)
+func (b *Bot) callbackRatingHistory(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	driverID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	rating, err := b.RatingRepo.GetByDriverID(driverID)
+	if err != nil {
+		log.Printf("Ошибка получения рейтинга: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении рейтинга", true)
+		return
+	}
+
+	if rating == nil {
+		b.answerCallbackQuery(query.ID, "ℹ️ У гонщика пока нет рейтинга", true)
+		return
+	}
+
+	history, err := b.RatingRepo.GetHistory(driverID, ratingHistoryLimit)
+	if err != nil {
+		log.Printf("Ошибка получения истории рейтинга: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении истории рейтинга", true)
+		return
+	}
+
+	text := fmt.Sprintf("📈 *История рейтинга: %s*\n\n", rating.DriverName)
+	text += fmt.Sprintf("Текущий рейтинг: *%.0f* (гонок: %d)\n\n", rating.Rating, rating.Races)
+
+	for _, change := range history {
+		text += fmt.Sprintf("• %s (%s): %.0f → %.0f (%s)\n",
+			change.RaceName, b.formatDate(change.RaceDate), change.Before, change.After, formatRatingDelta(change.Delta()))
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К рейтингу", "rating"),
+		),
+	)
+
+	b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+}
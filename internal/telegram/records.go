@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// recordCategories - страницы зала славы
+var recordCategories = []string{
+	"🏆 Победы и подиумы",
+	"💥 Лучшая гонка",
+	"🔥 Серии подиумов",
+	"👑 Титулы",
+	"⏱ Быстрейшее время",
+	"🚗 Машины",
+}
+
+// registerRecordHandlers регистрирует обработчики зала славы
+func (b *Bot) registerRecordHandlers() {
+	b.CommandHandlers["records"] = b.handleRecords
+	b.CallbackHandlers["records"] = b.callbackRecords
+}
+
+// formatRecordEntries форматирует таблицу рекордов гонщиков
+func formatRecordEntries(entries []*models.RecordEntry, unit func(int) string) string {
+	if len(entries) == 0 {
+		return "Пока нет данных.\n"
+	}
+
+	text := ""
+	for i, entry := range entries {
+		text += fmt.Sprintf("%s %d. *%s* — %s", getPlaceEmoji(i+1), i+1, entry.DriverName, unit(entry.Value))
+		if entry.Detail != "" {
+			text += fmt.Sprintf(" (%s)", entry.Detail)
+		}
+		text += "\n"
+	}
+	return text
+}
+
+// buildRecordsPage формирует текст страницы зала славы
+func (b *Bot) buildRecordsPage(index int) (string, error) {
+	text := fmt.Sprintf("🏛 *Зал славы: %s*\n\n", recordCategories[index])
+
+	switch index {
+	case 0:
+		finishes, err := b.RecordRepo.GetRaceFinishes()
+		if err != nil {
+			return "", err
+		}
+
+		count := func(n int) string { return fmt.Sprintf("%d", n) }
+
+		text += "*Больше всего побед в гонках:*\n"
+		text += formatRecordEntries(models.CountFinishes(finishes, (*models.RaceFinish).IsRaceWin, models.RecordsLimit), count)
+		text += "\n*Больше всего подиумов:*\n"
+		text += formatRecordEntries(models.CountFinishes(finishes, (*models.RaceFinish).IsPodium, models.RecordsLimit), count)
+		text += "\nМесто в гонке определяется суммой очков по всем дисциплинам."
+	case 1:
+		entries, err := b.RecordRepo.GetTopRaceScores(models.RecordsLimit)
+		if err != nil {
+			return "", err
+		}
+
+		text += "*Больше всего очков за одну гонку:*\n"
+		text += formatRecordEntries(entries, func(n int) string { return fmt.Sprintf("%d %s", n, pointsWord(n)) })
+	case 2:
+		finishes, err := b.RecordRepo.GetRaceFinishes()
+		if err != nil {
+			return "", err
+		}
+
+		text += "*Самые длинные серии подиумов подряд:*\n"
+		text += formatRecordEntries(models.LongestPodiumStreaks(finishes, models.RecordsLimit),
+			func(n int) string { return fmt.Sprintf("%d подряд", n) })
+		text += "\nСерию прерывает только гонка вне тройки, пропуск гонки ее не прерывает."
+	case 3:
+		entries, err := b.RecordRepo.GetSeasonTitles(models.RecordsLimit)
+		if err != nil {
+			return "", err
+		}
+
+		text += "*Больше всего выигранных сезонов:*\n"
+		text += formatRecordEntries(entries, func(n int) string { return fmt.Sprintf("👑 %d", n) })
+	case 4:
+		records, err := b.ResultRepo.GetTimeRecords(0)
+		if err != nil {
+			return "", err
+		}
+
+		fastest := models.FastestByDiscipline(records)
+		if len(fastest) == 0 {
+			text += "Время пока не вводилось. Рекорды появятся после первых результатов со временем в Драге, Ралли и Гонке от А к Б."
+		}
+
+		for _, record := range fastest {
+			text += fmt.Sprintf("*%s:* %s — %s\n", record.Discipline, models.FormatLapTime(record.TimeMs), record.DriverName)
+			text += fmt.Sprintf("   🚗 %s (класс %s), %s, %s\n", record.CarName, record.CarClass, record.RaceName, b.formatDate(record.Date))
+		}
+
+		if len(fastest) > 0 {
+			text += "\nРекорды по каждому классу машин - в /times."
+		}
+	case 5:
+		cars, err := b.RecordRepo.GetMostDrivenCars(models.RecordsLimit)
+		if err != nil {
+			return "", err
+		}
+
+		text += "*Чаще всего выдавались на гонки:*\n"
+		if len(cars) == 0 {
+			text += "Пока нет данных.\n"
+		}
+
+		for i, car := range cars {
+			name := car.CarName
+			if car.Year != "" {
+				name = fmt.Sprintf("%s (%s)", car.CarName, car.Year)
+			}
+			text += fmt.Sprintf("%d. *%s* — гонок: %d, гонщиков: %d", i+1, name, car.Races, car.Drivers)
+			if car.ClassLetter != "" {
+				text += fmt.Sprintf(", %s %d", car.ClassLetter, car.ClassNumber)
+			}
+			text += "\n"
+		}
+	}
+
+	return text, nil
+}
+
+// buildRecordsView формирует страницу зала славы с переключением категорий
+func (b *Bot) buildRecordsView(index int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	if index < 0 || index >= len(recordCategories) {
+		index = 0
+	}
+
+	text, err := b.buildRecordsPage(index)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+
+	for i, category := range recordCategories {
+		label := category
+		if i == index {
+			label = "✅ " + label
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("records:%d", i)))
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	prev := (index + len(recordCategories) - 1) % len(recordCategories)
+	next := (index + 1) % len(recordCategories)
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("◀️", fmt.Sprintf("records:%d", prev)),
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d/%d", index+1, len(recordCategories)), fmt.Sprintf("records:%d", index)),
+		tgbotapi.NewInlineKeyboardButtonData("▶️", fmt.Sprintf("records:%d", next)),
+	))
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", "back_to_main"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleRecords обрабатывает команду /records - зал славы
+func (b *Bot) handleRecords(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	text, keyboard, err := b.buildRecordsView(0)
+	if err != nil {
+		log.Printf("Ошибка получения рекордов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении рекордов.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackRecords показывает страницу зала славы (records:index)
+func (b *Bot) callbackRecords(query *tgbotapi.CallbackQuery) {
+	index, ok := parseIDArg(query, 1)
+	if !ok {
+		index = 0
+	}
+
+	text, keyboard, err := b.buildRecordsView(index)
+	if err != nil {
+		log.Printf("Ошибка получения рекордов: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении рекордов", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
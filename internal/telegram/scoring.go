@@ -0,0 +1,737 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	"github.com/athebyme/forza-top-gear-bot/internal/repository"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxScoringPlaces ограничивает длину таблицы очков за места
+const maxScoringPlaces = 20
+
+// registerScoringHandlers регистрирует обработчики таблицы очков
+func (b *Bot) registerScoringHandlers() {
+	b.CommandHandlers["scoring"] = b.handleScoring
+	b.CommandHandlers["recalc"] = b.handleRecalc
+
+	b.CallbackHandlers["scoring"] = b.callbackScoring
+	b.CallbackHandlers["scoring_edit"] = b.callbackScoringEdit
+	b.CallbackHandlers["scoring_reset"] = b.callbackScoringReset
+	b.CallbackHandlers["recalc"] = b.callbackRecalc
+	b.CallbackHandlers["recalc_apply"] = b.callbackRecalcApply
+}
+
+// getScoringRules возвращает таблицу очков сезона, к которому относится гонка.
+// При ошибке используются правила по умолчанию, чтобы не блокировать ввод результатов.
+func (b *Bot) getScoringRules(raceID int) *models.ScoringRules {
+	rules, err := b.ScoringRepo.GetByRaceID(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения таблицы очков для гонки %d: %v", raceID, err)
+		return models.DefaultScoringRules()
+	}
+
+	return rules
+}
+
+// getActiveScoringRules возвращает таблицу очков активного сезона
+func (b *Bot) getActiveScoringRules() *models.ScoringRules {
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil || season == nil {
+		return models.DefaultScoringRules()
+	}
+
+	rules, err := b.ScoringRepo.GetBySeasonID(season.ID)
+	if err != nil {
+		log.Printf("Ошибка получения таблицы очков сезона %d: %v", season.ID, err)
+		return models.DefaultScoringRules()
+	}
+
+	return rules
+}
+
+// pointsWord склоняет слово "очко" для числа
+func pointsWord(n int) string {
+	if n < 0 {
+		n = -n
+	}
+
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return "очко"
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return "очка"
+	default:
+		return "очков"
+	}
+}
+
+// formatScoringRules форматирует таблицу очков для вывода в сообщении
+func formatScoringRules(rules *models.ScoringRules) string {
+	var text string
+
+	for i, points := range rules.PlacePoints {
+		place := i + 1
+		emoji := getPlaceEmoji(place)
+		text += fmt.Sprintf("%s %d место - %d %s\n", emoji, place, points, pointsWord(points))
+	}
+
+	if rules.ParticipationPoints > 0 {
+		text += fmt.Sprintf("🏁 Участие в дисциплине - +%d %s\n",
+			rules.ParticipationPoints, pointsWord(rules.ParticipationPoints))
+	}
+
+	for _, status := range models.DisciplineStatuses {
+		if points := rules.PointsForStatus(status); points != 0 {
+			text += fmt.Sprintf("%s %s (%s) - %+d %s\n", models.DisciplineStatusEmoji(status), status,
+				models.DisciplineStatusName(status), points, pointsWord(points))
+		}
+	}
+
+	if rules.RerollPenalty > 0 {
+		text += fmt.Sprintf("⚠️ Реролл машины - штраф -%d %s\n",
+			rules.RerollPenalty, pointsWord(rules.RerollPenalty))
+	} else {
+		text += "🎲 Реролл машины - без штрафа\n"
+	}
+
+	return text
+}
+
+// handleScoring обрабатывает команду /scoring
+func (b *Bot) handleScoring(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активного сезона.")
+		return
+	}
+
+	if season == nil {
+		b.sendMessage(chatID, "⚠️ Нет активного сезона.")
+		return
+	}
+
+	text, keyboard, err := b.buildScoringView(season.ID, message.From.ID)
+	if err != nil {
+		log.Printf("Ошибка формирования таблицы очков: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении таблицы очков.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// buildScoringView формирует сообщение и клавиатуру с таблицей очков сезона
+func (b *Bot) buildScoringView(seasonID int, userID int64) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if season == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("сезон %d не найден", seasonID)
+	}
+
+	rules, err := b.ScoringRepo.GetBySeasonID(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("🏆 *Система подсчета очков: %s*\n\n", season.Name)
+	text += formatScoringRules(rules)
+
+	if len(rules.PlacePoints) > 0 {
+		text += fmt.Sprintf("\nМеста ниже %d-го приносят только очки за участие.", len(rules.PlacePoints))
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if b.IsAdmin(userID) {
+		keyboard = append(keyboard,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ Очки за места",
+					fmt.Sprintf("scoring_edit:%d:places", seasonID),
+				),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ Очки за участие",
+					fmt.Sprintf("scoring_edit:%d:participation", seasonID),
+				),
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ Штраф за реролл",
+					fmt.Sprintf("scoring_edit:%d:reroll", seasonID),
+				),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ DNS",
+					fmt.Sprintf("scoring_edit:%d:dns", seasonID),
+				),
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ DNF",
+					fmt.Sprintf("scoring_edit:%d:dnf", seasonID),
+				),
+				tgbotapi.NewInlineKeyboardButtonData(
+					"✏️ DSQ",
+					fmt.Sprintf("scoring_edit:%d:dsq", seasonID),
+				),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"♻️ Сбросить на 3/2/1",
+					fmt.Sprintf("scoring_reset:%d", seasonID),
+				),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"🔄 Пересчитать результаты сезона",
+					fmt.Sprintf("recalc:%d", seasonID),
+				),
+			),
+		)
+	}
+
+	// Переключатель сезонов
+	seasons, err := b.SeasonRepo.GetAll()
+	if err == nil && len(seasons) > 1 {
+		var row []tgbotapi.InlineKeyboardButton
+		for _, s := range seasons {
+			if s.ID == seasonID {
+				continue
+			}
+
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(s.Name, fmt.Sprintf("scoring:%d", s.ID)))
+			if len(row) == 3 {
+				keyboard = append(keyboard, row)
+				row = nil
+			}
+		}
+
+		if len(row) > 0 {
+			keyboard = append(keyboard, row)
+		}
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackScoring показывает таблицу очков выбранного сезона
+func (b *Bot) callbackScoring(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasonID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildScoringView(seasonID, query.From.ID)
+	if err != nil {
+		log.Printf("Ошибка формирования таблицы очков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении таблицы очков", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.editMessageWithKeyboard(chatID, messageID, text, keyboard)
+}
+
+// callbackScoringEdit запускает редактирование одного из параметров таблицы очков
+func (b *Bot) callbackScoringEdit(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для изменения таблицы очков", true)
+		return
+	}
+
+	// scoring_edit:seasonID:field
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasonID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	field := parts[2]
+
+	var prompt string
+	switch field {
+	case "places":
+		prompt = "✏️ Введите очки за места через пробел, начиная с 1-го места.\n\nПример: `10 8 6 5 4 3 2 1`"
+	case "participation":
+		prompt = "✏️ Введите количество очков за участие в дисциплине (0 - без очков за участие):"
+	case "reroll":
+		prompt = "✏️ Введите размер штрафа за реролл машины (0 - без штрафа):"
+	case "dns", "dnf", "dsq":
+		status := strings.ToUpper(field)
+		prompt = fmt.Sprintf("✏️ Введите очки за статус %s (%s) в дисциплине.\n\n"+
+			"Допускаются отрицательные значения, например `-2` как штраф за сход.",
+			status, models.DisciplineStatusName(status))
+	default:
+		b.answerCallbackQuery(query.ID, "⚠️ Неизвестный параметр", true)
+		return
+	}
+
+	b.StateManager.SetState(userID, "scoring_edit", map[string]interface{}{
+		"season_id": seasonID,
+		"field":     field,
+	})
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendMessage(chatID, prompt+"\n\nДля отмены используйте /cancel")
+}
+
+// callbackScoringReset сбрасывает таблицу очков сезона на правила по умолчанию
+func (b *Bot) callbackScoringReset(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для изменения таблицы очков", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasonID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	if err := b.ScoringRepo.Reset(seasonID); err != nil {
+		log.Printf("Ошибка сброса таблицы очков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сбросе таблицы очков", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Таблица очков сброшена", false)
+
+	text, keyboard, err := b.buildScoringView(seasonID, userID)
+	if err != nil {
+		log.Printf("Ошибка формирования таблицы очков: %v", err)
+		return
+	}
+
+	b.editMessageWithKeyboard(chatID, messageID, text, keyboard)
+}
+
+// handleScoringEditInput обрабатывает ввод нового значения таблицы очков
+func (b *Bot) handleScoringEditInput(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.StateManager.ClearState(userID)
+		b.sendMessage(chatID, "⛔ У вас нет прав для изменения таблицы очков")
+		return
+	}
+
+	seasonID := state.ContextData["season_id"].(int)
+	field := state.ContextData["field"].(string)
+
+	rules, err := b.ScoringRepo.GetBySeasonID(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения таблицы очков: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении таблицы очков.")
+		return
+	}
+
+	input := strings.TrimSpace(message.Text)
+
+	switch field {
+	case "places":
+		fields := strings.FieldsFunc(input, func(r rune) bool {
+			return r == ' ' || r == ',' || r == '/' || r == ';'
+		})
+
+		if len(fields) == 0 || len(fields) > maxScoringPlaces {
+			b.sendMessage(chatID, fmt.Sprintf("⚠️ Укажите от 1 до %d значений через пробел.", maxScoringPlaces))
+			return
+		}
+
+		points := make([]int, 0, len(fields))
+		for _, f := range fields {
+			value, err := strconv.Atoi(f)
+			if err != nil || value < 0 {
+				b.sendMessage(chatID, fmt.Sprintf("⚠️ Некорректное значение '%s'. Используйте неотрицательные целые числа.", f))
+				return
+			}
+			points = append(points, value)
+		}
+
+		rules.PlacePoints = points
+
+	case "participation", "reroll":
+		value, err := strconv.Atoi(input)
+		if err != nil || value < 0 || value > 100 {
+			b.sendMessage(chatID, "⚠️ Введите целое число от 0 до 100.")
+			return
+		}
+
+		if field == "participation" {
+			rules.ParticipationPoints = value
+		} else {
+			rules.RerollPenalty = value
+		}
+
+	case "dns", "dnf", "dsq":
+		value, err := strconv.Atoi(input)
+		if err != nil || value < -100 || value > 100 {
+			b.sendMessage(chatID, "⚠️ Введите целое число от -100 до 100.")
+			return
+		}
+
+		switch field {
+		case "dns":
+			rules.DNSPoints = value
+		case "dnf":
+			rules.DNFPoints = value
+		default:
+			rules.DSQPoints = value
+		}
+	}
+
+	rules.SeasonID = seasonID
+	if err := b.ScoringRepo.Save(rules); err != nil {
+		log.Printf("Ошибка сохранения таблицы очков: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении таблицы очков.")
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	text, keyboard, err := b.buildScoringView(seasonID, userID)
+	if err != nil {
+		log.Printf("Ошибка формирования таблицы очков: %v", err)
+		b.sendMessage(chatID, "✅ Таблица очков сохранена.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, "✅ Таблица очков сохранена.\n\n"+text+
+		"\n\nУже сохраненные результаты не меняются автоматически - используйте кнопку пересчета.", keyboard)
+}
+
+// driverStanding итоговая позиция гонщика до и после пересчета
+type driverStanding struct {
+	DriverID   int
+	DriverName string
+	OldTotal   int
+	NewTotal   int
+	OldPos     int
+	NewPos     int
+}
+
+// collectScoringRules загружает таблицы очков всех сезонов
+func (b *Bot) collectScoringRules() (map[int]*models.ScoringRules, error) {
+	seasons, err := b.SeasonRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[int]*models.ScoringRules, len(seasons))
+	for _, season := range seasons {
+		seasonRules, err := b.ScoringRepo.GetBySeasonID(season.ID)
+		if err != nil {
+			return nil, err
+		}
+		rules[season.ID] = seasonRules
+	}
+
+	return rules, nil
+}
+
+// buildRecalcStandings сводит пересчитанные результаты в таблицу гонщиков
+// и расставляет позиции до и после пересчета. Как и в турнирной таблице, учитываются
+// только подтвержденные результаты завершенных гонок.
+func buildRecalcStandings(recalculations []*repository.ScoreRecalculation) []*driverStanding {
+	byDriver := make(map[int]*driverStanding)
+	for _, rc := range recalculations {
+		if !rc.Counted {
+			continue
+		}
+
+		ds, ok := byDriver[rc.DriverID]
+		if !ok {
+			ds = &driverStanding{DriverID: rc.DriverID, DriverName: rc.DriverName}
+			byDriver[rc.DriverID] = ds
+		}
+		ds.OldTotal += rc.OldScore
+		ds.NewTotal += rc.NewScore
+	}
+
+	standings := make([]*driverStanding, 0, len(byDriver))
+	for _, ds := range byDriver {
+		standings = append(standings, ds)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].OldTotal != standings[j].OldTotal {
+			return standings[i].OldTotal > standings[j].OldTotal
+		}
+		return standings[i].DriverName < standings[j].DriverName
+	})
+	for i, ds := range standings {
+		ds.OldPos = i + 1
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].NewTotal != standings[j].NewTotal {
+			return standings[i].NewTotal > standings[j].NewTotal
+		}
+		return standings[i].DriverName < standings[j].DriverName
+	})
+	for i, ds := range standings {
+		ds.NewPos = i + 1
+	}
+
+	return standings
+}
+
+// recalcScopeName возвращает название области пересчета
+func (b *Bot) recalcScopeName(seasonID int) string {
+	if seasonID == 0 {
+		return "все сезоны"
+	}
+
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		return fmt.Sprintf("сезон %d", seasonID)
+	}
+
+	return season.Name
+}
+
+// handleRecalc обрабатывает команду /recalc [ID сезона|all]
+func (b *Bot) handleRecalc(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	if !b.IsAdmin(message.From.ID) {
+		b.sendMessage(chatID, "⛔ У вас нет прав для пересчета результатов")
+		return
+	}
+
+	var seasonID int
+	args := strings.Fields(message.Text)
+	if len(args) > 1 {
+		if strings.EqualFold(args[1], "all") {
+			seasonID = 0
+		} else {
+			id, err := strconv.Atoi(args[1])
+			if err != nil || id <= 0 {
+				b.sendMessage(chatID, "⚠️ Использование: /recalc [ID сезона|all]")
+				return
+			}
+			seasonID = id
+		}
+	} else {
+		season, err := b.SeasonRepo.GetActive()
+		if err != nil || season == nil {
+			b.sendMessage(chatID, "⚠️ Нет активного сезона. Укажите ID сезона или all.")
+			return
+		}
+		seasonID = season.ID
+	}
+
+	text, keyboard, err := b.buildRecalcPreview(seasonID)
+	if err != nil {
+		log.Printf("Ошибка пробного пересчета: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при пересчете результатов.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// buildRecalcPreview выполняет пробный пересчет и формирует сообщение с изменениями
+func (b *Bot) buildRecalcPreview(seasonID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	rules, err := b.collectScoringRules()
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	recalculations, err := b.ResultRepo.RecalculateScores(seasonID, rules, false)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("🔄 *Пересчет очков: %s*\n\n", b.recalcScopeName(seasonID))
+
+	changedResults, changedUncounted := 0, 0
+	for _, rc := range recalculations {
+		if rc.Changed() {
+			changedResults++
+			if !rc.Counted {
+				changedUncounted++
+			}
+		}
+	}
+
+	backRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+	)
+
+	if changedResults == 0 {
+		text += fmt.Sprintf("✅ Все %d результатов уже соответствуют текущим таблицам очков.", len(recalculations))
+		return text, tgbotapi.NewInlineKeyboardMarkup(backRow), nil
+	}
+
+	text += fmt.Sprintf("Будет изменено результатов: *%d* из %d\n\n", changedResults, len(recalculations))
+	text += "*Изменения в зачете:*\n"
+
+	for _, ds := range buildRecalcStandings(recalculations) {
+		if ds.OldTotal == ds.NewTotal && ds.OldPos == ds.NewPos {
+			continue
+		}
+
+		line := fmt.Sprintf("• *%s*: %d → %d (%+d)", ds.DriverName, ds.OldTotal, ds.NewTotal, ds.NewTotal-ds.OldTotal)
+		switch {
+		case ds.NewPos < ds.OldPos:
+			line += fmt.Sprintf(", место %d → %d ▲", ds.OldPos, ds.NewPos)
+		case ds.NewPos > ds.OldPos:
+			line += fmt.Sprintf(", место %d → %d ▼", ds.OldPos, ds.NewPos)
+		default:
+			line += fmt.Sprintf(", место %d", ds.NewPos)
+		}
+		text += line + "\n"
+	}
+
+	if changedUncounted > 0 {
+		text += fmt.Sprintf("\nИз них неподтвержденных или в незавершенных гонках: %d - в зачет они попадут позже.\n",
+			changedUncounted)
+	}
+
+	text += "\nЭто пробный расчет, в базе пока ничего не изменено."
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Применить пересчет", fmt.Sprintf("recalc_apply:%d", seasonID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", "cancel"),
+		),
+	)
+
+	return text, keyboard, nil
+}
+
+// callbackRecalc показывает пробный пересчет очков сезона
+func (b *Bot) callbackRecalc(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для пересчета результатов", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasonID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildRecalcPreview(seasonID)
+	if err != nil {
+		log.Printf("Ошибка пробного пересчета: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при пересчете результатов", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "", false)
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackRecalcApply применяет пересчет очков в одной транзакции
+func (b *Bot) callbackRecalcApply(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для пересчета результатов", true)
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	if len(parts) < 2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	seasonID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	rules, err := b.collectScoringRules()
+	if err != nil {
+		log.Printf("Ошибка получения таблиц очков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении таблиц очков", true)
+		return
+	}
+
+	recalculations, err := b.ResultRepo.RecalculateScores(seasonID, rules, true)
+	if err != nil {
+		log.Printf("Ошибка пересчета результатов: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Изменения не применены", true)
+		b.editMessage(chatID, messageID, "⚠️ Произошла ошибка при пересчете. Изменения не применены.")
+		return
+	}
+
+	changed := 0
+	for _, rc := range recalculations {
+		if rc.Changed() {
+			changed++
+		}
+	}
+
+	log.Printf("Пересчет очков (%s) выполнен администратором %d: изменено %d результатов",
+		b.recalcScopeName(seasonID), query.From.ID, changed)
+
+	// Рейтинг Эло и снимки таблицы зависят от мест и очков, пересчитываем их вместе с очками
+	b.recalculateRatings()
+	b.rebuildStandings()
+
+	b.answerCallbackQuery(query.ID, "✅ Пересчет выполнен", false)
+	b.editMessage(chatID, messageID, fmt.Sprintf("✅ *Пересчет выполнен: %s*\n\nОбновлено результатов: %d из %d.",
+		b.recalcScopeName(seasonID), changed, len(recalculations)))
+}
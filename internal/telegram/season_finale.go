@@ -0,0 +1,382 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerSeasonFinaleHandlers регистрирует обработчики завершения сезона
+func (b *Bot) registerSeasonFinaleHandlers() {
+	b.CommandHandlers["finishseason"] = b.handleFinishSeason
+
+	b.CallbackHandlers["season_finish"] = b.callbackSeasonFinish
+	b.CallbackHandlers["season_finish_confirm"] = b.callbackSeasonFinishConfirm
+}
+
+// seasonRaceCounts возвращает число гонок сезона по состояниям
+func (b *Bot) seasonRaceCounts(seasonID int) (map[string]int, error) {
+	races, err := b.RaceRepo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, race := range races {
+		counts[race.State]++
+	}
+	return counts, nil
+}
+
+// getFinalStandings возвращает таблицу сезона после последней завершенной гонки
+func (b *Bot) getFinalStandings(seasonID int) ([]*models.StandingsEntry, int, error) {
+	races, err := b.StandingsRepo.GetRaces(seasonID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(races) == 0 {
+		return nil, 0, nil
+	}
+
+	entries, err := b.StandingsRepo.GetSnapshot(seasonID, races[len(races)-1].ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, len(races), nil
+}
+
+// formatSeasonStandings форматирует итоговую таблицу сезона с чемпионом (при равенстве очков - несколько)
+func formatSeasonStandings(entries []*models.StandingsEntry) string {
+	if len(entries) == 0 {
+		return "В сезоне не было завершенных гонок с подтвержденными результатами.\n"
+	}
+
+	var champions []string
+	for _, entry := range entries {
+		if entry.Gap == 0 && entry.Points > 0 {
+			champions = append(champions, entry.DriverName)
+		}
+	}
+
+	text := ""
+	if len(champions) > 0 {
+		text += fmt.Sprintf("👑 *Чемпион:* %s — %d %s\n\n", strings.Join(champions, ", "),
+			entries[0].Points, pointsWord(entries[0].Points))
+	}
+
+	text += "*Итоговая таблица:*\n"
+	for _, entry := range entries {
+		text += fmt.Sprintf("%s %d. *%s* — %d %s", getPlaceEmoji(entry.Position), entry.Position,
+			entry.DriverName, entry.Points, pointsWord(entry.Points))
+		if entry.Gap > 0 {
+			text += fmt.Sprintf(" (−%d)", entry.Gap)
+		}
+		text += "\n"
+	}
+
+	return text
+}
+
+// buildSeasonRecap формирует итоги сезона для рассылки гонщикам
+func (b *Bot) buildSeasonRecap(season *models.Season) (string, error) {
+	entries, races, err := b.getFinalStandings(season.ID)
+	if err != nil {
+		return "", err
+	}
+
+	text := fmt.Sprintf("🏁 *Сезон '%s' завершен!*\n", season.Name)
+	text += fmt.Sprintf("%s - %s, гонок: %d\n\n", b.formatDate(season.StartDate), b.formatDate(season.EndDate), races)
+	text += formatSeasonStandings(entries)
+
+	if champions := b.formatDisciplineChampions(season.ID); champions != "" {
+		text += "\n" + champions
+	}
+
+	if moves := b.formatSeasonDivisionMoves(season.ID); moves != "" {
+		text += "\n" + moves
+	}
+
+	return text, nil
+}
+
+// buildSeasonFinishPreview формирует предварительные итоги сезона перед завершением.
+// Возвращает ok = false, если сезон завершить нельзя.
+func (b *Bot) buildSeasonFinishPreview(season *models.Season) (string, tgbotapi.InlineKeyboardMarkup, bool, error) {
+	backKeyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К сезонам", "seasons"),
+	))
+
+	if !season.Active && !season.EndDate.IsZero() {
+		return fmt.Sprintf("ℹ️ Сезон '%s' уже завершен %s.", season.Name, b.formatDate(season.EndDate)),
+			backKeyboard, false, nil
+	}
+
+	counts, err := b.seasonRaceCounts(season.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, false, err
+	}
+
+	if counts[models.RaceStateInProgress] > 0 {
+		return fmt.Sprintf("⛔ В сезоне '%s' есть незавершенные гонки (%d). Завершите их перед завершением сезона.",
+			season.Name, counts[models.RaceStateInProgress]), backKeyboard, false, nil
+	}
+
+	// Итоги считаются по свежим снимкам таблицы
+	b.rebuildStandings()
+
+	entries, races, err := b.getFinalStandings(season.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, false, err
+	}
+
+	text := fmt.Sprintf("🏁 *Завершение сезона '%s'*\n\n", season.Name)
+	text += fmt.Sprintf("Завершено гонок: %d\n", races)
+	if counts[models.RaceStateNotStarted] > 0 {
+		text += fmt.Sprintf("⚠️ Не проведено гонок: %d - они останутся в архиве сезона без результатов.\n",
+			counts[models.RaceStateNotStarted])
+	}
+	text += "\n" + formatSeasonStandings(entries)
+
+	if champions := b.formatDisciplineChampions(season.ID); champions != "" {
+		text += "\n" + champions
+	}
+
+	// Переходы между дивизионами рассчитываются по текущим таблицам и фиксируются при завершении
+	divisions, moves, err := b.planSeasonDivisionMoves(season.ID)
+	if err != nil {
+		log.Printf("Ошибка расчета переходов между дивизионами сезона %d: %v", season.ID, err)
+	} else if plan := formatDivisionMoves(divisions, moves); plan != "" {
+		text += "\n" + plan
+	}
+
+	text += "\nПосле завершения сезон уйдет в архив, а всем гонщикам будут отправлены итоги."
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Завершить и начать новый", fmt.Sprintf("season_finish_confirm:%d:1", season.ID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Только завершить", fmt.Sprintf("season_finish_confirm:%d:0", season.ID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", "seasons"),
+		),
+	)
+
+	return text, keyboard, true, nil
+}
+
+// handleFinishSeason обрабатывает команду /finishseason - завершение активного сезона
+func (b *Bot) handleFinishSeason(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	if !b.IsAdmin(message.From.ID) {
+		b.sendMessage(chatID, "⛔ У вас нет прав для завершения сезона")
+		return
+	}
+
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активного сезона.")
+		return
+	}
+
+	if season == nil {
+		b.sendMessage(chatID, "⚠️ Нет активного сезона.")
+		return
+	}
+
+	text, keyboard, _, err := b.buildSeasonFinishPreview(season)
+	if err != nil {
+		log.Printf("Ошибка подготовки итогов сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при подготовке итогов сезона.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackSeasonFinish показывает предварительные итоги сезона перед завершением (season_finish:seasonID)
+func (b *Bot) callbackSeasonFinish(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для завершения сезона", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения сезона %d: %v", seasonID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Сезон не найден", true)
+		return
+	}
+
+	text, keyboard, _, err := b.buildSeasonFinishPreview(season)
+	if err != nil {
+		log.Printf("Ошибка подготовки итогов сезона: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при подготовке итогов сезона", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackSeasonFinishConfirm завершает сезон, рассылает итоги и при необходимости
+// запрашивает название следующего сезона (season_finish_confirm:seasonID:next)
+func (b *Bot) callbackSeasonFinishConfirm(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для завершения сезона", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	next, _ := parseIDArg(query, 2)
+
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения сезона %d: %v", seasonID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Сезон не найден", true)
+		return
+	}
+
+	// Состояние гонок могло измениться, пока администратор смотрел итоги
+	text, keyboard, ok, err := b.buildSeasonFinishPreview(season)
+	if err != nil {
+		log.Printf("Ошибка подготовки итогов сезона: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при подготовке итогов сезона", true)
+		return
+	}
+	if !ok {
+		b.editMessageWithKeyboard(chatID, query.Message.MessageID, text, keyboard)
+		return
+	}
+
+	if err := b.SeasonRepo.Complete(seasonID, time.Now()); err != nil {
+		log.Printf("Ошибка завершения сезона %d: %v", seasonID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при завершении сезона", true)
+		return
+	}
+
+	log.Printf("Сезон %d завершен администратором %d", seasonID, userID)
+
+	season, err = b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения сезона %d: %v", seasonID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Сезон завершен, но не удалось подготовить итоги", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Сезон завершен", false)
+
+	// Достижение чемпиона выдается по итогам завершенного сезона
+	b.evaluateSeasonAchievements(seasonID)
+
+	// Повышения и понижения фиксируются по итоговым таблицам дивизионов
+	b.applyDivisionMoves(seasonID)
+
+	recap, err := b.buildSeasonRecap(season)
+	if err != nil {
+		log.Printf("Ошибка подготовки итогов сезона %d: %v", seasonID, err)
+	} else {
+		go b.broadcastToDrivers(recap)
+	}
+
+	b.editMessage(chatID, query.Message.MessageID, fmt.Sprintf("✅ Сезон '%s' завершен и перенесен в архив. Итоги отправлены гонщикам.", season.Name))
+
+	if next == 1 {
+		b.StateManager.SetState(userID, "season_next_name", map[string]interface{}{
+			"previous_season_id": seasonID,
+		})
+		b.sendMessage(chatID, "Введите название нового сезона (от 3 до 30 символов).\nНовый сезон начнется сегодня и получит таблицу очков и дивизионы завершенного.")
+	}
+}
+
+// broadcastToDrivers отправляет сообщение всем зарегистрированным гонщикам
+func (b *Bot) broadcastToDrivers(text string) {
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков для рассылки: %v", err)
+		return
+	}
+
+	for _, driver := range drivers {
+		b.sendMessage(driver.TelegramID, text)
+	}
+}
+
+// handleSeasonNextName создает и активирует следующий сезон после завершения предыдущего
+func (b *Bot) handleSeasonNextName(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	name := strings.TrimSpace(message.Text)
+	if len(name) < 3 || len(name) > 30 {
+		b.sendMessage(chatID, "⚠️ Название должно содержать от 3 до 30 символов. Пожалуйста, введите корректное название:")
+		return
+	}
+
+	previousSeasonID, _ := state.ContextData["previous_season_id"].(int)
+
+	season := &models.Season{
+		Name:      name,
+		StartDate: time.Now(),
+	}
+
+	seasonID, err := b.SeasonRepo.Create(season)
+	if err != nil {
+		log.Printf("Ошибка создания сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при создании нового сезона.")
+		return
+	}
+
+	if err := b.SeasonRepo.Activate(seasonID); err != nil {
+		log.Printf("Ошибка активации сезона %d: %v", seasonID, err)
+		b.sendMessage(chatID, "⚠️ Сезон создан, но не удалось его активировать.")
+		return
+	}
+
+	// Таблица очков переходит в новый сезон без изменений
+	if previousSeasonID > 0 {
+		rules, err := b.ScoringRepo.GetBySeasonID(previousSeasonID)
+		if err != nil {
+			log.Printf("Ошибка получения таблицы очков сезона %d: %v", previousSeasonID, err)
+		} else {
+			rules.SeasonID = seasonID
+			if err := b.ScoringRepo.Save(rules); err != nil {
+				log.Printf("Ошибка переноса таблицы очков в сезон %d: %v", seasonID, err)
+			}
+		}
+
+		// Дивизионы переходят в новый сезон с учетом повышений и понижений
+		if _, err := b.carryOverDivisions(previousSeasonID, seasonID); err != nil {
+			log.Printf("Ошибка переноса дивизионов в сезон %d: %v", seasonID, err)
+		}
+	}
+
+	b.StateManager.ClearState(userID)
+
+	b.sendMessage(chatID, fmt.Sprintf("✅ Сезон '%s' создан и активирован!", name))
+	go b.broadcastToDrivers(fmt.Sprintf("🚦 Стартовал новый сезон *%s*! Следите за анонсами гонок.", name))
+
+	b.handleSeasons(message)
+}
@@ -0,0 +1,294 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerStandingsHandlers регистрирует обработчики истории турнирной таблицы
+func (b *Bot) registerStandingsHandlers() {
+	b.CommandHandlers["standings"] = b.handleStandings
+
+	b.CallbackHandlers["standings"] = b.callbackStandings
+	b.CallbackHandlers["standings_season"] = b.callbackStandingsSeason
+	b.CallbackHandlers["standings_race"] = b.callbackStandingsRace
+}
+
+// rebuildStandings пересчитывает снимки турнирной таблицы по всем завершенным гонкам
+func (b *Bot) rebuildStandings() {
+	races, err := b.StandingsRepo.Rebuild()
+	if err != nil {
+		log.Printf("Ошибка пересчета снимков турнирной таблицы: %v", err)
+		return
+	}
+	log.Printf("Снимки турнирной таблицы пересчитаны по %d гонкам", races)
+}
+
+// formatPositionMove форматирует изменение позиции гонщика относительно предыдущей таблицы.
+// Пустая карта previous означает, что сравнивать не с чем.
+func formatPositionMove(previous map[int]int, driverID, position int) string {
+	if len(previous) == 0 {
+		return ""
+	}
+
+	before, ok := previous[driverID]
+	switch {
+	case !ok:
+		return " 🆕"
+	case before > position:
+		return fmt.Sprintf(" ▲%d", before-position)
+	case before < position:
+		return fmt.Sprintf(" ▼%d", position-before)
+	default:
+		return ""
+	}
+}
+
+// standingsScopeName возвращает название таблицы сезона (0 - все сезоны)
+func (b *Bot) standingsScopeName(seasonID int) string {
+	if seasonID == 0 {
+		return "все сезоны"
+	}
+
+	season, err := b.SeasonRepo.GetByID(seasonID)
+	if err != nil || season == nil {
+		return fmt.Sprintf("сезон %d", seasonID)
+	}
+	return season.Name
+}
+
+// buildStandingsSeasonsView формирует выбор сезона для истории таблицы
+func (b *Bot) buildStandingsSeasonsView() (string, tgbotapi.InlineKeyboardMarkup, error) {
+	seasons, err := b.SeasonRepo.GetAll()
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := "📋 *История турнирной таблицы*\n\n" +
+		"Выберите сезон, чтобы посмотреть таблицу после любой завершенной гонки.\n" +
+		"Быстрый доступ: `/standings <ID сезона> <номер гонки>` (ID 0 - все сезоны)."
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("📊 Все сезоны (ID 0)", "standings_season:0"),
+	))
+
+	for _, season := range seasons {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s (ID %d)", season.Name, season.ID),
+				fmt.Sprintf("standings_season:%d", season.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Главное меню", "back_to_main"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// buildStandingsRacesView формирует список гонок сезона со снимками таблицы
+func (b *Bot) buildStandingsRacesView(seasonID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	races, err := b.StandingsRepo.GetRaces(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("📋 *Таблица: %s*\n\n", b.standingsScopeName(seasonID))
+
+	if len(races) == 0 {
+		text += "Таблица появится после первой завершенной гонки."
+	} else {
+		text += "Выберите гонку, после которой показать таблицу:"
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for i, race := range races {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%d. %s (%s)", i+1, race.Name, b.formatDate(race.Date)),
+				fmt.Sprintf("standings_race:%d:%d", seasonID, i+1),
+			),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К выбору сезона", "standings"),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// buildStandingsView формирует таблицу сезона после гонки с номером raceNumber (с 1)
+func (b *Bot) buildStandingsView(seasonID, raceNumber int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	races, err := b.StandingsRepo.GetRaces(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if raceNumber < 1 || raceNumber > len(races) {
+		return fmt.Sprintf("⚠️ В таблице '%s' нет гонки №%d. Завершенных гонок: %d.",
+				b.standingsScopeName(seasonID), raceNumber, len(races)),
+			tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🔙 К списку гонок", fmt.Sprintf("standings_season:%d", seasonID)),
+			)), nil
+	}
+
+	race := races[raceNumber-1]
+
+	entries, err := b.StandingsRepo.GetSnapshot(seasonID, race.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	previous := make(map[int]int)
+	if raceNumber > 1 {
+		before, err := b.StandingsRepo.GetSnapshot(seasonID, races[raceNumber-2].ID)
+		if err != nil {
+			return "", tgbotapi.InlineKeyboardMarkup{}, err
+		}
+		for _, entry := range before {
+			previous[entry.DriverID] = entry.Position
+		}
+	}
+
+	text := fmt.Sprintf("📋 *Таблица: %s*\n", b.standingsScopeName(seasonID))
+	text += fmt.Sprintf("После гонки №%d: *%s* (%s)\n\n", raceNumber, race.Name, b.formatDate(race.Date))
+
+	for _, entry := range entries {
+		text += fmt.Sprintf("%s %d. *%s* — %d %s", getPlaceEmoji(entry.Position), entry.Position,
+			entry.DriverName, entry.Points, pointsWord(entry.Points))
+		if entry.Gap > 0 {
+			text += fmt.Sprintf(" (−%d)", entry.Gap)
+		}
+		text += formatPositionMove(previous, entry.DriverID, entry.Position) + "\n"
+	}
+
+	var navRow []tgbotapi.InlineKeyboardButton
+	if raceNumber > 1 {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("◀️ Пред.",
+			fmt.Sprintf("standings_race:%d:%d", seasonID, raceNumber-1)))
+	}
+	if raceNumber < len(races) {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("След. ▶️",
+			fmt.Sprintf("standings_race:%d:%d", seasonID, raceNumber+1)))
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	if len(navRow) > 0 {
+		keyboard = append(keyboard, navRow)
+	}
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К списку гонок", fmt.Sprintf("standings_season:%d", seasonID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleStandings обрабатывает команду /standings [сезон] [номер гонки]
+func (b *Bot) handleStandings(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	var args []int
+	for _, field := range strings.Fields(message.CommandArguments()) {
+		value, err := strconv.Atoi(field)
+		if err != nil || value < 0 {
+			b.sendMessage(chatID, "⚠️ Использование: /standings [ID сезона] [номер гонки]. ID сезона 0 - все сезоны.")
+			return
+		}
+		args = append(args, value)
+	}
+
+	var text string
+	var keyboard tgbotapi.InlineKeyboardMarkup
+	var err error
+
+	switch len(args) {
+	case 0:
+		text, keyboard, err = b.buildStandingsSeasonsView()
+	case 1:
+		text, keyboard, err = b.buildStandingsRacesView(args[0])
+	default:
+		text, keyboard, err = b.buildStandingsView(args[0], args[1])
+	}
+
+	if err != nil {
+		log.Printf("Ошибка получения истории таблицы: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении истории таблицы.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackStandings показывает выбор сезона для истории таблицы (standings)
+func (b *Bot) callbackStandings(query *tgbotapi.CallbackQuery) {
+	text, keyboard, err := b.buildStandingsSeasonsView()
+	if err != nil {
+		log.Printf("Ошибка получения списка сезонов: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка сезонов", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackStandingsSeason показывает гонки сезона со снимками таблицы (standings_season:seasonID)
+func (b *Bot) callbackStandingsSeason(query *tgbotapi.CallbackQuery) {
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildStandingsRacesView(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения гонок со снимками таблицы: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка гонок", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackStandingsRace показывает таблицу после гонки (standings_race:seasonID:raceNumber)
+func (b *Bot) callbackStandingsRace(query *tgbotapi.CallbackQuery) {
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	raceNumber, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный номер гонки", true)
+		return
+	}
+
+	text, keyboard, err := b.buildStandingsView(seasonID, raceNumber)
+	if err != nil {
+		log.Printf("Ошибка получения снимка таблицы: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении таблицы", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// previousStandingsPositions возвращает позиции до последней завершенной гонки для стрелок в рейтинге
+func (b *Bot) previousStandingsPositions(seasonID int) map[int]int {
+	positions, err := b.StandingsRepo.GetPreviousPositions(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения предыдущих позиций: %v", err)
+		return nil
+	}
+	return positions
+}
@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// statusButtonsRow создает ряд кнопок статусов DNS/DNF/DSQ для клавиатуры мест
+func statusButtonsRow(data func(value string) string) []tgbotapi.InlineKeyboardButton {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, status := range models.DisciplineStatuses {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%s %s", models.DisciplineStatusEmoji(status), status),
+			data(status),
+		))
+	}
+	return row
+}
+
+// parsePlaceValue разбирает значение кнопки клавиатуры мест: номер места от 0 до fieldSize или статус
+func parsePlaceValue(value string, fieldSize int) (place int, status string, ok bool) {
+	if status, ok := models.ParseDisciplineStatus(value); ok {
+		return 0, status, true
+	}
+
+	place, err := strconv.Atoi(value)
+	if err != nil || place < 0 || place > fieldSize {
+		return 0, "", false
+	}
+
+	return place, "", true
+}
+
+// stateStatuses возвращает карту статусов из контекста состояния ввода результата
+func stateStatuses(state models.UserState) map[string]string {
+	if statuses, ok := state.ContextData["statuses"].(map[string]string); ok {
+		return statuses
+	}
+	return make(map[string]string)
+}
+
+// getResultEmoji возвращает эмодзи результата дисциплины: статуса, если он есть, иначе места
+func getResultEmoji(place int, status string) string {
+	if status != "" {
+		return models.DisciplineStatusEmoji(status)
+	}
+	return getPlaceEmoji(place)
+}
+
+// getResultText возвращает текст результата дисциплины: статуса, если он есть, иначе места
+func getResultText(place int, status string) string {
+	if status != "" {
+		return fmt.Sprintf("%s (%s)", status, models.DisciplineStatusName(status))
+	}
+	return getPlaceText(place)
+}
+
+// formatStatusCounts форматирует строку карточки гонщика со счетчиками DNS/DNF/DSQ, пусто если их нет
+func formatStatusCounts(stats *models.DriverStats) string {
+	if stats.DNS+stats.DNF+stats.DSQ == 0 {
+		return ""
+	}
+	return fmt.Sprintf("🚩 *DNS/DNF/DSQ:* %d/%d/%d\n", stats.DNS, stats.DNF, stats.DSQ)
+}
@@ -0,0 +1,588 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerTeamHandlers регистрирует обработчики команд и командного зачета
+func (b *Bot) registerTeamHandlers() {
+	b.CommandHandlers["teams"] = b.handleTeams
+
+	b.CallbackHandlers["teams"] = b.callbackTeams
+	b.CallbackHandlers["team_admin"] = b.callbackTeamAdmin
+	b.CallbackHandlers["team_create"] = b.callbackTeamCreate
+	b.CallbackHandlers["team_manage"] = b.callbackTeamManage
+	b.CallbackHandlers["team_add_pick"] = b.callbackTeamAddPick
+	b.CallbackHandlers["team_add"] = b.callbackTeamAdd
+	b.CallbackHandlers["team_remove"] = b.callbackTeamRemove
+	b.CallbackHandlers["team_delete"] = b.callbackTeamDelete
+	b.CallbackHandlers["team_delete_confirm"] = b.callbackTeamDeleteConfirm
+}
+
+// seasonDriverTeams возвращает команды гонщиков сезона (для гонок вне сезона - пустую карту)
+func (b *Bot) seasonDriverTeams(seasonID int) map[int]*models.Team {
+	if seasonID == 0 {
+		return map[int]*models.Team{}
+	}
+
+	teams, err := b.TeamRepo.GetDriverTeams(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения команд гонщиков сезона %d: %v", seasonID, err)
+		return map[int]*models.Team{}
+	}
+	return teams
+}
+
+// teamBadge возвращает значок команды гонщика для списков результатов
+func teamBadge(teams map[int]*models.Team, driverID int) string {
+	if team, ok := teams[driverID]; ok {
+		return " " + team.Badge
+	}
+	return ""
+}
+
+// formatDriverTeam форматирует строку команды гонщика в активном сезоне для карточки
+func (b *Bot) formatDriverTeam(driverID int) string {
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		return ""
+	}
+
+	if season == nil {
+		return ""
+	}
+
+	team, ok := b.seasonDriverTeams(season.ID)[driverID]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("👥 *Команда:* %s\n", team.Label())
+}
+
+// buildTeamsView формирует командный зачет сезона
+func (b *Bot) buildTeamsView(seasonID int, isAdmin bool) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	standings, err := b.TeamRepo.GetStandings(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("👥 *Командный зачет: %s*\n\n", b.standingsScopeName(seasonID))
+
+	if len(standings) == 0 {
+		text += "В этом сезоне команды еще не сформированы."
+	}
+
+	incomplete := false
+	for _, standing := range standings {
+		text += fmt.Sprintf("%s %d. *%s* — %d %s", getPlaceEmoji(standing.Position), standing.Position,
+			standing.Team.Label(), standing.Points, pointsWord(standing.Points))
+		if !standing.IsComplete() {
+			incomplete = true
+			text += " ⚠️"
+		}
+		text += "\n"
+
+		for _, member := range standing.Members {
+			text += fmt.Sprintf("   • %s — %d\n", member.DriverName, member.Points)
+		}
+	}
+
+	if incomplete {
+		text += fmt.Sprintf("\n⚠️ — в команде меньше %d гонщиков", models.TeamMinMembers)
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if isAdmin {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⚙️ Управление командами", fmt.Sprintf("team_admin:%d", seasonID)),
+		))
+	}
+
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 К рейтингу", fmt.Sprintf("leaderboard:%d", seasonID)),
+	))
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// handleTeams обрабатывает команду /teams - командный зачет активного сезона
+func (b *Bot) handleTeams(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активного сезона.")
+		return
+	}
+
+	if season == nil {
+		b.sendMessage(chatID, "⚠️ Нет активного сезона.")
+		return
+	}
+
+	text, keyboard, err := b.buildTeamsView(season.ID, b.IsAdmin(message.From.ID))
+	if err != nil {
+		log.Printf("Ошибка получения командного зачета: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении командного зачета.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackTeams показывает командный зачет сезона (teams:seasonID)
+func (b *Bot) callbackTeams(query *tgbotapi.CallbackQuery) {
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildTeamsView(seasonID, b.IsAdmin(query.From.ID))
+	if err != nil {
+		log.Printf("Ошибка получения командного зачета: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении командного зачета", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// buildTeamAdminView формирует список команд сезона для управления
+func (b *Bot) buildTeamAdminView(seasonID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	standings, err := b.TeamRepo.GetStandings(seasonID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("⚙️ *Управление командами: %s*\n\n", b.standingsScopeName(seasonID))
+	text += fmt.Sprintf("В команде от %d до %d гонщиков, гонщик может состоять только в одной команде сезона.\n",
+		models.TeamMinMembers, models.TeamMaxMembers)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for _, standing := range standings {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s (%d/%d)", standing.Team.Label(), len(standing.Members), models.TeamMaxMembers),
+				fmt.Sprintf("team_manage:%d", standing.Team.ID),
+			),
+		))
+	}
+
+	keyboard = append(keyboard,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➕ Создать команду", fmt.Sprintf("team_create:%d", seasonID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К командному зачету", fmt.Sprintf("teams:%d", seasonID)),
+		),
+	)
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// callbackTeamAdmin показывает список команд сезона для управления (team_admin:seasonID)
+func (b *Bot) callbackTeamAdmin(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildTeamAdminView(seasonID)
+	if err != nil {
+		log.Printf("Ошибка получения команд сезона: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении команд", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackTeamCreate запрашивает название новой команды (team_create:seasonID)
+func (b *Bot) callbackTeamCreate(query *tgbotapi.CallbackQuery) {
+	userID := query.From.ID
+
+	if !b.IsAdmin(userID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	seasonID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID сезона", true)
+		return
+	}
+
+	b.StateManager.SetState(userID, "team_name", map[string]interface{}{
+		"season_id": seasonID,
+	})
+
+	b.sendMessage(query.Message.Chat.ID, "Введите название команды (от 2 до 30 символов):")
+}
+
+// handleTeamName обрабатывает ввод названия команды
+func (b *Bot) handleTeamName(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	name := strings.TrimSpace(message.Text)
+	if err := models.ValidateTeamName(name); err != nil {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ %v. Пожалуйста, введите корректное название:", err))
+		return
+	}
+
+	seasonID, _ := state.ContextData["season_id"].(int)
+
+	b.StateManager.SetState(userID, "team_badge", map[string]interface{}{
+		"season_id": seasonID,
+		"name":      name,
+	})
+
+	b.sendMessage(chatID, fmt.Sprintf("Отправьте эмодзи - значок команды, он будет показан рядом с именами гонщиков.\nОтправьте \"-\", чтобы использовать значок по умолчанию %s.", models.DefaultTeamBadge))
+}
+
+// handleTeamBadge обрабатывает ввод значка команды и создает команду
+func (b *Bot) handleTeamBadge(message *tgbotapi.Message, state models.UserState) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+
+	badge := strings.TrimSpace(message.Text)
+	if badge == "-" {
+		badge = models.DefaultTeamBadge
+	}
+
+	if err := models.ValidateTeamBadge(badge); err != nil {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ %v. Отправьте эмодзи или \"-\":", err))
+		return
+	}
+
+	seasonID, _ := state.ContextData["season_id"].(int)
+	name, _ := state.ContextData["name"].(string)
+
+	teamID, err := b.TeamRepo.Create(&models.Team{
+		SeasonID: seasonID,
+		Name:     name,
+		Badge:    badge,
+	})
+	if err != nil {
+		log.Printf("Ошибка создания команды: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при создании команды.")
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	if teamID == 0 {
+		b.sendMessage(chatID, fmt.Sprintf("⚠️ Команда '%s' уже есть в этом сезоне.", name))
+		return
+	}
+
+	text, keyboard, err := b.buildTeamManageView(teamID)
+	if err != nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		b.sendMessage(chatID, fmt.Sprintf("✅ Команда '%s' создана.", name))
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, fmt.Sprintf("✅ Команда создана!\n\n%s", text), keyboard)
+}
+
+// buildTeamManageView формирует карточку команды с кнопками управления составом
+func (b *Bot) buildTeamManageView(teamID int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	team, err := b.TeamRepo.GetByID(teamID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if team == nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("команда %d не найдена", teamID)
+	}
+
+	members, err := b.TeamRepo.GetMembers(teamID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("*%s*\n", team.Label())
+	text += fmt.Sprintf("Состав: %d/%d\n\n", len(members), models.TeamMaxMembers)
+
+	if len(members) == 0 {
+		text += "В команде пока нет гонщиков."
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	for _, member := range members {
+		text += fmt.Sprintf("• %s — %d %s\n", member.DriverName, member.Points, pointsWord(member.Points))
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("❌ %s", member.DriverName),
+				fmt.Sprintf("team_remove:%d:%d", teamID, member.DriverID),
+			),
+		))
+	}
+
+	if len(members) < models.TeamMinMembers {
+		text += fmt.Sprintf("\n⚠️ Для командного зачета нужно минимум %d гонщика.", models.TeamMinMembers)
+	}
+
+	if len(members) < models.TeamMaxMembers {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➕ Добавить гонщика", fmt.Sprintf("team_add_pick:%d", teamID)),
+		))
+	}
+
+	keyboard = append(keyboard,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить команду", fmt.Sprintf("team_delete:%d", teamID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 К списку команд", fmt.Sprintf("team_admin:%d", team.SeasonID)),
+		),
+	)
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...), nil
+}
+
+// showTeamManage обновляет сообщение карточкой команды
+func (b *Bot) showTeamManage(query *tgbotapi.CallbackQuery, teamID int) {
+	text, keyboard, err := b.buildTeamManageView(teamID)
+	if err != nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Команда не найдена", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackTeamManage показывает карточку команды для управления (team_manage:teamID)
+func (b *Bot) callbackTeamManage(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	b.showTeamManage(query, teamID)
+}
+
+// callbackTeamAddPick показывает гонщиков без команды в сезоне (team_add_pick:teamID)
+func (b *Bot) callbackTeamAddPick(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	team, err := b.TeamRepo.GetByID(teamID)
+	if err != nil || team == nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Команда не найдена", true)
+		return
+	}
+
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		log.Printf("Ошибка получения списка гонщиков: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении списка гонщиков", true)
+		return
+	}
+
+	// Гонщик может состоять только в одной команде сезона
+	teams := b.seasonDriverTeams(team.SeasonID)
+	var free []*models.Driver
+	for _, driver := range drivers {
+		if _, ok := teams[driver.ID]; !ok {
+			free = append(free, driver)
+		}
+	}
+
+	if len(free) == 0 {
+		b.answerCallbackQuery(query.ID, "ℹ️ Все гонщики уже распределены по командам", true)
+		return
+	}
+
+	keyboard := DriverPickerRows(free, fmt.Sprintf("team_add:%d", teamID))
+	keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔙 Назад", fmt.Sprintf("team_manage:%d", teamID)),
+	))
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		fmt.Sprintf("Выберите гонщика для команды *%s*:", team.Label()), tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// callbackTeamAdd добавляет гонщика в команду (team_add:teamID:driverID)
+func (b *Bot) callbackTeamAdd(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	driverID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	added, err := b.TeamRepo.AddMember(teamID, driverID)
+	if err != nil {
+		log.Printf("Ошибка добавления гонщика %d в команду %d: %v", driverID, teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при добавлении гонщика", true)
+		return
+	}
+
+	if !added {
+		b.answerCallbackQuery(query.ID, "⚠️ Команда заполнена или гонщик уже состоит в команде этого сезона", true)
+		b.showTeamManage(query, teamID)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Гонщик добавлен в команду", false)
+	b.showTeamManage(query, teamID)
+
+	// Сообщаем гонщику о зачислении в команду
+	driver, err := b.DriverRepo.GetByID(driverID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика %d: %v", driverID, err)
+		return
+	}
+
+	team, err := b.TeamRepo.GetByID(teamID)
+	if err != nil || team == nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		return
+	}
+
+	b.sendMessage(driver.TelegramID, fmt.Sprintf("👥 Вы зачислены в команду *%s*! Ваши очки в этом сезоне идут в командный зачет (/teams).", team.Label()))
+}
+
+// callbackTeamRemove исключает гонщика из команды (team_remove:teamID:driverID)
+func (b *Bot) callbackTeamRemove(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	driverID, ok := parseIDArg(query, 2)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонщика", true)
+		return
+	}
+
+	if err := b.TeamRepo.RemoveMember(teamID, driverID); err != nil {
+		log.Printf("Ошибка исключения гонщика %d из команды %d: %v", driverID, teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при исключении гонщика", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Гонщик исключен из команды", false)
+	b.showTeamManage(query, teamID)
+}
+
+// callbackTeamDelete запрашивает подтверждение удаления команды (team_delete:teamID)
+func (b *Bot) callbackTeamDelete(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	team, err := b.TeamRepo.GetByID(teamID)
+	if err != nil || team == nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Команда не найдена", true)
+		return
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Да, удалить", fmt.Sprintf("team_delete_confirm:%d", teamID)),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", fmt.Sprintf("team_manage:%d", teamID)),
+		),
+	)
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID,
+		fmt.Sprintf("Удалить команду *%s*? Результаты гонщиков сохранятся, но команда пропадет из командного зачета.", team.Label()),
+		keyboard)
+}
+
+// callbackTeamDeleteConfirm удаляет команду (team_delete_confirm:teamID)
+func (b *Bot) callbackTeamDeleteConfirm(query *tgbotapi.CallbackQuery) {
+	if !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ У вас нет прав для управления командами", true)
+		return
+	}
+
+	teamID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID команды", true)
+		return
+	}
+
+	team, err := b.TeamRepo.GetByID(teamID)
+	if err != nil || team == nil {
+		log.Printf("Ошибка получения команды %d: %v", teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Команда не найдена", true)
+		return
+	}
+
+	if err := b.TeamRepo.Delete(teamID); err != nil {
+		log.Printf("Ошибка удаления команды %d: %v", teamID, err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при удалении команды", true)
+		return
+	}
+
+	b.answerCallbackQuery(query.ID, "✅ Команда удалена", false)
+
+	text, keyboard, err := b.buildTeamAdminView(team.SeasonID)
+	if err != nil {
+		log.Printf("Ошибка получения команд сезона: %v", err)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
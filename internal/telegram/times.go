@@ -0,0 +1,183 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerTimeHandlers регистрирует обработчики рекордов по времени
+func (b *Bot) registerTimeHandlers() {
+	b.CommandHandlers["times"] = b.handleTimes
+}
+
+// parseDisciplineInput разбирает ввод результата дисциплины: место, статус DNS/DNF/DSQ или,
+// для дисциплин на время, время в формате m:ss.mmm. Для времени возвращается предварительное
+// место 1, окончательное место рассчитывается после сохранения по времени всех участников.
+func parseDisciplineInput(text, discipline string, fieldSize int) (place int, timeMs int, status string, ok bool) {
+	text = strings.TrimSpace(text)
+
+	if models.IsTimedDiscipline(discipline) && strings.Contains(text, ":") {
+		timeMs, err := models.ParseLapTime(text)
+		if err != nil {
+			return 0, 0, "", false
+		}
+		return 1, timeMs, "", true
+	}
+
+	place, status, ok = parsePlaceValue(text, fieldSize)
+	return place, 0, status, ok
+}
+
+// disciplineInputError возвращает подсказку о допустимом вводе результата дисциплины
+func disciplineInputError(discipline string, fieldSize int) string {
+	text := fmt.Sprintf("⚠️ Пожалуйста, введите число от 0 до %d (0 - не участвовал, 1-%d - место) или статус DNS, DNF, DSQ.", fieldSize, fieldSize)
+	if models.IsTimedDiscipline(discipline) {
+		text += "\nИли отправьте время в формате `m:ss.mmm`, например `1:23.456`."
+	}
+	return text
+}
+
+// disciplineTimeHint возвращает подсказку о вводе времени для дисциплин на время
+func disciplineTimeHint(discipline string) string {
+	if !models.IsTimedDiscipline(discipline) {
+		return ""
+	}
+	return "\n\n⏱ Можно отправить время в формате `m:ss.mmm` — место рассчитается автоматически."
+}
+
+// stateTimes возвращает карту времен из контекста состояния ввода результата
+func stateTimes(state models.UserState) map[string]int {
+	if times, ok := state.ContextData["times"].(map[string]int); ok {
+		return times
+	}
+	return make(map[string]int)
+}
+
+// formatDisciplineResult форматирует место или статус в дисциплине и время, если оно указано
+func formatDisciplineResult(result *models.RaceResult, discipline string) string {
+	if _, ok := result.Results[discipline]; !ok && models.IsJudgedDiscipline(discipline) {
+		return "🗳 ожидает голосования"
+	}
+
+	text := getResultText(result.Results[discipline], result.Statuses[discipline])
+	if t, ok := result.Times[discipline]; ok {
+		text += fmt.Sprintf(" (⏱ %s)", models.FormatLapTime(t))
+	}
+	return text
+}
+
+// finalizeResultPlaces пересчитывает места гонки по времени и по голосованию в судейских
+// дисциплинах после сохранения результата и возвращает актуальный счет сохраненного результата
+func (b *Bot) finalizeResultPlaces(resultID int, result *models.RaceResult) int {
+	if len(result.Times) > 0 {
+		if _, err := b.ResultRepo.ApplyTimedPlaces(result.RaceID, b.getScoringRules(result.RaceID)); err != nil {
+			log.Printf("Ошибка пересчета мест по времени для гонки %d: %v", result.RaceID, err)
+		}
+	}
+
+	// Место в судейской дисциплине гонщик не вводит, оно берется из итогов голосования
+	b.applyJudgedPlaces(result.RaceID)
+
+	updated, err := b.ResultRepo.GetByID(resultID)
+	if err != nil || updated == nil {
+		return result.TotalScore
+	}
+
+	return updated.TotalScore
+}
+
+// handleTimes обрабатывает команду /times [класс] - рекорды и личные рекорды по времени
+func (b *Bot) handleTimes(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := message.From.ID
+
+	carClass := strings.ToUpper(strings.TrimSpace(message.CommandArguments()))
+
+	records, err := b.ResultRepo.GetTimeRecords(0)
+	if err != nil {
+		log.Printf("Ошибка получения рекордов: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении рекордов.")
+		return
+	}
+
+	text := "⏱ *Рекорды по времени*"
+	if carClass != "" {
+		text += fmt.Sprintf(" (класс %s)", carClass)
+	}
+	text += "\n\n"
+
+	records = filterTimeRecords(records, carClass)
+	if len(records) == 0 {
+		text += "Пока нет ни одного результата со временем.\n"
+	} else {
+		text += formatTimeRecords(records, true)
+	}
+
+	// Личные рекорды гонщика
+	driver, err := b.DriverRepo.GetByTelegramID(userID)
+	if err == nil && driver != nil {
+		personal, err := b.ResultRepo.GetTimeRecords(driver.ID)
+		if err != nil {
+			log.Printf("Ошибка получения личных рекордов: %v", err)
+		} else if personal = filterTimeRecords(personal, carClass); len(personal) > 0 {
+			text += "\n👤 *Ваши личные рекорды:*\n\n"
+			text += formatTimeRecords(personal, false)
+		}
+	}
+
+	text += "\nФильтр по классу: `/times A`"
+
+	b.sendMessage(chatID, text)
+}
+
+// filterTimeRecords оставляет рекорды указанного класса (пустой класс - все)
+func filterTimeRecords(records []*models.TimeRecord, carClass string) []*models.TimeRecord {
+	if carClass == "" {
+		return records
+	}
+
+	var filtered []*models.TimeRecord
+	for _, record := range records {
+		if strings.EqualFold(record.CarClass, carClass) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// formatTimeRecords форматирует рекорды, сгруппированные по дисциплинам
+func formatTimeRecords(records []*models.TimeRecord, withDriver bool) string {
+	byDiscipline := make(map[string][]*models.TimeRecord)
+	var disciplines []string
+
+	for _, record := range records {
+		if _, exists := byDiscipline[record.Discipline]; !exists {
+			disciplines = append(disciplines, record.Discipline)
+		}
+		byDiscipline[record.Discipline] = append(byDiscipline[record.Discipline], record)
+	}
+
+	sort.Strings(disciplines)
+
+	var text string
+	for _, discipline := range disciplines {
+		text += fmt.Sprintf("*%s:*\n", discipline)
+
+		for _, record := range byDiscipline[discipline] {
+			text += fmt.Sprintf("• %s: *%s*", record.CarClass, models.FormatLapTime(record.TimeMs))
+			if withDriver {
+				text += fmt.Sprintf(" — %s", record.DriverName)
+			}
+			text += fmt.Sprintf(" (%s, %s)\n", record.CarName, record.RaceName)
+		}
+
+		text += "\n"
+	}
+
+	return text
+}
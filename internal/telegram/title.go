@@ -0,0 +1,269 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// registerTitleHandlers регистрирует обработчики борьбы за титул
+func (b *Bot) registerTitleHandlers() {
+	b.CommandHandlers["title"] = b.handleTitle
+	b.CallbackHandlers["title"] = b.callbackTitle
+}
+
+// calculateTitleRace считает борьбу за титул сезона по текущей таблице и незавершенным гонкам
+func (b *Bot) calculateTitleRace(seasonID int) (*models.TitleRace, error) {
+	races, err := b.RaceRepo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := b.ScoringRepo.GetBySeasonID(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Максимум очков за гонку - победа во всех ее дисциплинах
+	var remaining []*models.RemainingRace
+	completed := make(map[int]*models.Race)
+	for _, race := range races {
+		if race.State == models.RaceStateCompleted || race.Completed {
+			completed[race.ID] = race
+			continue
+		}
+
+		division, err := b.DivisionRepo.GetRaceDivision(race.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		pending := &models.RemainingRace{
+			RaceID:    race.ID,
+			Name:      race.Name,
+			MaxPoints: rules.MaxRacePoints(len(race.Disciplines)),
+		}
+		if division != nil {
+			pending.DivisionID = division.ID
+		}
+		remaining = append(remaining, pending)
+	}
+
+	driverDivisions, err := b.DivisionRepo.GetDriverDivisions(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	divisions := make(map[int]int, len(driverDivisions))
+	for driverID, division := range driverDivisions {
+		divisions[driverID] = division.ID
+	}
+
+	entries, _, err := b.getFinalStandings(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	drivers, err := b.DriverRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// В борьбе участвуют и гонщики, еще не набравшие очков
+	points := make(map[int]int, len(entries))
+	for _, entry := range entries {
+		points[entry.DriverID] = entry.Points
+	}
+
+	// Неподтвержденный результат завершенной гонки не входит в таблицу, но после проверки
+	// может принести гонщику до максимума очков за гонку, поэтому титул до этого не решен
+	pendingResults, err := b.ResultRepo.GetPending(0)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingPoints := make(map[int]int)
+	for _, result := range pendingResults {
+		if race, ok := completed[result.RaceID]; ok {
+			pendingPoints[result.DriverID] += rules.MaxRacePoints(len(race.Disciplines))
+		}
+	}
+
+	contenders := make([]*models.TitleContender, 0, len(drivers))
+	for _, driver := range drivers {
+		contenders = append(contenders, &models.TitleContender{
+			DriverID:      driver.ID,
+			DriverName:    driver.Name,
+			Points:        points[driver.ID],
+			PendingPoints: pendingPoints[driver.ID],
+		})
+	}
+
+	return models.CalculateTitleRace(contenders, remaining, divisions), nil
+}
+
+// buildTitleView формирует расклад борьбы за титул сезона
+func (b *Bot) buildTitleView(season *models.Season) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	title, err := b.calculateTitleRace(season.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	text := fmt.Sprintf("🏆 *Борьба за титул: %s*\n\n", season.Name)
+	text += fmt.Sprintf("Осталось гонок: %d (до %d %s за все)\n\n", title.RemainingRaces,
+		title.RemainingMax, pointsWord(title.RemainingMax))
+
+	if title.Champion != nil {
+		text += fmt.Sprintf("👑 *%s* обеспечил титул: догнать лидера уже невозможно!\n\n", title.Champion.DriverName)
+	}
+
+	hidden := 0
+	pending := false
+	for i, contender := range title.Contenders {
+		// Выбывшие гонщики без очков только удлиняют список
+		if contender.Eliminated && contender.Points == 0 {
+			hidden++
+			continue
+		}
+
+		mark := "✅"
+		switch {
+		case i == 0 && title.Champion != nil:
+			mark = "👑"
+		case contender.Eliminated:
+			mark = "❌"
+		}
+
+		text += fmt.Sprintf("%s *%s* — %d %s, максимум %d", mark, contender.DriverName,
+			contender.Points, pointsWord(contender.Points), contender.MaxPoints)
+		if contender.RemainingRaces < title.RemainingRaces {
+			text += fmt.Sprintf(" (доступно гонок: %d)", contender.RemainingRaces)
+		}
+		if contender.PendingPoints > 0 {
+			text += " ⏳"
+			pending = true
+		}
+		text += "\n"
+	}
+
+	if hidden > 0 {
+		text += fmt.Sprintf("_...и еще %d без очков и шансов_\n", hidden)
+	}
+
+	text += "\n✅ — еще может стать чемпионом, ❌ — математически выбыл.\n"
+	text += "Максимум считается по победам во всех дисциплинах оставшихся гонок, доступных гонщику."
+	if pending {
+		text += "\n⏳ — у гонщика есть неподтвержденные результаты, они тоже учтены в максимуме."
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить", "title"),
+			tgbotapi.NewInlineKeyboardButtonData("🏆 Таблица", fmt.Sprintf("leaderboard:%d", season.ID)),
+		),
+	)
+
+	return text, keyboard, nil
+}
+
+// handleTitle обрабатывает команду /title - математика борьбы за титул активного сезона
+func (b *Bot) handleTitle(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активного сезона.")
+		return
+	}
+
+	if season == nil {
+		b.sendMessage(chatID, "⚠️ Нет активного сезона.")
+		return
+	}
+
+	text, keyboard, err := b.buildTitleView(season)
+	if err != nil {
+		log.Printf("Ошибка расчета борьбы за титул: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при расчете борьбы за титул.")
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackTitle обновляет расклад борьбы за титул активного сезона
+func (b *Bot) callbackTitle(query *tgbotapi.CallbackQuery) {
+	season, err := b.SeasonRepo.GetActive()
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения активного сезона: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Нет активного сезона", true)
+		return
+	}
+
+	text, keyboard, err := b.buildTitleView(season)
+	if err != nil {
+		log.Printf("Ошибка расчета борьбы за титул: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при расчете борьбы за титул", true)
+		return
+	}
+
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// checkTitleClinch проверяет после завершенной гонки, обеспечил ли лидер титул активного сезона,
+// и один раз сообщает об этом всем гонщикам
+func (b *Bot) checkTitleClinch(raceID int) {
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		log.Printf("Ошибка получения гонки %d для проверки титула: %v", raceID, err)
+		return
+	}
+
+	if race.State != models.RaceStateCompleted || race.SeasonID == 0 {
+		return
+	}
+
+	season, err := b.SeasonRepo.GetByID(race.SeasonID)
+	if err != nil || season == nil {
+		log.Printf("Ошибка получения сезона %d для проверки титула: %v", race.SeasonID, err)
+		return
+	}
+
+	if !season.Active {
+		return
+	}
+
+	title, err := b.calculateTitleRace(season.ID)
+	if err != nil {
+		log.Printf("Ошибка расчета борьбы за титул сезона %d: %v", season.ID, err)
+		return
+	}
+
+	if title.Champion == nil {
+		return
+	}
+
+	marked, err := b.StandingsRepo.MarkTitleClinched(season.ID, title.Champion.DriverID, raceID)
+	if err != nil {
+		log.Printf("Ошибка сохранения досрочного титула сезона %d: %v", season.ID, err)
+		return
+	}
+
+	if !marked {
+		return
+	}
+
+	log.Printf("Гонщик %d обеспечил титул сезона %d после гонки %d", title.Champion.DriverID, season.ID, raceID)
+
+	text := fmt.Sprintf("👑 *%s* обеспечил титул чемпиона сезона '%s' по итогам гонки '%s'!",
+		title.Champion.DriverName, season.Name, race.Name)
+	if title.RemainingRaces > 0 {
+		text += fmt.Sprintf("\n\nДо конца сезона осталось гонок: %d, но догнать лидера уже невозможно. Расклад - в /title.",
+			title.RemainingRaces)
+	}
+
+	b.broadcastToDrivers(text)
+}
@@ -0,0 +1,657 @@
+package telegram
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/athebyme/forza-top-gear-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// voteCandidate участник гонки в бюллетене судейской дисциплины
+type voteCandidate struct {
+	DriverID  int
+	CarNumber int
+	CarName   string
+	PhotoURL  string
+}
+
+// label возвращает подпись участника в бюллетене без имени гонщика
+func (c voteCandidate) label() string {
+	return fmt.Sprintf("№%d %s", c.CarNumber, c.CarName)
+}
+
+// registerVoteHandlers регистрирует обработчики голосования в судейских дисциплинах
+func (b *Bot) registerVoteHandlers() {
+	b.CommandHandlers["vote"] = b.handleVote
+
+	b.CallbackHandlers["vote"] = b.callbackVote
+	b.CallbackHandlers["vote_pick"] = b.callbackVotePick
+	b.CallbackHandlers["vote_reset"] = b.callbackVoteReset
+	b.CallbackHandlers["vote_submit"] = b.callbackVoteSubmit
+	b.CallbackHandlers["vote_status"] = b.callbackVoteStatus
+}
+
+// judgedDisciplines возвращает судейские дисциплины гонки
+func judgedDisciplines(race *models.Race) []string {
+	var disciplines []string
+	for _, discipline := range race.Disciplines {
+		if models.IsJudgedDiscipline(discipline) {
+			disciplines = append(disciplines, discipline)
+		}
+	}
+	return disciplines
+}
+
+// selfReportedDisciplines возвращает дисциплины, место в которых гонщик указывает сам
+func selfReportedDisciplines(disciplines []string) []string {
+	var result []string
+	for _, discipline := range disciplines {
+		if !models.IsJudgedDiscipline(discipline) {
+			result = append(result, discipline)
+		}
+	}
+	return result
+}
+
+// judgedDisciplinesNote возвращает пояснение о судейских дисциплинах для начала ввода результата
+func judgedDisciplinesNote(race *models.Race) string {
+	judged := judgedDisciplines(race)
+	if len(judged) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("🗳 Места в дисциплинах %s определяются голосованием участников (/vote).\n\n", strings.Join(judged, ", "))
+}
+
+// applyJudgedPlaces пересчитывает места гонки в судейских дисциплинах по итогам голосования
+func (b *Bot) applyJudgedPlaces(raceID int) {
+	if _, err := b.VoteRepo.ApplyJudgedPlaces(raceID, b.getScoringRules(raceID)); err != nil {
+		log.Printf("Ошибка пересчета мест по голосованию для гонки %d: %v", raceID, err)
+	}
+}
+
+// submitJudgedOnlyResult сохраняет результат гонки, все дисциплины которой судейские:
+// вводить гонщику нечего, места появятся по итогам голосования
+func (b *Bot) submitJudgedOnlyResult(chatID, userID int64, race *models.Race, carNumber int, carName, carPhoto string) {
+	b.StateManager.ClearState(userID)
+
+	driver, err := b.DriverRepo.GetByTelegramID(userID)
+	if err != nil || driver == nil {
+		log.Printf("Ошибка получения гонщика: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении данных гонщика.")
+		return
+	}
+
+	rules := b.getScoringRules(race.ID)
+
+	rerollUsed, err := b.ResultRepo.GetDriverRerollStatus(race.ID, driver.ID)
+	if err != nil {
+		log.Printf("Ошибка проверки статуса реролла: %v", err)
+	}
+
+	rerollPenalty := 0
+	if rerollUsed {
+		rerollPenalty = rules.RerollPenalty
+	}
+
+	result := &models.RaceResult{
+		RaceID:             race.ID,
+		DriverID:           driver.ID,
+		CarNumber:          carNumber,
+		CarName:            carName,
+		CarPhotoURL:        carPhoto,
+		Results:            make(map[string]int),
+		Statuses:           make(map[string]string),
+		TotalScore:         rules.CalculateTotalScore(nil, nil, rerollPenalty),
+		RerollPenalty:      rerollPenalty,
+		ConfirmationStatus: models.ResultStatusPending,
+	}
+
+	var resultID int
+	if rerollPenalty > 0 {
+		resultID, err = b.ResultRepo.CreateWithRerollPenalty(result)
+	} else {
+		resultID, err = b.ResultRepo.Create(result)
+	}
+
+	if err != nil {
+		log.Printf("Ошибка сохранения результата: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при сохранении результатов.")
+		return
+	}
+
+	b.finalizeResultPlaces(resultID, result)
+
+	b.sendMessage(chatID, judgedDisciplinesNote(race)+"✅ Участие записано: вводить места в этой гонке не нужно.")
+	b.onResultSubmitted(chatID, resultID)
+}
+
+// checkVoteAllowed проверяет, может ли гонщик голосовать в дисциплине гонки.
+// Возвращает текст причины отказа или пустую строку.
+func (b *Bot) checkVoteAllowed(race *models.Race, discipline string, driverID int) (string, error) {
+	if race.State != models.RaceStateInProgress {
+		return "⚠️ Голосование открыто только во время гонки", nil
+	}
+
+	if !models.IsJudgedDiscipline(discipline) || !containsString(race.Disciplines, discipline) {
+		return "⚠️ В этой дисциплине нет голосования", nil
+	}
+
+	participants, err := b.VoteRepo.GetParticipants(race.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if !containsInt(participants, driverID) {
+		return "⚠️ Голосовать могут только участники гонки", nil
+	}
+
+	voted, err := b.VoteRepo.HasVoted(race.ID, discipline, driverID)
+	if err != nil {
+		return "", err
+	}
+
+	if voted {
+		return "⚠️ Вы уже голосовали в этой дисциплине", nil
+	}
+
+	return "", nil
+}
+
+// getVoteCandidates возвращает участников гонки с их машинами, кроме голосующего
+func (b *Bot) getVoteCandidates(raceID, voterID int) ([]voteCandidate, error) {
+	registrations, err := b.RaceRepo.GetRegisteredDrivers(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []voteCandidate
+	for _, reg := range registrations {
+		if reg.DriverID == voterID {
+			continue
+		}
+
+		candidate := voteCandidate{DriverID: reg.DriverID, CarName: "машина не назначена"}
+
+		assignment, err := b.CarRepo.GetDriverCarAssignment(raceID, reg.DriverID)
+		if err != nil {
+			log.Printf("Ошибка получения машины гонщика %d: %v", reg.DriverID, err)
+		}
+		if assignment != nil && assignment.Car != nil {
+			candidate.CarNumber = assignment.AssignmentNumber
+			candidate.CarName = fmt.Sprintf("%s (%s)", assignment.Car.Name, assignment.Car.Year)
+			candidate.PhotoURL = assignment.Car.ImageURL
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// sendBallotInvitation приглашает гонщика проголосовать в судейских дисциплинах гонки
+func (b *Bot) sendBallotInvitation(chatID int64, race *models.Race) {
+	judged := judgedDisciplines(race)
+	if len(judged) == 0 {
+		return
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	for _, discipline := range judged {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🗳 Голосовать: %s", discipline),
+				fmt.Sprintf("vote:%d:%s", race.ID, discipline),
+			),
+		))
+	}
+
+	text := fmt.Sprintf("🗳 *Голосование в гонке '%s'*\n\n", race.Name)
+	text += fmt.Sprintf("Места в дисциплинах %s определяют участники: расставьте машины соперников от лучшей к худшей. "+
+		"За себя голосовать нельзя, бюллетень отправляется один раз.", strings.Join(judged, ", "))
+
+	b.sendMessageWithKeyboard(chatID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// handleVote обрабатывает команду /vote - открытые бюллетени гонщика в текущей гонке
+func (b *Bot) handleVote(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+
+	driver, err := b.DriverRepo.GetByTelegramID(message.From.ID)
+	if err != nil {
+		log.Printf("Ошибка получения гонщика: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении данных гонщика.")
+		return
+	}
+
+	if driver == nil {
+		b.sendMessage(chatID, "⚠️ Вы не зарегистрированы как гонщик. Используйте /register.")
+		return
+	}
+
+	race, err := b.RaceRepo.GetActiveRace()
+	if err != nil {
+		log.Printf("Ошибка получения активной гонки: %v", err)
+		b.sendMessage(chatID, "⚠️ Произошла ошибка при получении активной гонки.")
+		return
+	}
+
+	if race == nil || len(judgedDisciplines(race)) == 0 {
+		b.sendMessage(chatID, "🗳 Сейчас нет открытых голосований.")
+		return
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+	text := fmt.Sprintf("🗳 *Голосование в гонке '%s'*\n\n", race.Name)
+
+	for _, discipline := range judgedDisciplines(race) {
+		reason, err := b.checkVoteAllowed(race, discipline, driver.ID)
+		if err != nil {
+			log.Printf("Ошибка проверки бюллетеня: %v", err)
+			b.sendMessage(chatID, "⚠️ Произошла ошибка при проверке голосования.")
+			return
+		}
+
+		if reason != "" {
+			text += fmt.Sprintf("• %s: %s\n", discipline, strings.TrimPrefix(reason, "⚠️ "))
+			continue
+		}
+
+		text += fmt.Sprintf("• %s: ожидает вашего голоса\n", discipline)
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🗳 Голосовать: %s", discipline),
+				fmt.Sprintf("vote:%d:%s", race.ID, discipline),
+			),
+		))
+	}
+
+	if len(keyboard) == 0 {
+		b.sendMessage(chatID, text)
+		return
+	}
+
+	b.sendMessageWithKeyboard(chatID, text, tgbotapi.NewInlineKeyboardMarkup(keyboard...))
+}
+
+// buildBallotView формирует сообщение бюллетеня с уже расставленными и оставшимися участниками
+func buildBallotView(raceID int, discipline string, candidates []voteCandidate, ranking []int) (string, tgbotapi.InlineKeyboardMarkup) {
+	byID := make(map[int]voteCandidate, len(candidates))
+	for _, candidate := range candidates {
+		byID[candidate.DriverID] = candidate
+	}
+
+	text := fmt.Sprintf("🗳 *Бюллетень: %s*\n\n", discipline)
+
+	if len(ranking) > 0 {
+		text += "*Ваш порядок:*\n"
+		for i, driverID := range ranking {
+			text += fmt.Sprintf("%s %s\n", getPlaceEmoji(i+1), byID[driverID].label())
+		}
+		text += "\n"
+	}
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	if len(ranking) < len(candidates) {
+		text += fmt.Sprintf("Выберите машину на %d место:", len(ranking)+1)
+
+		for _, candidate := range candidates {
+			if containsInt(ranking, candidate.DriverID) {
+				continue
+			}
+
+			keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					candidate.label(),
+					fmt.Sprintf("vote_pick:%d:%d", raceID, candidate.DriverID),
+				),
+			))
+		}
+	} else {
+		text += "Все участники расставлены. Отправьте бюллетень — изменить его будет нельзя."
+
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Отправить бюллетень", fmt.Sprintf("vote_submit:%d", raceID)),
+		))
+	}
+
+	if len(ranking) > 0 {
+		keyboard = append(keyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("↩️ Начать заново", fmt.Sprintf("vote_reset:%d", raceID)),
+		))
+	}
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(keyboard...)
+}
+
+// getBallotState возвращает состояние заполняемого бюллетеня для гонки raceID
+func (b *Bot) getBallotState(userID int64, raceID int) (models.UserState, bool) {
+	state, exists := b.StateManager.GetState(userID)
+	if !exists || state.State != "vote_ballot" || state.ContextData["race_id"] != raceID {
+		return models.UserState{}, false
+	}
+	return state, true
+}
+
+// callbackVote открывает бюллетень судейской дисциплины (vote:raceID:discipline)
+func (b *Bot) callbackVote(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	raceID, ok := parseIDArg(query, 1)
+	parts := strings.SplitN(query.Data, ":", 3)
+	if !ok || len(parts) < 3 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+	discipline := parts[2]
+
+	driver, err := b.DriverRepo.GetByTelegramID(userID)
+	if err != nil || driver == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Вы не зарегистрированы как гонщик", true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	reason, err := b.checkVoteAllowed(race, discipline, driver.ID)
+	if err != nil {
+		log.Printf("Ошибка проверки бюллетеня: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при проверке голосования", true)
+		return
+	}
+
+	if reason != "" {
+		b.answerCallbackQuery(query.ID, reason, true)
+		return
+	}
+
+	candidates, err := b.getVoteCandidates(raceID, driver.ID)
+	if err != nil {
+		log.Printf("Ошибка получения участников голосования: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении участников", true)
+		return
+	}
+
+	if len(candidates) == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ В гонке нет других участников для голосования", true)
+		return
+	}
+
+	// Показываем машины соперников без имен гонщиков
+	for _, candidate := range candidates {
+		if candidate.PhotoURL != "" {
+			b.sendPhoto(chatID, candidate.PhotoURL, candidate.label())
+		} else {
+			b.sendMessage(chatID, fmt.Sprintf("🚗 %s (фото нет)", candidate.label()))
+		}
+	}
+
+	b.StateManager.SetState(userID, "vote_ballot", map[string]interface{}{
+		"race_id":    raceID,
+		"discipline": discipline,
+		"voter_id":   driver.ID,
+		"candidates": candidates,
+		"ranking":    []int{},
+	})
+
+	text, keyboard := buildBallotView(raceID, discipline, candidates, nil)
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// callbackVotePick ставит участника на следующее место бюллетеня (vote_pick:raceID:driverID)
+func (b *Bot) callbackVotePick(query *tgbotapi.CallbackQuery) {
+	raceID, ok := parseIDArg(query, 1)
+	driverID, ok2 := parseIDArg(query, 2)
+	if !ok || !ok2 {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	state, ok := b.getBallotState(query.From.ID, raceID)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Бюллетень устарел, откройте его заново через /vote", true)
+		return
+	}
+
+	discipline := state.ContextData["discipline"].(string)
+	candidates := state.ContextData["candidates"].([]voteCandidate)
+	ranking := state.ContextData["ranking"].([]int)
+
+	isCandidate := false
+	for _, candidate := range candidates {
+		if candidate.DriverID == driverID {
+			isCandidate = true
+			break
+		}
+	}
+
+	if !isCandidate || containsInt(ranking, driverID) {
+		b.answerCallbackQuery(query.ID, "⚠️ Этот участник уже расставлен", true)
+		return
+	}
+
+	ranking = append(ranking, driverID)
+	state.ContextData["ranking"] = ranking
+	b.StateManager.SetState(query.From.ID, "vote_ballot", state.ContextData)
+
+	text, keyboard := buildBallotView(raceID, discipline, candidates, ranking)
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackVoteReset очищает порядок в бюллетене (vote_reset:raceID)
+func (b *Bot) callbackVoteReset(query *tgbotapi.CallbackQuery) {
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	state, ok := b.getBallotState(query.From.ID, raceID)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Бюллетень устарел, откройте его заново через /vote", true)
+		return
+	}
+
+	state.ContextData["ranking"] = []int{}
+	b.StateManager.SetState(query.From.ID, "vote_ballot", state.ContextData)
+
+	discipline := state.ContextData["discipline"].(string)
+	candidates := state.ContextData["candidates"].([]voteCandidate)
+
+	text, keyboard := buildBallotView(raceID, discipline, candidates, nil)
+	b.editMessageWithKeyboard(query.Message.Chat.ID, query.Message.MessageID, text, keyboard)
+}
+
+// callbackVoteSubmit проверяет и сохраняет бюллетень, затем пересчитывает места (vote_submit:raceID)
+func (b *Bot) callbackVoteSubmit(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+	userID := query.From.ID
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный формат запроса", true)
+		return
+	}
+
+	state, ok := b.getBallotState(userID, raceID)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Бюллетень устарел, откройте его заново через /vote", true)
+		return
+	}
+
+	discipline := state.ContextData["discipline"].(string)
+	voterID := state.ContextData["voter_id"].(int)
+	ranking := state.ContextData["ranking"].([]int)
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	reason, err := b.checkVoteAllowed(race, discipline, voterID)
+	if err != nil {
+		log.Printf("Ошибка проверки бюллетеня: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при проверке голосования", true)
+		return
+	}
+
+	if reason != "" {
+		b.StateManager.ClearState(userID)
+		b.answerCallbackQuery(query.ID, reason, true)
+		return
+	}
+
+	// Состав участников мог измениться после открытия бюллетеня
+	participants, err := b.VoteRepo.GetParticipants(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения участников голосования: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сохранении бюллетеня", true)
+		return
+	}
+
+	if err := models.ValidateBallot(voterID, ranking, participants); err != nil {
+		b.StateManager.ClearState(userID)
+		b.answerCallbackQuery(query.ID, "⚠️ Бюллетень недействителен: "+err.Error()+". Откройте его заново через /vote", true)
+		return
+	}
+
+	ballotID, err := b.VoteRepo.Create(&models.Ballot{
+		RaceID:     raceID,
+		Discipline: discipline,
+		VoterID:    voterID,
+		Ranking:    ranking,
+	})
+	if err != nil {
+		log.Printf("Ошибка сохранения бюллетеня: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при сохранении бюллетеня", true)
+		return
+	}
+
+	b.StateManager.ClearState(userID)
+
+	if ballotID == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ Вы уже голосовали в этой дисциплине", true)
+		return
+	}
+
+	b.applyJudgedPlaces(raceID)
+
+	b.answerCallbackQuery(query.ID, "✅ Голос учтен", false)
+	b.editMessage(chatID, query.Message.MessageID,
+		fmt.Sprintf("✅ *Ваш бюллетень в дисциплине %s учтен.*\n\nМеста будут пересчитываться по мере голосования остальных участников.", discipline))
+}
+
+// callbackVoteStatus показывает ход голосования гонки (vote_status:raceID).
+// Во время гонки доступен только администраторам, после завершения - всем.
+func (b *Bot) callbackVoteStatus(query *tgbotapi.CallbackQuery) {
+	chatID := query.Message.Chat.ID
+
+	raceID, ok := parseIDArg(query, 1)
+	if !ok {
+		b.answerCallbackQuery(query.ID, "⚠️ Неверный ID гонки", true)
+		return
+	}
+
+	race, err := b.RaceRepo.GetByID(raceID)
+	if err != nil || race == nil {
+		b.answerCallbackQuery(query.ID, "⚠️ Гонка не найдена", true)
+		return
+	}
+
+	if race.State != models.RaceStateCompleted && !b.IsAdmin(query.From.ID) {
+		b.answerCallbackQuery(query.ID, "⛔ Итоги голосования будут доступны после завершения гонки", true)
+		return
+	}
+
+	if len(judgedDisciplines(race)) == 0 {
+		b.answerCallbackQuery(query.ID, "⚠️ В этой гонке нет судейских дисциплин", true)
+		return
+	}
+
+	registrations, err := b.RaceRepo.GetRegisteredDrivers(raceID)
+	if err != nil {
+		log.Printf("Ошибка получения участников гонки: %v", err)
+		b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении участников", true)
+		return
+	}
+
+	names := make(map[int]string, len(registrations))
+	participants := make([]int, 0, len(registrations))
+	for _, reg := range registrations {
+		names[reg.DriverID] = reg.DriverName
+		participants = append(participants, reg.DriverID)
+	}
+
+	text := fmt.Sprintf("🗳 *Голосование: %s*\n\n", race.Name)
+
+	for _, discipline := range judgedDisciplines(race) {
+		ballots, err := b.VoteRepo.GetByRace(raceID, discipline)
+		if err != nil {
+			log.Printf("Ошибка получения бюллетеней: %v", err)
+			b.answerCallbackQuery(query.ID, "⚠️ Произошла ошибка при получении бюллетеней", true)
+			return
+		}
+
+		voted := make(map[int]bool, len(ballots))
+		for _, ballot := range ballots {
+			voted[ballot.VoterID] = true
+		}
+
+		text += fmt.Sprintf("*%s* — бюллетеней: %d из %d\n", discipline, len(ballots), len(participants))
+
+		if len(ballots) > 0 {
+			for _, tally := range models.TallyBallots(ballots, participants) {
+				text += fmt.Sprintf("%s %s — %.2f (первых мест: %d)\n",
+					getPlaceEmoji(tally.Place), names[tally.DriverID], tally.AverageScore(), tally.FirstPlaces)
+			}
+		}
+
+		var waiting []string
+		for _, id := range participants {
+			if !voted[id] {
+				waiting = append(waiting, names[id])
+			}
+		}
+		if len(waiting) > 0 && race.State == models.RaceStateInProgress {
+			text += fmt.Sprintf("⏳ Не проголосовали: %s\n", strings.Join(waiting, ", "))
+		}
+
+		text += "\n"
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔙 Назад к гонке", fmt.Sprintf("race_details:%d", raceID)),
+		),
+	)
+
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// containsInt проверяет наличие числа в срезе
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString проверяет наличие строки в срезе
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}